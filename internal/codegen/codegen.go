@@ -0,0 +1,327 @@
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"postOffice/internal/postman"
+	"strconv"
+	"strings"
+)
+
+type Target struct {
+	Name  string
+	Label string
+}
+
+var targets = []Target{
+	{Name: "curl", Label: "cURL"},
+	{Name: "httpie", Label: "HTTPie"},
+	{Name: "go", Label: "Go (net/http)"},
+	{Name: "python", Label: "Python (requests)"},
+	{Name: "fetch", Label: "JavaScript (fetch)"},
+}
+
+type resolvedRequest struct {
+	method   string
+	url      string
+	headers  []postman.Header
+	body     string
+	formData []postman.FormParam
+}
+
+func Targets() []Target {
+	return append([]Target{}, targets...)
+}
+
+func Generate(target string, req *postman.Request, variables []postman.VariableSource) (string, error) {
+	if req == nil {
+		return "", fmt.Errorf("request cannot be nil")
+	}
+
+	resolved := resolveRequest(req, variables)
+
+	switch strings.ToLower(target) {
+	case "curl":
+		return generateCurl(resolved), nil
+	case "httpie", "http":
+		return generateHTTPie(resolved), nil
+	case "go":
+		return generateGo(resolved), nil
+	case "python", "py":
+		return generatePython(resolved), nil
+	case "fetch", "js", "javascript":
+		return generateFetch(resolved), nil
+	default:
+		return "", fmt.Errorf("unknown snippet target: %s", target)
+	}
+}
+
+func resolveRequest(req *postman.Request, variables []postman.VariableSource) resolvedRequest {
+	resolved := resolvedRequest{
+		method: strings.ToUpper(req.Method),
+		url:    postman.ResolveVariables(req.URL.Raw, variables),
+	}
+	if resolved.method == "" {
+		resolved.method = "GET"
+	}
+	if req.URL.Raw == "" && len(req.URL.Host) > 0 {
+		resolved.url = postman.ResolveVariables("https://"+strings.Join(req.URL.Host, ".")+"/"+strings.Join(req.URL.Path, "/"), variables)
+	}
+
+	isFormData := req.Body != nil && req.Body.Mode == "formdata" && len(req.Body.FormData) > 0
+
+	for _, header := range req.Header {
+		if isFormData && strings.EqualFold(header.Key, "Content-Type") {
+			continue
+		}
+		resolved.headers = append(resolved.headers, postman.Header{
+			Key:   header.Key,
+			Value: postman.ResolveVariables(header.Value, variables),
+		})
+	}
+
+	if isFormData {
+		for _, param := range req.Body.FormData {
+			resolved.formData = append(resolved.formData, postman.FormParam{
+				Key:   postman.ResolveVariables(param.Key, variables),
+				Value: postman.ResolveVariables(param.Value, variables),
+				Type:  param.Type,
+				Src:   postman.ResolveVariables(param.Src, variables),
+			})
+		}
+	} else if req.Body != nil {
+		resolved.body = postman.ResolveVariables(req.Body.Raw, variables)
+	}
+
+	return resolved
+}
+
+func generateCurl(req resolvedRequest) string {
+	var parts []string
+	if req.method != "GET" || req.body != "" {
+		parts = append(parts, "curl -X "+req.method+" "+shellQuote(req.url))
+	} else {
+		parts = append(parts, "curl "+shellQuote(req.url))
+	}
+
+	for _, header := range req.headers {
+		parts = append(parts, "-H "+shellQuote(header.Key+": "+header.Value))
+	}
+	for _, param := range req.formData {
+		if param.Type == "file" {
+			parts = append(parts, "-F "+shellQuote(param.Key+"=@"+param.Src))
+		} else {
+			parts = append(parts, "-F "+shellQuote(param.Key+"="+param.Value))
+		}
+	}
+	if req.body != "" {
+		parts = append(parts, "--data-raw "+shellQuote(req.body))
+	}
+
+	return strings.Join(parts, " \\\n  ")
+}
+
+func generateHTTPie(req resolvedRequest) string {
+	var parts []string
+	command := "http"
+	if len(req.formData) > 0 {
+		command += " --multipart"
+	}
+	if req.body != "" {
+		command += " --raw " + shellQuote(req.body)
+	}
+	parts = append(parts, command+" "+req.method+" "+shellQuote(req.url))
+
+	for _, header := range req.headers {
+		parts = append(parts, shellQuote(header.Key+":"+header.Value))
+	}
+	for _, param := range req.formData {
+		if param.Type == "file" {
+			parts = append(parts, shellQuote(param.Key+"@"+param.Src))
+		} else {
+			parts = append(parts, shellQuote(param.Key+"="+param.Value))
+		}
+	}
+
+	return strings.Join(parts, " \\\n  ")
+}
+
+func generateGo(req resolvedRequest) string {
+	imports := []string{"fmt", "io", "net/http"}
+	var body strings.Builder
+	bodyArg := "nil"
+
+	switch {
+	case len(req.formData) > 0:
+		imports = []string{"bytes", "fmt", "io", "mime/multipart", "net/http"}
+		hasFile := false
+		body.WriteString("\tvar buf bytes.Buffer\n")
+		body.WriteString("\twriter := multipart.NewWriter(&buf)\n")
+		for _, param := range req.formData {
+			if param.Type == "file" {
+				hasFile = true
+				body.WriteString(fmt.Sprintf("\tif err := addFile(writer, %s, %s); err != nil {\n\t\tpanic(err)\n\t}\n",
+					strconv.Quote(param.Key), strconv.Quote(param.Src)))
+			} else {
+				body.WriteString(fmt.Sprintf("\twriter.WriteField(%s, %s)\n", strconv.Quote(param.Key), strconv.Quote(param.Value)))
+			}
+		}
+		body.WriteString("\twriter.Close()\n\n")
+		if hasFile {
+			imports = append(imports, "os", "path/filepath")
+		}
+		bodyArg = "&buf"
+	case req.body != "":
+		imports = append(imports, "strings")
+		body.WriteString(fmt.Sprintf("\tbody := strings.NewReader(%s)\n\n", strconv.Quote(req.body)))
+		bodyArg = "body"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("package main\n\nimport (\n")
+	for _, imp := range imports {
+		sb.WriteString("\t" + strconv.Quote(imp) + "\n")
+	}
+	sb.WriteString(")\n\nfunc main() {\n")
+	sb.WriteString(body.String())
+	sb.WriteString(fmt.Sprintf("\treq, err := http.NewRequest(%s, %s, %s)\n", strconv.Quote(req.method), strconv.Quote(req.url), bodyArg))
+	sb.WriteString("\tif err != nil {\n\t\tpanic(err)\n\t}\n")
+	for _, header := range req.headers {
+		sb.WriteString(fmt.Sprintf("\treq.Header.Set(%s, %s)\n", strconv.Quote(header.Key), strconv.Quote(header.Value)))
+	}
+	if len(req.formData) > 0 {
+		sb.WriteString("\treq.Header.Set(\"Content-Type\", writer.FormDataContentType())\n")
+	}
+	sb.WriteString("\n\tresp, err := http.DefaultClient.Do(req)\n")
+	sb.WriteString("\tif err != nil {\n\t\tpanic(err)\n\t}\n")
+	sb.WriteString("\tdefer resp.Body.Close()\n\n")
+	sb.WriteString("\trespBody, err := io.ReadAll(resp.Body)\n")
+	sb.WriteString("\tif err != nil {\n\t\tpanic(err)\n\t}\n\n")
+	sb.WriteString("\tfmt.Println(resp.Status)\n")
+	sb.WriteString("\tfmt.Println(string(respBody))\n")
+	sb.WriteString("}\n")
+
+	if strings.Contains(body.String(), "addFile(") {
+		sb.WriteString("\nfunc addFile(writer *multipart.Writer, field, path string) error {\n")
+		sb.WriteString("\tfile, err := os.Open(path)\n")
+		sb.WriteString("\tif err != nil {\n\t\treturn err\n\t}\n")
+		sb.WriteString("\tdefer file.Close()\n\n")
+		sb.WriteString("\tpart, err := writer.CreateFormFile(field, filepath.Base(path))\n")
+		sb.WriteString("\tif err != nil {\n\t\treturn err\n\t}\n")
+		sb.WriteString("\t_, err = io.Copy(part, file)\n")
+		sb.WriteString("\treturn err\n}\n")
+	}
+
+	return sb.String()
+}
+
+func generatePython(req resolvedRequest) string {
+	var sb strings.Builder
+	sb.WriteString("import requests\n\n")
+	sb.WriteString("url = " + jsString(req.url) + "\n")
+
+	args := []string{jsString(req.method), "url"}
+
+	if len(req.headers) > 0 {
+		sb.WriteString("headers = {\n")
+		for _, header := range req.headers {
+			sb.WriteString("    " + jsString(header.Key) + ": " + jsString(header.Value) + ",\n")
+		}
+		sb.WriteString("}\n")
+		args = append(args, "headers=headers")
+	}
+
+	if len(req.formData) > 0 {
+		var fields, files []postman.FormParam
+		for _, param := range req.formData {
+			if param.Type == "file" {
+				files = append(files, param)
+			} else {
+				fields = append(fields, param)
+			}
+		}
+		if len(fields) > 0 {
+			sb.WriteString("data = {\n")
+			for _, param := range fields {
+				sb.WriteString("    " + jsString(param.Key) + ": " + jsString(param.Value) + ",\n")
+			}
+			sb.WriteString("}\n")
+			args = append(args, "data=data")
+		}
+		if len(files) > 0 {
+			sb.WriteString("files = {\n")
+			for _, param := range files {
+				sb.WriteString("    " + jsString(param.Key) + ": open(" + jsString(param.Src) + ", \"rb\"),\n")
+			}
+			sb.WriteString("}\n")
+			args = append(args, "files=files")
+		}
+	} else if req.body != "" {
+		sb.WriteString("data = " + jsString(req.body) + "\n")
+		args = append(args, "data=data")
+	}
+
+	sb.WriteString("\nresponse = requests.request(" + strings.Join(args, ", ") + ")\n")
+	sb.WriteString("print(response.status_code)\n")
+	sb.WriteString("print(response.text)\n")
+	return sb.String()
+}
+
+func generateFetch(req resolvedRequest) string {
+	var sb strings.Builder
+
+	if len(req.formData) > 0 {
+		hasFile := false
+		for _, param := range req.formData {
+			if param.Type == "file" {
+				hasFile = true
+			}
+		}
+		if hasFile {
+			sb.WriteString("const fs = require(\"fs\");\n\n")
+		}
+		sb.WriteString("const form = new FormData();\n")
+		for _, param := range req.formData {
+			if param.Type == "file" {
+				sb.WriteString(fmt.Sprintf("form.append(%s, new Blob([fs.readFileSync(%s)]), %s);\n",
+					jsString(param.Key), jsString(param.Src), jsString(filepath.Base(param.Src))))
+			} else {
+				sb.WriteString(fmt.Sprintf("form.append(%s, %s);\n", jsString(param.Key), jsString(param.Value)))
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("const response = await fetch(" + jsString(req.url) + ", {\n")
+	sb.WriteString("  method: " + jsString(req.method) + ",\n")
+	if len(req.headers) > 0 {
+		sb.WriteString("  headers: {\n")
+		for _, header := range req.headers {
+			sb.WriteString("    " + jsString(header.Key) + ": " + jsString(header.Value) + ",\n")
+		}
+		sb.WriteString("  },\n")
+	}
+	if len(req.formData) > 0 {
+		sb.WriteString("  body: form,\n")
+	} else if req.body != "" {
+		sb.WriteString("  body: " + jsString(req.body) + ",\n")
+	}
+	sb.WriteString("});\n\n")
+	sb.WriteString("console.log(response.status);\n")
+	sb.WriteString("console.log(await response.text());\n")
+	return sb.String()
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func jsString(s string) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
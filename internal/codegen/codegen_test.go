@@ -0,0 +1,139 @@
+package codegen
+
+import (
+	"go/parser"
+	"go/token"
+	"postOffice/internal/postman"
+	"strings"
+	"testing"
+)
+
+func testRequest() *postman.Request {
+	return &postman.Request{
+		Method: "POST",
+		URL:    postman.URL{Raw: "{{baseUrl}}/users"},
+		Header: []postman.Header{
+			{Key: "Content-Type", Value: "application/json"},
+			{Key: "Authorization", Value: "Bearer {{token}}"},
+		},
+		Body: &postman.Body{Mode: "raw", Raw: `{"name": "O'Brien"}`},
+	}
+}
+
+func testVariables() []postman.VariableSource {
+	return []postman.VariableSource{
+		{Key: "baseUrl", Value: "https://api.example.com"},
+		{Key: "token", Value: "abc123"},
+	}
+}
+
+func TestGenerate_AllTargetsResolveVariables(t *testing.T) {
+	for _, target := range Targets() {
+		t.Run(target.Name, func(t *testing.T) {
+			snippet, err := Generate(target.Name, testRequest(), testVariables())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !strings.Contains(snippet, "https://api.example.com/users") {
+				t.Errorf("Expected resolved URL in snippet:\n%s", snippet)
+			}
+			if !strings.Contains(snippet, "Bearer abc123") {
+				t.Errorf("Expected resolved header in snippet:\n%s", snippet)
+			}
+			if strings.Contains(snippet, "{{") {
+				t.Errorf("Expected no unresolved variables in snippet:\n%s", snippet)
+			}
+		})
+	}
+}
+
+func TestGenerate_CurlRoundTrip(t *testing.T) {
+	snippet, err := Generate("curl", testRequest(), testVariables())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	item, err := postman.ParseCurlCommand(snippet)
+	if err != nil {
+		t.Fatalf("Generated curl could not be parsed: %v\n%s", err, snippet)
+	}
+	if item.Request.Method != "POST" || item.Request.URL.Raw != "https://api.example.com/users" {
+		t.Errorf("Unexpected round trip request: %+v", item.Request)
+	}
+	if item.Request.Body.Raw != `{"name": "O'Brien"}` {
+		t.Errorf("Expected body to survive shell quoting, got %s", item.Request.Body.Raw)
+	}
+	if len(item.Request.Header) != 2 {
+		t.Errorf("Expected 2 headers, got %+v", item.Request.Header)
+	}
+}
+
+func TestGenerate_GoIsValidSource(t *testing.T) {
+	requests := map[string]*postman.Request{
+		"raw body": testRequest(),
+		"no body":  {Method: "GET", URL: postman.URL{Raw: "https://example.com"}},
+		"form data": {
+			Method: "POST",
+			URL:    postman.URL{Raw: "https://example.com/upload"},
+			Body: &postman.Body{Mode: "formdata", FormData: []postman.FormParam{
+				{Key: "name", Value: "alice", Type: "text"},
+				{Key: "avatar", Type: "file", Src: "/tmp/me.png"},
+			}},
+		},
+	}
+
+	for name, req := range requests {
+		t.Run(name, func(t *testing.T) {
+			snippet, err := Generate("go", req, nil)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if _, err := parser.ParseFile(token.NewFileSet(), "main.go", snippet, 0); err != nil {
+				t.Errorf("Generated Go does not parse: %v\n%s", err, snippet)
+			}
+		})
+	}
+}
+
+func TestGenerate_FormData(t *testing.T) {
+	req := &postman.Request{
+		Method: "POST",
+		URL:    postman.URL{Raw: "https://example.com/upload"},
+		Header: []postman.Header{{Key: "Content-Type", Value: "multipart/form-data"}},
+		Body: &postman.Body{Mode: "formdata", FormData: []postman.FormParam{
+			{Key: "name", Value: "alice", Type: "text"},
+			{Key: "avatar", Type: "file", Src: "/tmp/me.png"},
+		}},
+	}
+
+	expected := map[string][]string{
+		"curl":   {"-F 'name=alice'", "-F 'avatar=@/tmp/me.png'"},
+		"httpie": {"--multipart", "'avatar@/tmp/me.png'"},
+		"python": {`files = {`, `open("/tmp/me.png", "rb")`},
+		"fetch":  {`form.append("name", "alice")`, "body: form"},
+	}
+
+	for target, fragments := range expected {
+		snippet, err := Generate(target, req, nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for _, fragment := range fragments {
+			if !strings.Contains(snippet, fragment) {
+				t.Errorf("%s: expected %q in snippet:\n%s", target, fragment, snippet)
+			}
+		}
+		if strings.Contains(snippet, "multipart/form-data\"") || strings.Contains(snippet, "Content-Type: multipart") {
+			t.Errorf("%s: expected explicit multipart Content-Type to be dropped:\n%s", target, snippet)
+		}
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	if _, err := Generate("cobol", testRequest(), nil); err == nil {
+		t.Error("Expected error for unknown target")
+	}
+	if _, err := Generate("curl", nil, nil); err == nil {
+		t.Error("Expected error for nil request")
+	}
+}
@@ -0,0 +1,176 @@
+package http
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+const DefaultMaxBodyBytes = 10 * 1024 * 1024
+
+const sniffLength = 512
+
+// maxScriptBodyBytes caps how much of a file-backed body is loaded into
+// memory for test scripts, examples and other consumers that need text.
+var maxScriptBodyBytes int64 = 64 * 1024 * 1024
+
+var ErrBodyTooLarge = errors.New("response body is too large to load")
+
+var textContentTypes = []string{
+	"json", "xml", "html", "javascript", "ecmascript", "yaml", "csv",
+	"x-www-form-urlencoded", "graphql", "svg",
+}
+
+type responseBody struct {
+	text   string
+	file   string
+	size   int64
+	sha256 string
+	binary bool
+}
+
+func isTextContentType(contentType string) (known bool, text bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType == "" {
+		return false, false
+	}
+	if strings.HasPrefix(mediaType, "text/") {
+		return true, true
+	}
+	for _, marker := range textContentTypes {
+		if strings.Contains(mediaType, marker) {
+			return true, true
+		}
+	}
+	if mediaType == "application/octet-stream" {
+		return false, false
+	}
+	return true, false
+}
+
+func looksBinary(sample []byte) bool {
+	if bytes.IndexByte(sample, 0) != -1 {
+		return true
+	}
+	for len(sample) > 0 {
+		r, size := utf8.DecodeRune(sample)
+		if r == utf8.RuneError && size == 1 && len(sample) >= utf8.UTFMax {
+			return true
+		}
+		sample = sample[size:]
+	}
+	return false
+}
+
+func readResponseBody(r io.Reader, contentType string, limit int64) (responseBody, error) {
+	if limit <= 0 {
+		limit = DefaultMaxBodyBytes
+	}
+
+	hasher := sha256.New()
+	reader := io.TeeReader(r, hasher)
+
+	var buf bytes.Buffer
+	n, err := io.CopyN(&buf, reader, limit+1)
+	if err != nil && err != io.EOF {
+		return responseBody{}, err
+	}
+
+	known, text := isTextContentType(contentType)
+	binary := known && !text
+	if !known {
+		binary = looksBinary(buf.Bytes()[:min(buf.Len(), sniffLength)])
+	}
+
+	if n <= limit && !binary {
+		return responseBody{
+			text:   buf.String(),
+			size:   n,
+			sha256: hex.EncodeToString(hasher.Sum(nil)),
+		}, nil
+	}
+
+	file, err := os.CreateTemp("", "postoffice-response-*")
+	if err != nil {
+		return responseBody{}, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer file.Close()
+
+	size, err := io.Copy(file, io.MultiReader(&buf, reader))
+	if err != nil {
+		os.Remove(file.Name())
+		return responseBody{}, err
+	}
+
+	return responseBody{
+		file:   file.Name(),
+		size:   size,
+		sha256: hex.EncodeToString(hasher.Sum(nil)),
+		binary: binary,
+	}, nil
+}
+
+func (r *Response) Size() int64 {
+	if r.BodyFile != "" {
+		return r.BodySize
+	}
+	return int64(len(r.Body))
+}
+
+// LoadBody returns the full response body, reading it back from BodyFile
+// when it was streamed to disk. Bodies over the script limit are refused
+// with ErrBodyTooLarge rather than loaded partially.
+func (r *Response) LoadBody() (string, error) {
+	if r.BodyFile == "" {
+		return r.Body, nil
+	}
+	if r.BodySize > maxScriptBodyBytes {
+		return "", fmt.Errorf("%w: %d bytes (limit %d bytes)", ErrBodyTooLarge, r.BodySize, maxScriptBodyBytes)
+	}
+
+	data, err := os.ReadFile(r.BodyFile)
+	if err != nil {
+		return "", fmt.Errorf("response body is no longer available: %w", err)
+	}
+	return string(data), nil
+}
+
+func (r *Response) WriteBody(path string) error {
+	if r.BodyFile == "" {
+		return os.WriteFile(path, []byte(r.Body), 0644)
+	}
+
+	src, err := os.Open(r.BodyFile)
+	if err != nil {
+		return fmt.Errorf("response body is no longer available: %w", err)
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+func (r *Response) RemoveBodyFile() error {
+	if r.BodyFile == "" {
+		return nil
+	}
+	err := os.Remove(r.BodyFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
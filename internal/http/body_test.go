@@ -0,0 +1,216 @@
+package http
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"postOffice/internal/postman"
+	"postOffice/internal/script"
+	"strings"
+	"testing"
+)
+
+func executeBody(t *testing.T, executor *Executor, contentType string, body []byte) *Response {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if contentType != "" {
+			w.Header().Set("Content-Type", contentType)
+		}
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	resp, _ := executor.Execute(&postman.Request{Method: "GET", URL: postman.URL{Raw: server.URL}}, nil, nil, nil, nil)
+	if resp.Error != nil {
+		t.Fatalf("Expected no error, got %v", resp.Error)
+	}
+	t.Cleanup(func() { resp.RemoveBodyFile() })
+	return resp
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestExecute_SmallTextBodyStaysInMemory(t *testing.T) {
+	body := []byte(`{"ok":true}`)
+	resp := executeBody(t, NewExecutor(), "application/json", body)
+
+	if resp.Body != string(body) || resp.BodyFile != "" || resp.Binary {
+		t.Errorf("Expected body in memory, got body %q file %q", resp.Body, resp.BodyFile)
+	}
+	if resp.BodySize != int64(len(body)) || resp.BodySHA256 != sha256Hex(body) {
+		t.Errorf("Unexpected size %d or hash %s", resp.BodySize, resp.BodySHA256)
+	}
+}
+
+func TestExecute_LargeBodyIsStreamedToFile(t *testing.T) {
+	body := []byte(strings.Repeat("abcdefgh", 1024))
+	executor := NewExecutor()
+	executor.SetMaxBodyBytes(1024)
+
+	resp := executeBody(t, executor, "text/plain", body)
+	if resp.Body != "" || resp.BodyFile == "" || resp.Binary {
+		t.Fatalf("Expected large text body to be streamed to a file, got file %q", resp.BodyFile)
+	}
+
+	data, err := os.ReadFile(resp.BodyFile)
+	if err != nil || !bytes.Equal(data, body) {
+		t.Fatalf("Expected temp file to hold the full body, got %d bytes, %v", len(data), err)
+	}
+	if resp.Size() != int64(len(body)) || resp.BodySHA256 != sha256Hex(body) {
+		t.Errorf("Unexpected size %d or hash %s", resp.Size(), resp.BodySHA256)
+	}
+
+	if err := resp.RemoveBodyFile(); err != nil {
+		t.Fatalf("Failed to remove body file: %v", err)
+	}
+	if _, err := os.Stat(resp.BodyFile); !os.IsNotExist(err) {
+		t.Error("Expected temp file to be removed")
+	}
+}
+
+func TestExecute_BinaryBodyIsStreamedToFile(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        []byte
+		binary      bool
+	}{
+		{"binary content type", "image/png", png, true},
+		{"sniffed binary", "", png, true},
+		{"octet-stream with text", "application/octet-stream", []byte("plain text"), false},
+		{"vendor json", "application/vnd.api+json", []byte(`{"data":[]}`), false},
+		{"svg", "image/svg+xml", []byte("<svg/>"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := executeBody(t, NewExecutor(), tt.contentType, tt.body)
+			if resp.Binary != tt.binary || (resp.BodyFile != "") != tt.binary {
+				t.Errorf("Expected binary=%v, got binary=%v file=%q", tt.binary, resp.Binary, resp.BodyFile)
+			}
+		})
+	}
+}
+
+func TestResponse_WriteBody(t *testing.T) {
+	dir := t.TempDir()
+
+	inMemory := &Response{Body: "hello"}
+	if err := inMemory.WriteBody(filepath.Join(dir, "memory.txt")); err != nil {
+		t.Fatalf("Failed to write body: %v", err)
+	}
+
+	executor := NewExecutor()
+	executor.SetMaxBodyBytes(4)
+	streamed := executeBody(t, executor, "application/zip", []byte("PK\x03\x04zipdata"))
+	if err := streamed.WriteBody(filepath.Join(dir, "export.zip")); err != nil {
+		t.Fatalf("Failed to write streamed body: %v", err)
+	}
+
+	for name, expected := range map[string]string{"memory.txt": "hello", "export.zip": "PK\x03\x04zipdata"} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil || string(data) != expected {
+			t.Errorf("%s: expected %q, got %q (%v)", name, expected, data, err)
+		}
+	}
+}
+
+func executeBodyWithTests(t *testing.T, executor *Executor, body []byte, exec []string) (*Response, *script.TestResult) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	item := &postman.Item{
+		Name:   "large",
+		Events: []postman.Event{{Listen: "test", Script: postman.Script{Exec: exec}}},
+	}
+	resp, result := executor.Execute(&postman.Request{Method: "GET", URL: postman.URL{Raw: server.URL}}, item, nil, nil, nil)
+	if resp.Error != nil {
+		t.Fatalf("Expected no error, got %v", resp.Error)
+	}
+	t.Cleanup(func() { resp.RemoveBodyFile() })
+	return resp, result
+}
+
+func TestExecute_TestScriptsSeeStreamedBody(t *testing.T) {
+	body := []byte(`{"items":["` + strings.Repeat("x", 2048) + `"]}`)
+	executor := NewExecutor()
+	executor.SetMaxBodyBytes(1024)
+
+	resp, result := executeBodyWithTests(t, executor, body, []string{
+		`pm.test("body", () => { if (pm.response.json().items[0].length !== 2048) throw new Error("short body"); });`,
+	})
+	if resp.BodyFile == "" {
+		t.Fatal("Expected body to be streamed to a file")
+	}
+	if len(result.Errors) > 0 || len(result.Tests) != 1 || !result.Tests[0].Passed {
+		t.Errorf("Expected test script to see the streamed body, got %+v", result)
+	}
+}
+
+func TestExecute_TestScriptsRefuseBodyOverScriptLimit(t *testing.T) {
+	previous := maxScriptBodyBytes
+	maxScriptBodyBytes = 2048
+	t.Cleanup(func() { maxScriptBodyBytes = previous })
+
+	executor := NewExecutor()
+	executor.SetMaxBodyBytes(1024)
+
+	_, result := executeBodyWithTests(t, executor, []byte(strings.Repeat("a", 4096)), []string{
+		`pm.test("body", () => {});`,
+	})
+	if len(result.Tests) != 0 {
+		t.Errorf("Expected scripts not to run, got %+v", result.Tests)
+	}
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0], "too large") {
+		t.Errorf("Expected a body too large error, got %v", result.Errors)
+	}
+	if !result.HasFailures() {
+		t.Error("Expected the result to count as failed")
+	}
+}
+
+func TestResponse_LoadBody(t *testing.T) {
+	inMemory := &Response{Body: "hello"}
+	if body, err := inMemory.LoadBody(); err != nil || body != "hello" {
+		t.Errorf("Expected in-memory body, got %q (%v)", body, err)
+	}
+
+	missing := &Response{BodyFile: filepath.Join(t.TempDir(), "gone"), BodySize: 3}
+	if _, err := missing.LoadBody(); err == nil {
+		t.Error("Expected error for a removed body file")
+	}
+
+	huge := &Response{BodyFile: "unused", BodySize: maxScriptBodyBytes + 1}
+	if _, err := huge.LoadBody(); !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("Expected ErrBodyTooLarge, got %v", err)
+	}
+}
+
+func TestLooksBinary(t *testing.T) {
+	tests := map[string]bool{
+		"plain text":                 false,
+		"héllo wörld":                false,
+		"with\x00nul":                true,
+		"\xff\xfe\xfd\xfc invalid":   true,
+		"truncated rune at end \xe2": false,
+	}
+	for sample, expected := range tests {
+		if got := looksBinary([]byte(sample)); got != expected {
+			t.Errorf("looksBinary(%q) = %v, expected %v", sample, got, expected)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package http
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type ConnectionInfo struct {
+	Proto        string
+	RemoteAddr   string
+	TLSVersion   string
+	CipherSuite  string
+	ServerName   string
+	Certificates []CertificateInfo
+}
+
+type CertificateInfo struct {
+	Subject   string
+	Issuer    string
+	DNSNames  []string
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func newConnectionInfo(resp *http.Response, remoteAddr string) ConnectionInfo {
+	info := ConnectionInfo{
+		Proto:      resp.Proto,
+		RemoteAddr: remoteAddr,
+	}
+	if resp.TLS == nil {
+		return info
+	}
+
+	info.TLSVersion = tls.VersionName(resp.TLS.Version)
+	info.CipherSuite = tls.CipherSuiteName(resp.TLS.CipherSuite)
+	info.ServerName = resp.TLS.ServerName
+	for _, cert := range resp.TLS.PeerCertificates {
+		info.Certificates = append(info.Certificates, newCertificateInfo(cert))
+	}
+	return info
+}
+
+func newCertificateInfo(cert *x509.Certificate) CertificateInfo {
+	return CertificateInfo{
+		Subject:   cert.Subject.String(),
+		Issuer:    cert.Issuer.String(),
+		DNSNames:  cert.DNSNames,
+		NotBefore: cert.NotBefore,
+		NotAfter:  cert.NotAfter,
+	}
+}
+
+func decodedBody(resp *http.Response) (io.Reader, *countingReader, error) {
+	counter := &countingReader{r: resp.Body}
+
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))) {
+	case "gzip", "x-gzip":
+		reader, err := gzip.NewReader(counter)
+		if err == io.EOF {
+			return counter, counter, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		return reader, counter, nil
+	case "deflate":
+		reader, err := zlib.NewReader(counter)
+		if err == io.EOF {
+			return counter, counter, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		return reader, counter, nil
+	}
+	return counter, counter, nil
+}
+
+func headerSize(resp *http.Response) int64 {
+	size := len(resp.Proto) + len(resp.Status) + 3
+	for key, values := range resp.Header {
+		for _, value := range values {
+			size += len(key) + len(value) + 4
+		}
+	}
+	return int64(size + 2)
+}
@@ -0,0 +1,75 @@
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"postOffice/internal/postman"
+	"strings"
+	"testing"
+)
+
+func TestExecute_DecodesGzipAndRecordsSizes(t *testing.T) {
+	body := strings.Repeat(`{"id":1,"name":"widget"},`, 200)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			t.Errorf("Expected gzip to be requested, got %q", r.Header.Get("Accept-Encoding"))
+		}
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		zw.Write([]byte(body))
+		zw.Close()
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(buf.Bytes())
+	}))
+	defer server.Close()
+
+	resp, _ := NewExecutor().Execute(&postman.Request{Method: "GET", URL: postman.URL{Raw: server.URL}}, nil, nil, nil, nil)
+	if resp.Error != nil {
+		t.Fatalf("Expected no error, got %v", resp.Error)
+	}
+	if resp.Body != body {
+		t.Fatalf("Expected decoded body, got %d bytes", len(resp.Body))
+	}
+	if resp.Size() != int64(len(body)) || resp.TransferSize <= 0 || resp.TransferSize >= resp.Size() {
+		t.Errorf("Expected compressed transfer smaller than body, got transfer %d, body %d", resp.TransferSize, resp.Size())
+	}
+	if resp.HeaderSize <= 0 {
+		t.Errorf("Expected header size, got %d", resp.HeaderSize)
+	}
+	if resp.Connection.Proto != "HTTP/1.1" || resp.Connection.RemoteAddr != server.Listener.Addr().String() {
+		t.Errorf("Unexpected connection info: %+v", resp.Connection)
+	}
+	if resp.Connection.TLSVersion != "" {
+		t.Errorf("Expected no TLS details for plain HTTP, got %q", resp.Connection.TLSVersion)
+	}
+}
+
+func TestExecute_RecordsTLSDetails(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	executor := NewExecutor()
+	executor.client = server.Client()
+	resp, _ := executor.Execute(&postman.Request{Method: "GET", URL: postman.URL{Raw: server.URL}}, nil, nil, nil, nil)
+	if resp.Error != nil {
+		t.Fatalf("Expected no error, got %v", resp.Error)
+	}
+
+	if !strings.HasPrefix(resp.Connection.TLSVersion, "TLS ") || resp.Connection.CipherSuite == "" {
+		t.Errorf("Expected TLS version and cipher suite, got %+v", resp.Connection)
+	}
+	if len(resp.Connection.Certificates) == 0 {
+		t.Fatal("Expected the peer certificate chain")
+	}
+	if cert := resp.Connection.Certificates[0]; cert.Subject == "" || cert.NotAfter.Before(cert.NotBefore) {
+		t.Errorf("Unexpected certificate info: %+v", cert)
+	}
+	if resp.TransferSize != 2 {
+		t.Errorf("Expected uncompressed transfer size to match body, got %d", resp.TransferSize)
+	}
+}
@@ -2,9 +2,13 @@ package http
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"os"
+	"path/filepath"
 	"postOffice/internal/postman"
 	"postOffice/internal/script"
 	"strings"
@@ -14,47 +18,104 @@ import (
 type Response struct {
 	StatusCode     int
 	Status         string
+	Proto          string
 	Headers        map[string][]string
 	Body           string
+	BodyFile       string
+	BodySize       int64
+	BodySHA256     string
+	TransferSize   int64
+	HeaderSize     int64
+	Binary         bool
+	Connection     ConnectionInfo
+	Timings        Timings
+	StartedAt      time.Time
 	Duration       time.Duration
 	Error          error
 	RequestURL     string
+	FinalMethod    string
+	FinalURL       string
+	Redirects      []RedirectHop
 	RequestMethod  string
 	RequestHeaders map[string]string
 	RequestBody    string
 }
 
 type Executor struct {
-	client *http.Client
+	client       *http.Client
+	maxBodyBytes int64
 }
 
 func NewExecutor() *Executor {
 	return &Executor{
 		client: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:       30 * time.Second,
+			CheckRedirect: checkRedirect,
 		},
+		maxBodyBytes: DefaultMaxBodyBytes,
 	}
 }
 
+func (e *Executor) SetMaxBodyBytes(limit int64) {
+	e.maxBodyBytes = limit
+}
+
 func (e *Executor) Execute(
 	req *postman.Request,
 	item *postman.Item,
 	collection *postman.Collection,
 	environment *postman.Environment,
 	variables []postman.VariableSource,
+) (*Response, *script.TestResult) {
+	return e.ExecuteWithData(req, item, collection, environment, variables, nil)
+}
+
+// ExecuteContext is Execute with the HTTP request bound to ctx, so cancelling
+// ctx aborts a request that is still in flight.
+func (e *Executor) ExecuteContext(
+	ctx context.Context,
+	req *postman.Request,
+	item *postman.Item,
+	collection *postman.Collection,
+	environment *postman.Environment,
+	variables []postman.VariableSource,
+) (*Response, *script.TestResult) {
+	return e.execute(ctx, req, item, collection, environment, variables, nil)
+}
+
+func (e *Executor) ExecuteWithData(
+	req *postman.Request,
+	item *postman.Item,
+	collection *postman.Collection,
+	environment *postman.Environment,
+	variables []postman.VariableSource,
+	iterationData map[string]string,
+) (*Response, *script.TestResult) {
+	return e.execute(context.Background(), req, item, collection, environment, variables, iterationData)
+}
+
+func (e *Executor) execute(
+	ctx context.Context,
+	req *postman.Request,
+	item *postman.Item,
+	collection *postman.Collection,
+	environment *postman.Environment,
+	variables []postman.VariableSource,
+	iterationData map[string]string,
 ) (*Response, *script.TestResult) {
 	start := time.Now()
-	resp := &Response{}
+	resp := &Response{StartedAt: start}
 
-	updatedVariables := variables
+	updatedVariables := postman.WithIterationData(variables, iterationData)
 	if item != nil {
-		preReqErrors := e.executePreRequestScripts(item, collection, environment)
+		preReqErrors := e.executePreRequestScripts(item, collection, environment, iterationData)
+		resp.Timings.PreRequestScript = time.Since(start)
 		if len(preReqErrors) > 0 {
 			resp.Error = fmt.Errorf("pre-request script errors: %v", preReqErrors)
 			resp.Duration = time.Since(start)
 			return resp, nil
 		}
-		updatedVariables = rebuildVariables(collection, environment)
+		updatedVariables = postman.WithIterationData(rebuildVariables(collection, environment), iterationData)
 	}
 
 	httpReq, err := e.buildRequest(req, updatedVariables)
@@ -78,9 +139,16 @@ func (e *Executor) Execute(
 		httpReq.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
 	}
 
-	httpResp, err := e.client.Do(httpReq)
+	if httpReq.Header.Get("Accept-Encoding") == "" {
+		httpReq.Header.Set("Accept-Encoding", "gzip")
+	}
+
+	tracer := newRequestTracer()
+	httpResp, err := e.client.Do(tracer.withTrace(httpReq.WithContext(ctx)))
 	if err != nil {
 		resp.Error = fmt.Errorf("request failed: %w", err)
+		tracer.record(&resp.Timings, time.Now())
+		resp.Redirects = tracer.redirects()
 		resp.Duration = time.Since(start)
 		return resp, nil
 	}
@@ -88,29 +156,85 @@ func (e *Executor) Execute(
 
 	resp.StatusCode = httpResp.StatusCode
 	resp.Status = httpResp.Status
+	resp.Proto = httpResp.Proto
+	resp.FinalMethod = httpResp.Request.Method
+	resp.FinalURL = httpResp.Request.URL.String()
+	resp.Redirects = tracer.redirects()
 	resp.Headers = httpResp.Header
+	resp.HeaderSize = headerSize(httpResp)
+	resp.Connection = newConnectionInfo(httpResp, tracer.remoteAddress())
 
-	body, err := io.ReadAll(httpResp.Body)
+	bodyReader, transferred, err := decodedBody(httpResp)
 	if err != nil {
-		resp.Error = fmt.Errorf("failed to read response body: %w", err)
+		resp.Error = fmt.Errorf("failed to decode response body: %w", err)
 		resp.Duration = time.Since(start)
 		return resp, nil
 	}
 
-	resp.Body = string(body)
-	resp.Duration = time.Since(start)
+	body, err := readResponseBody(bodyReader, httpResp.Header.Get("Content-Type"), e.maxBodyBytes)
+	if err != nil {
+		resp.Error = fmt.Errorf("failed to read response body: %w", err)
+		resp.Duration = time.Since(start)
+		return resp, nil
+	}
 
-	testResult := e.executeTestScripts(item, collection, environment, resp)
+	resp.Body = body.text
+	resp.BodyFile = body.file
+	resp.BodySize = body.size
+	resp.BodySHA256 = body.sha256
+	resp.TransferSize = transferred.n
+	resp.Binary = body.binary
+
+	done := time.Now()
+	tracer.record(&resp.Timings, done)
+	resp.Duration = done.Sub(tracer.start)
+
+	testStart := time.Now()
+	testResult := e.executeTestScripts(item, collection, environment, resp, iterationData)
+	if testResult != nil {
+		resp.Timings.TestScript = time.Since(testStart)
+	}
 
 	return resp, testResult
 }
 
+func (e *Executor) ExecuteInScopes(
+	req *postman.Request,
+	item *postman.Item,
+	scopes *postman.VariableScopes,
+	variables []postman.VariableSource,
+	iterationData map[string]string,
+) (*Response, *script.TestResult, postman.VariableChanges) {
+	response, testResult := e.ExecuteWithData(req, item, scopes.Collection, scopes.Environment, variables, iterationData)
+	return response, testResult, scopes.Changes()
+}
+
+func (e *Executor) ResolveVariables(
+	item *postman.Item,
+	collection *postman.Collection,
+	environment *postman.Environment,
+	variables []postman.VariableSource,
+) ([]postman.VariableSource, error) {
+	if item == nil {
+		return variables, nil
+	}
+
+	scopes := postman.SnapshotVariableScopes(collection, environment)
+	preReqErrors := e.executePreRequestScripts(item, scopes.Collection, scopes.Environment, nil)
+	if len(preReqErrors) > 0 {
+		return nil, fmt.Errorf("pre-request script errors: %v", preReqErrors)
+	}
+
+	return rebuildVariables(scopes.Collection, scopes.Environment), nil
+}
+
 func (e *Executor) executePreRequestScripts(
 	item *postman.Item,
 	collection *postman.Collection,
 	environment *postman.Environment,
+	iterationData map[string]string,
 ) []string {
-	ctx := &script.ExecutionContext{}
+	ctx := &script.ExecutionContext{IterationData: iterationData}
 
 	if collection != nil {
 		ctx.CollectionVars = collection.Variables
@@ -138,21 +262,34 @@ func (e *Executor) executeTestScripts(
 	collection *postman.Collection,
 	environment *postman.Environment,
 	resp *Response,
+	iterationData map[string]string,
 ) *script.TestResult {
 	if item == nil {
 		return nil
 	}
 
+	body, err := resp.LoadBody()
+	if err != nil && hasTestScripts(item.Events) {
+		return &script.TestResult{
+			Tests:  []script.Test{},
+			Errors: []string{"test scripts skipped: " + err.Error()},
+		}
+	}
+
 	responseData := &script.ResponseData{
 		StatusCode:   resp.StatusCode,
 		Status:       resp.Status,
-		Body:         resp.Body,
+		Body:         body,
 		Headers:      resp.Headers,
 		ResponseTime: resp.Duration.Milliseconds(),
+		Timings:      resp.Timings.Milliseconds(),
+		BodySize:     resp.Size(),
+		HeaderSize:   resp.HeaderSize,
 	}
 
 	ctx := &script.ExecutionContext{
-		Response: responseData,
+		Response:      responseData,
+		IterationData: iterationData,
 	}
 
 	if collection != nil {
@@ -176,6 +313,15 @@ func (e *Executor) executeTestScripts(
 	return result
 }
 
+func hasTestScripts(events []postman.Event) bool {
+	for _, event := range events {
+		if event.Listen == "test" {
+			return true
+		}
+	}
+	return false
+}
+
 func rebuildVariables(collection *postman.Collection, environment *postman.Environment) []postman.VariableSource {
 	var variables []postman.VariableSource
 	seen := make(map[string]bool)
@@ -217,7 +363,15 @@ func (e *Executor) buildRequest(req *postman.Request, variables []postman.Variab
 	url = postman.ResolveVariables(url, variables)
 
 	var body io.Reader
-	if req.Body != nil && req.Body.Raw != "" {
+	multipartContentType := ""
+	if req.Body != nil && req.Body.Mode == "formdata" && len(req.Body.FormData) > 0 {
+		formBody, contentType, err := buildFormData(req.Body.FormData, variables)
+		if err != nil {
+			return nil, err
+		}
+		body = formBody
+		multipartContentType = contentType
+	} else if req.Body != nil && req.Body.Raw != "" {
 		resolvedBody := postman.ResolveVariables(req.Body.Raw, variables)
 		body = bytes.NewBufferString(resolvedBody)
 	}
@@ -232,9 +386,46 @@ func (e *Executor) buildRequest(req *postman.Request, variables []postman.Variab
 		httpReq.Header.Set(header.Key, resolvedValue)
 	}
 
+	if multipartContentType != "" {
+		httpReq.Header.Set("Content-Type", multipartContentType)
+	}
+
 	return httpReq, nil
 }
 
+func buildFormData(params []postman.FormParam, variables []postman.VariableSource) (io.Reader, string, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	for _, param := range params {
+		key := postman.ResolveVariables(param.Key, variables)
+		if param.Type == "file" {
+			src := postman.ResolveVariables(param.Src, variables)
+			data, err := os.ReadFile(src)
+			if err != nil {
+				return nil, "", fmt.Errorf("failed to read form file %s: %w", src, err)
+			}
+			part, err := writer.CreateFormFile(key, filepath.Base(src))
+			if err != nil {
+				return nil, "", fmt.Errorf("failed to create form file: %w", err)
+			}
+			if _, err := part.Write(data); err != nil {
+				return nil, "", fmt.Errorf("failed to write form file: %w", err)
+			}
+			continue
+		}
+		if err := writer.WriteField(key, postman.ResolveVariables(param.Value, variables)); err != nil {
+			return nil, "", fmt.Errorf("failed to write form field: %w", err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, "", fmt.Errorf("failed to finalize form data: %w", err)
+	}
+
+	return &buf, writer.FormDataContentType(), nil
+}
+
 func (e *Executor) buildURL(url *postman.URL) string {
 	if len(url.Host) == 0 {
 		return url.Raw
@@ -3,6 +3,8 @@ package http
 import (
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"postOffice/internal/postman"
 	"strings"
 	"testing"
@@ -539,6 +541,63 @@ func TestBuildRequest_EmptyBody(t *testing.T) {
 	}
 }
 
+func TestBuildRequest_FormData(t *testing.T) {
+	executor := NewExecutor()
+	filePath := filepath.Join(t.TempDir(), "upload.txt")
+	if err := os.WriteFile(filePath, []byte("file contents"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	req := &postman.Request{
+		Method: "POST",
+		URL: postman.URL{
+			Raw: "https://example.com/upload",
+		},
+		Header: []postman.Header{
+			{Key: "Content-Type", Value: "multipart/form-data"},
+		},
+		Body: &postman.Body{
+			Mode: "formdata",
+			FormData: []postman.FormParam{
+				{Key: "name", Value: "{{user}}", Type: "text"},
+				{Key: "file", Type: "file", Src: filePath},
+			},
+		},
+	}
+	variables := []postman.VariableSource{{Key: "user", Value: "alice"}}
+
+	httpReq, err := executor.buildRequest(req, variables)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if err := httpReq.ParseMultipartForm(1 << 20); err != nil {
+		t.Fatalf("Expected multipart body, got error: %v", err)
+	}
+	if httpReq.FormValue("name") != "alice" {
+		t.Errorf("Expected resolved form field 'alice', got '%s'", httpReq.FormValue("name"))
+	}
+	if files := httpReq.MultipartForm.File["file"]; len(files) != 1 || files[0].Filename != "upload.txt" {
+		t.Errorf("Expected uploaded file 'upload.txt', got %+v", files)
+	}
+}
+
+func TestBuildRequest_FormDataMissingFile(t *testing.T) {
+	executor := NewExecutor()
+	req := &postman.Request{
+		Method: "POST",
+		URL:    postman.URL{Raw: "https://example.com/upload"},
+		Body: &postman.Body{
+			Mode:     "formdata",
+			FormData: []postman.FormParam{{Key: "file", Type: "file", Src: "/nonexistent/file.txt"}},
+		},
+	}
+
+	if _, err := executor.buildRequest(req, nil); err == nil {
+		t.Error("Expected error for missing form file")
+	}
+}
+
 func TestExecute_Timeout(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping timeout test in short mode")
@@ -702,3 +761,89 @@ func TestResponse_Fields(t *testing.T) {
 		t.Errorf("Expected RequestBody 'test body', got '%s'", resp.RequestBody)
 	}
 }
+
+func TestResolveVariables_RunsPreRequestScriptsOnCopies(t *testing.T) {
+	executor := NewExecutor()
+	item := &postman.Item{
+		Name: "Scripted",
+		Events: []postman.Event{{
+			Listen: "prerequest",
+			Script: postman.Script{Exec: []string{
+				"pm.collectionVariables.set('token', 'generated');",
+				"pm.environmentVariables.set('host', 'scripted.example.com');",
+			}},
+		}},
+	}
+	collection := &postman.Collection{
+		Info:      postman.Info{Name: "Test"},
+		Variables: []postman.Variable{{Key: "token", Value: "original"}},
+	}
+	environment := &postman.Environment{
+		Name:   "Env",
+		Values: []postman.EnvVariable{{Key: "host", Value: "example.com", Enabled: true}},
+	}
+
+	variables, err := executor.ResolveVariables(item, collection, environment, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	resolved := postman.ResolveVariables("https://{{host}}/?t={{token}}", variables)
+	if resolved != "https://scripted.example.com/?t=generated" {
+		t.Errorf("Expected script-resolved URL, got %s", resolved)
+	}
+	if collection.Variables[0].Value != "original" || environment.Values[0].Value != "example.com" {
+		t.Error("Expected original collection and environment to be unchanged")
+	}
+}
+
+func TestResolveVariables_ScriptError(t *testing.T) {
+	executor := NewExecutor()
+	item := &postman.Item{
+		Events: []postman.Event{{
+			Listen: "prerequest",
+			Script: postman.Script{Exec: []string{"throw new Error('boom');"}},
+		}},
+	}
+
+	if _, err := executor.ResolveVariables(item, nil, nil, nil); err == nil {
+		t.Error("Expected error from failing pre-request script")
+	}
+}
+
+func TestExecuteInScopes_ReturnsScriptWrites(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.Query().Get("t")))
+	}))
+	defer server.Close()
+
+	item := &postman.Item{
+		Name: "Scripted",
+		Events: []postman.Event{
+			{Listen: "prerequest", Script: postman.Script{Exec: []string{"pm.collectionVariables.set('token', 'generated');"}}},
+			{Listen: "test", Script: postman.Script{Exec: []string{"pm.environmentVariables.set('echo', pm.response.text());"}}},
+		},
+	}
+	collection := &postman.Collection{
+		Info:      postman.Info{Name: "Test"},
+		Variables: []postman.Variable{{Key: "token", Value: "original"}},
+	}
+	environment := &postman.Environment{Name: "Env"}
+
+	scopes := postman.SnapshotVariableScopes(collection, environment)
+	request := &postman.Request{Method: "GET", URL: postman.URL{Raw: server.URL + "/?t={{token}}"}}
+	resp, testResult, changes := NewExecutor().ExecuteInScopes(request, item, scopes, nil, nil)
+	if resp.Error != nil || testResult == nil || resp.Body != "generated" {
+		t.Fatalf("Expected the pre-request write to be used, got %q (%v)", resp.Body, resp.Error)
+	}
+
+	if collection.Variables[0].Value != "original" || len(environment.Values) != 0 {
+		t.Error("Expected the live scopes to be unchanged")
+	}
+	if len(changes.Collection) != 1 || changes.Collection[0].Value != "generated" {
+		t.Errorf("Unexpected collection changes: %+v", changes.Collection)
+	}
+	if len(changes.Environment) != 1 || changes.Environment[0].Key != "echo" || changes.Environment[0].Value != "generated" {
+		t.Errorf("Unexpected environment changes: %+v", changes.Environment)
+	}
+}
@@ -0,0 +1,204 @@
+package http
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"postOffice/internal/postman"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	harVersion         = "1.2"
+	harCreatorName     = "postOffice"
+	harCreatorVersion  = "1.0"
+	harDateTimeFormat  = "2006-01-02T15:04:05.000Z07:00"
+	defaultHTTPVersion = "HTTP/1.1"
+)
+
+func BuildHAR(responses []*Response) *postman.HAR {
+	har := &postman.HAR{
+		Log: postman.HARLog{
+			Version: harVersion,
+			Creator: postman.HARCreator{Name: harCreatorName, Version: harCreatorVersion},
+			Entries: []postman.HAREntry{},
+		},
+	}
+
+	var recorded []*Response
+	for _, resp := range responses {
+		if resp != nil && resp.RequestURL != "" {
+			recorded = append(recorded, resp)
+		}
+	}
+	sort.SliceStable(recorded, func(i, j int) bool {
+		return recorded[i].StartedAt.Before(recorded[j].StartedAt)
+	})
+
+	for _, resp := range recorded {
+		har.Log.Entries = append(har.Log.Entries, buildHAREntry(resp))
+	}
+
+	return har
+}
+
+func buildHAREntry(resp *Response) postman.HAREntry {
+	entry := postman.HAREntry{
+		StartedDateTime: resp.StartedAt.Format(harDateTimeFormat),
+		Time:            harMillis(resp.Duration),
+		Request:         buildHARRequest(resp),
+		Response:        buildHARResponse(resp),
+		Timings:         buildHARTimings(resp),
+	}
+	if resp.Error != nil {
+		entry.Comment = resp.Error.Error()
+	}
+
+	return entry
+}
+
+// buildHARTimings maps the recorded phases onto HAR timings. HAR counts the
+// TLS handshake as part of connect, and uses -1 for phases that did not
+// happen, such as DNS and connect on a reused connection. Responses without
+// recorded phases report their whole duration as wait.
+func buildHARTimings(resp *Response) postman.HARTimings {
+	timings := resp.Timings
+	if timings.TTFB == 0 && timings.Download == 0 {
+		return postman.HARTimings{Blocked: -1, DNS: -1, Connect: -1, Wait: harMillis(resp.Duration), SSL: -1}
+	}
+
+	optional := func(d time.Duration) float64 {
+		if d <= 0 {
+			return -1
+		}
+		return harMillis(d)
+	}
+	connect := optional(timings.Connect)
+	if connect >= 0 {
+		connect = harMillis(timings.Connect + timings.TLS)
+	}
+
+	return postman.HARTimings{
+		Blocked: -1,
+		DNS:     optional(timings.DNS),
+		Connect: connect,
+		Send:    0,
+		Wait:    harMillis(timings.TTFB),
+		Receive: harMillis(timings.Download),
+		SSL:     optional(timings.TLS),
+	}
+}
+
+func harMillis(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+func buildHARRequest(resp *Response) postman.HARRequest {
+	header := http.Header{}
+	for key, value := range resp.RequestHeaders {
+		header.Set(key, value)
+	}
+
+	request := postman.HARRequest{
+		Method:      resp.RequestMethod,
+		URL:         resp.RequestURL,
+		HTTPVersion: harHTTPVersion(resp),
+		Cookies:     []postman.HARNameValue{},
+		Headers:     harHeaders(header),
+		QueryString: []postman.HARNameValue{},
+		HeadersSize: -1,
+		BodySize:    int64(len(resp.RequestBody)),
+	}
+
+	for _, cookie := range (&http.Request{Header: header}).Cookies() {
+		request.Cookies = append(request.Cookies, postman.HARNameValue{Name: cookie.Name, Value: cookie.Value})
+	}
+
+	if parsed, err := url.Parse(resp.RequestURL); err == nil {
+		query := parsed.Query()
+		keys := make([]string, 0, len(query))
+		for key := range query {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			for _, value := range query[key] {
+				request.QueryString = append(request.QueryString, postman.HARNameValue{Name: key, Value: value})
+			}
+		}
+	}
+
+	if resp.RequestBody != "" {
+		request.PostData = &postman.HARPostData{
+			MimeType: header.Get("Content-Type"),
+			Text:     resp.RequestBody,
+		}
+	}
+
+	return request
+}
+
+func buildHARResponse(resp *Response) postman.HARResponse {
+	header := http.Header(resp.Headers)
+	_, statusText, _ := strings.Cut(resp.Status, " ")
+
+	response := postman.HARResponse{
+		Status:      resp.StatusCode,
+		StatusText:  statusText,
+		HTTPVersion: harHTTPVersion(resp),
+		Cookies:     []postman.HARNameValue{},
+		Headers:     harHeaders(header),
+		Content: postman.HARContent{
+			Size:     resp.Size(),
+			MimeType: header.Get("Content-Type"),
+		},
+		RedirectURL: header.Get("Location"),
+		HeadersSize: -1,
+		BodySize:    resp.Size(),
+	}
+	if resp.HeaderSize > 0 {
+		response.HeadersSize = resp.HeaderSize
+	}
+	if resp.TransferSize > 0 {
+		response.BodySize = resp.TransferSize
+	}
+
+	for _, cookie := range (&http.Response{Header: header}).Cookies() {
+		response.Cookies = append(response.Cookies, postman.HARNameValue{Name: cookie.Name, Value: cookie.Value})
+	}
+
+	if utf8.ValidString(resp.Body) {
+		response.Content.Text = resp.Body
+	} else {
+		response.Content.Text = base64.StdEncoding.EncodeToString([]byte(resp.Body))
+		response.Content.Encoding = "base64"
+	}
+
+	return response
+}
+
+func harHeaders(header http.Header) []postman.HARNameValue {
+	keys := make([]string, 0, len(header))
+	for key := range header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	headers := []postman.HARNameValue{}
+	for _, key := range keys {
+		for _, value := range header[key] {
+			headers = append(headers, postman.HARNameValue{Name: key, Value: value})
+		}
+	}
+	return headers
+}
+
+func harHTTPVersion(resp *Response) string {
+	if resp.Proto != "" {
+		return resp.Proto
+	}
+	return defaultHTTPVersion
+}
@@ -0,0 +1,129 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"postOffice/internal/postman"
+	"testing"
+	"time"
+)
+
+func TestBuildHAR_FromExecutedResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc"})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	executor := NewExecutor()
+	req := &postman.Request{
+		Method: "POST",
+		URL:    postman.URL{Raw: server.URL + "/items?debug=1"},
+		Header: []postman.Header{
+			{Key: "Content-Type", Value: "application/json"},
+			{Key: "Cookie", Value: "theme=dark"},
+		},
+		Body: &postman.Body{Mode: "raw", Raw: `{"name":"widget"}`},
+	}
+
+	resp, _ := executor.Execute(req, nil, nil, nil, nil)
+	if resp.Error != nil {
+		t.Fatalf("Request failed: %v", resp.Error)
+	}
+
+	har := BuildHAR([]*Response{resp})
+
+	if har.Log.Version != "1.2" || len(har.Log.Entries) != 1 {
+		t.Fatalf("Expected HAR 1.2 with 1 entry, got %+v", har.Log)
+	}
+	entry := har.Log.Entries[0]
+
+	if entry.Request.Method != "POST" || entry.Request.URL != server.URL+"/items?debug=1" {
+		t.Errorf("Unexpected request: %s %s", entry.Request.Method, entry.Request.URL)
+	}
+	if entry.Request.PostData == nil || entry.Request.PostData.Text != `{"name":"widget"}` ||
+		entry.Request.PostData.MimeType != "application/json" {
+		t.Errorf("Unexpected post data: %+v", entry.Request.PostData)
+	}
+	if len(entry.Request.QueryString) != 1 || entry.Request.QueryString[0].Name != "debug" {
+		t.Errorf("Unexpected query string: %+v", entry.Request.QueryString)
+	}
+	if len(entry.Request.Cookies) != 1 || entry.Request.Cookies[0].Value != "dark" {
+		t.Errorf("Unexpected request cookies: %+v", entry.Request.Cookies)
+	}
+
+	if entry.Response.Status != 201 || entry.Response.StatusText != "Created" {
+		t.Errorf("Unexpected response status: %d %s", entry.Response.Status, entry.Response.StatusText)
+	}
+	if entry.Response.Content.Text != `{"ok":true}` || entry.Response.Content.MimeType != "application/json" {
+		t.Errorf("Unexpected response content: %+v", entry.Response.Content)
+	}
+	if len(entry.Response.Cookies) != 1 || entry.Response.Cookies[0].Name != "session" {
+		t.Errorf("Unexpected response cookies: %+v", entry.Response.Cookies)
+	}
+	if entry.Response.HTTPVersion != "HTTP/1.1" {
+		t.Errorf("Expected HTTP/1.1, got %s", entry.Response.HTTPVersion)
+	}
+	if entry.Time <= 0 || entry.Timings.Wait <= 0 || entry.Timings.Wait > entry.Time {
+		t.Errorf("Expected wait within the total time, got time=%v wait=%v", entry.Time, entry.Timings.Wait)
+	}
+	if entry.Timings.Connect < 0 || entry.Timings.SSL != -1 {
+		t.Errorf("Expected a plain HTTP connect timing without SSL, got %+v", entry.Timings)
+	}
+	if _, err := time.Parse(time.RFC3339, entry.StartedDateTime); err != nil {
+		t.Errorf("Expected ISO 8601 startedDateTime, got %s", entry.StartedDateTime)
+	}
+}
+
+func TestBuildHAR_OrdersAndSkips(t *testing.T) {
+	now := time.Now()
+	responses := []*Response{
+		{RequestMethod: "GET", RequestURL: "https://example.com/b", StartedAt: now.Add(time.Second), StatusCode: 200, Status: "200 OK"},
+		nil,
+		{RequestMethod: "GET", RequestURL: "https://example.com/a", StartedAt: now, Error: errors.New("connection refused")},
+		{Error: errors.New("invalid URL")},
+		{RequestMethod: "GET", RequestURL: "https://example.com/bin", StartedAt: now.Add(2 * time.Second), Body: "\xff\xfe"},
+	}
+
+	har := BuildHAR(responses)
+
+	if len(har.Log.Entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(har.Log.Entries))
+	}
+	if har.Log.Entries[0].Request.URL != "https://example.com/a" || har.Log.Entries[0].Comment != "connection refused" {
+		t.Errorf("Expected failed request first with comment, got %+v", har.Log.Entries[0])
+	}
+	if har.Log.Entries[2].Response.Content.Encoding != "base64" {
+		t.Errorf("Expected binary body to be base64 encoded, got %+v", har.Log.Entries[2].Response.Content)
+	}
+}
+
+func TestBuildHAREntry_MapsRecordedTimings(t *testing.T) {
+	resp := &Response{
+		RequestMethod: "GET",
+		RequestURL:    "https://example.com",
+		Duration:      100 * time.Millisecond,
+		Timings: Timings{
+			DNS:      5 * time.Millisecond,
+			Connect:  10 * time.Millisecond,
+			TLS:      20 * time.Millisecond,
+			TTFB:     40 * time.Millisecond,
+			Download: 15 * time.Millisecond,
+		},
+	}
+
+	timings := buildHAREntry(resp).Timings
+	expected := postman.HARTimings{Blocked: -1, DNS: 5, Connect: 30, Send: 0, Wait: 40, Receive: 15, SSL: 20}
+	if timings != expected {
+		t.Errorf("Expected %+v, got %+v", expected, timings)
+	}
+
+	resp.Timings = Timings{TTFB: 40 * time.Millisecond, Download: 15 * time.Millisecond, ConnectionReused: true}
+	timings = buildHAREntry(resp).Timings
+	if timings.DNS != -1 || timings.Connect != -1 || timings.SSL != -1 || timings.Wait != 40 {
+		t.Errorf("Expected DNS, connect and SSL not to apply on a reused connection, got %+v", timings)
+	}
+}
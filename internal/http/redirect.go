@@ -0,0 +1,59 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const maxRedirects = 10
+
+type RedirectHop struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Status     string
+	Location   string
+	Headers    map[string][]string
+	Duration   time.Duration
+}
+
+type tracerContextKey struct{}
+
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if tracer, ok := req.Context().Value(tracerContextKey{}).(*requestTracer); ok && req.Response != nil {
+		tracer.redirect(via[len(via)-1], req.Response)
+	}
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+	return nil
+}
+
+func (t *requestTracer) redirect(previous *http.Request, resp *http.Response) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	t.hops = append(t.hops, RedirectHop{
+		Method:     previous.Method,
+		URL:        previous.URL.String(),
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Location:   resp.Header.Get("Location"),
+		Headers:    resp.Header,
+		Duration:   now.Sub(t.hopStart),
+	})
+
+	t.hopStart = now
+	t.dnsStart, t.dnsDone = time.Time{}, time.Time{}
+	t.connectStart, t.connectDone = time.Time{}, time.Time{}
+	t.tlsStart, t.tlsDone = time.Time{}, time.Time{}
+	t.gotConn, t.firstByte = time.Time{}, time.Time{}
+}
+
+func (t *requestTracer) redirects() []RedirectHop {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.hops
+}
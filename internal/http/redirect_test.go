@@ -0,0 +1,77 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"postOffice/internal/postman"
+	"strings"
+	"testing"
+)
+
+func TestExecute_RecordsRedirectChain(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc"})
+		http.Redirect(w, r, "/authorize", http.StatusSeeOther)
+	})
+	mux.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/callback?code=xyz", http.StatusFound)
+	})
+	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("done"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	request := &postman.Request{
+		Method: "POST",
+		URL:    postman.URL{Raw: server.URL + "/login"},
+		Body:   &postman.Body{Mode: "raw", Raw: "user=me"},
+	}
+	resp, _ := NewExecutor().Execute(request, nil, nil, nil, nil)
+	if resp.Error != nil {
+		t.Fatalf("Expected no error, got %v", resp.Error)
+	}
+
+	if len(resp.Redirects) != 2 {
+		t.Fatalf("Expected 2 redirect hops, got %d", len(resp.Redirects))
+	}
+	first, second := resp.Redirects[0], resp.Redirects[1]
+	if first.Method != "POST" || first.URL != server.URL+"/login" || first.StatusCode != http.StatusSeeOther || first.Location != "/authorize" {
+		t.Errorf("Unexpected first hop: %+v", first)
+	}
+	if len(first.Headers["Set-Cookie"]) != 1 || first.Duration <= 0 {
+		t.Errorf("Expected first hop headers and timing, got %+v", first)
+	}
+	if second.Method != "GET" || second.StatusCode != http.StatusFound || second.Location != "/callback?code=xyz" {
+		t.Errorf("Unexpected second hop: %+v", second)
+	}
+
+	if resp.RequestURL != server.URL+"/login" || resp.FinalURL != server.URL+"/callback?code=xyz" || resp.FinalMethod != "GET" {
+		t.Errorf("Expected original and final URLs, got %s (%s %s)", resp.RequestURL, resp.FinalMethod, resp.FinalURL)
+	}
+	if resp.Body != "done" {
+		t.Errorf("Expected final body, got %q", resp.Body)
+	}
+	if resp.Timings.Redirects != first.Duration+second.Duration {
+		t.Errorf("Expected redirect phase %v to sum the hops, got %v", first.Duration+second.Duration, resp.Timings.Redirects)
+	}
+	if resp.Timings.Network() > resp.Duration {
+		t.Errorf("Expected phases %v to fit in the duration %v", resp.Timings.Network(), resp.Duration)
+	}
+}
+
+func TestExecute_StopsRedirectLoops(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	}))
+	defer server.Close()
+
+	resp, _ := NewExecutor().Execute(&postman.Request{Method: "GET", URL: postman.URL{Raw: server.URL}}, nil, nil, nil, nil)
+	if resp.Error == nil || !strings.Contains(resp.Error.Error(), "stopped after 10 redirects") {
+		t.Fatalf("Expected redirect limit error, got %v", resp.Error)
+	}
+	if len(resp.Redirects) != maxRedirects {
+		t.Errorf("Expected %d recorded hops, got %d", maxRedirects, len(resp.Redirects))
+	}
+}
@@ -0,0 +1,159 @@
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+type Timings struct {
+	PreRequestScript time.Duration
+	Redirects        time.Duration
+	DNS              time.Duration
+	Connect          time.Duration
+	TLS              time.Duration
+	TTFB             time.Duration
+	Download         time.Duration
+	TestScript       time.Duration
+	ConnectionReused bool
+}
+
+type TimingPhase struct {
+	Name     string
+	Start    time.Duration
+	Duration time.Duration
+}
+
+type requestTracer struct {
+	mu           sync.Mutex
+	start        time.Time
+	hopStart     time.Time
+	dnsStart     time.Time
+	dnsDone      time.Time
+	connectStart time.Time
+	connectDone  time.Time
+	tlsStart     time.Time
+	tlsDone      time.Time
+	gotConn      time.Time
+	firstByte    time.Time
+	reused       bool
+	remoteAddr   string
+	hops         []RedirectHop
+}
+
+func newRequestTracer() *requestTracer {
+	now := time.Now()
+	return &requestTracer{start: now, hopStart: now}
+}
+
+func (t *requestTracer) mark(target *time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if target.IsZero() {
+		*target = time.Now()
+	}
+}
+
+func (t *requestTracer) withTrace(req *http.Request) *http.Request {
+	trace := &httptrace.ClientTrace{
+		DNSStart:          func(httptrace.DNSStartInfo) { t.mark(&t.dnsStart) },
+		DNSDone:           func(httptrace.DNSDoneInfo) { t.mark(&t.dnsDone) },
+		ConnectStart:      func(string, string) { t.mark(&t.connectStart) },
+		ConnectDone:       func(string, string, error) { t.mark(&t.connectDone) },
+		TLSHandshakeStart: func() { t.mark(&t.tlsStart) },
+		TLSHandshakeDone:  func(tls.ConnectionState, error) { t.mark(&t.tlsDone) },
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.mark(&t.gotConn)
+			t.mu.Lock()
+			t.reused = info.Reused
+			if info.Conn != nil {
+				t.remoteAddr = info.Conn.RemoteAddr().String()
+			}
+			t.mu.Unlock()
+		},
+		GotFirstResponseByte: func() { t.mark(&t.firstByte) },
+	}
+	ctx := context.WithValue(req.Context(), tracerContextKey{}, t)
+	return req.WithContext(httptrace.WithClientTrace(ctx, trace))
+}
+
+func (t *requestTracer) remoteAddress() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.remoteAddr
+}
+
+func between(from, to time.Time) time.Duration {
+	if from.IsZero() || to.IsZero() || to.Before(from) {
+		return 0
+	}
+	return to.Sub(from)
+}
+
+func (t *requestTracer) record(timings *Timings, done time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	waitFrom := t.gotConn
+	if waitFrom.IsZero() {
+		waitFrom = t.hopStart
+	}
+
+	timings.Redirects = 0
+	for _, hop := range t.hops {
+		timings.Redirects += hop.Duration
+	}
+	timings.DNS = between(t.dnsStart, t.dnsDone)
+	timings.Connect = between(t.connectStart, t.connectDone)
+	timings.TLS = between(t.tlsStart, t.tlsDone)
+	timings.TTFB = between(waitFrom, t.firstByte)
+	timings.Download = between(t.firstByte, done)
+	timings.ConnectionReused = t.reused
+}
+
+func (t Timings) Network() time.Duration {
+	return t.Redirects + t.DNS + t.Connect + t.TLS + t.TTFB + t.Download
+}
+
+func (t Timings) Total() time.Duration {
+	return t.PreRequestScript + t.Network() + t.TestScript
+}
+
+func (t Timings) Phases() []TimingPhase {
+	phases := []TimingPhase{
+		{Name: "Pre-request", Duration: t.PreRequestScript},
+		{Name: "Redirects", Duration: t.Redirects},
+		{Name: "DNS", Duration: t.DNS},
+		{Name: "Connect", Duration: t.Connect},
+		{Name: "TLS", Duration: t.TLS},
+		{Name: "TTFB", Duration: t.TTFB},
+		{Name: "Download", Duration: t.Download},
+		{Name: "Tests", Duration: t.TestScript},
+	}
+
+	var offset time.Duration
+	for i := range phases {
+		phases[i].Start = offset
+		offset += phases[i].Duration
+	}
+	return phases
+}
+
+func (t Timings) Milliseconds() map[string]float64 {
+	millis := func(d time.Duration) float64 {
+		return float64(d.Microseconds()) / 1000
+	}
+	return map[string]float64{
+		"preRequestScript": millis(t.PreRequestScript),
+		"redirects":        millis(t.Redirects),
+		"dns":              millis(t.DNS),
+		"connect":          millis(t.Connect),
+		"tls":              millis(t.TLS),
+		"ttfb":             millis(t.TTFB),
+		"download":         millis(t.Download),
+		"total":            millis(t.Network()),
+	}
+}
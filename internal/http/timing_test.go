@@ -0,0 +1,83 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"postOffice/internal/postman"
+	"testing"
+	"time"
+)
+
+func TestTimings_Phases(t *testing.T) {
+	timings := Timings{
+		PreRequestScript: 5 * time.Millisecond,
+		DNS:              10 * time.Millisecond,
+		Connect:          20 * time.Millisecond,
+		TTFB:             40 * time.Millisecond,
+		Download:         15 * time.Millisecond,
+		TestScript:       10 * time.Millisecond,
+	}
+
+	phases := timings.Phases()
+	if len(phases) != 8 {
+		t.Fatalf("Expected 8 phases, got %d", len(phases))
+	}
+
+	var offset time.Duration
+	for _, phase := range phases {
+		if phase.Start != offset {
+			t.Errorf("%s: expected start %v, got %v", phase.Name, offset, phase.Start)
+		}
+		offset += phase.Duration
+	}
+	if offset != timings.Total() || timings.Total() != 100*time.Millisecond {
+		t.Errorf("Expected phases to sum to total 100ms, got %v", offset)
+	}
+	if timings.Network() != 85*time.Millisecond {
+		t.Errorf("Expected network time 85ms, got %v", timings.Network())
+	}
+
+	millis := timings.Milliseconds()
+	for key, expected := range map[string]float64{"preRequestScript": 5, "redirects": 0, "dns": 10, "connect": 20, "tls": 0, "ttfb": 40, "download": 15, "total": 85} {
+		if millis[key] != expected {
+			t.Errorf("Milliseconds()[%q] = %v, expected %v", key, millis[key], expected)
+		}
+	}
+}
+
+func TestExecute_RecordsTimings(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(5 * time.Millisecond)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	executor := NewExecutor()
+	executor.client = server.Client()
+	request := &postman.Request{Method: "GET", URL: postman.URL{Raw: server.URL}}
+
+	first, _ := executor.Execute(request, nil, nil, nil, nil)
+	if first.Error != nil {
+		t.Fatalf("Expected no error, got %v", first.Error)
+	}
+	if first.Timings.Connect <= 0 || first.Timings.TLS <= 0 {
+		t.Errorf("Expected connect and TLS timings on a new connection, got %+v", first.Timings)
+	}
+	if first.Timings.TTFB < 5*time.Millisecond {
+		t.Errorf("Expected TTFB to include server processing time, got %v", first.Timings.TTFB)
+	}
+	if first.Timings.ConnectionReused {
+		t.Error("Expected first request to open a new connection")
+	}
+	if first.Duration < first.Timings.Network() {
+		t.Errorf("Expected duration %v to cover network phases %v", first.Duration, first.Timings.Network())
+	}
+
+	second, _ := executor.Execute(request, nil, nil, nil, nil)
+	if second.Error != nil {
+		t.Fatalf("Expected no error, got %v", second.Error)
+	}
+	if !second.Timings.ConnectionReused || second.Timings.Connect != 0 || second.Timings.TLS != 0 {
+		t.Errorf("Expected second request to reuse the connection, got %+v", second.Timings)
+	}
+}
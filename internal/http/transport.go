@@ -0,0 +1,23 @@
+package http
+
+import (
+	"net/http"
+	"time"
+)
+
+func NewTunedTransport(connections int) *http.Transport {
+	connections = max(connections, 1)
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = max(connections, 100)
+	transport.MaxIdleConnsPerHost = connections
+	transport.IdleConnTimeout = 90 * time.Second
+	transport.ResponseHeaderTimeout = 30 * time.Second
+	return transport
+}
+
+func (e *Executor) WithTransport(transport http.RoundTripper) *Executor {
+	client := *e.client
+	client.Transport = transport
+	return &Executor{client: &client, maxBodyBytes: e.maxBodyBytes}
+}
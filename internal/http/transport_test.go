@@ -0,0 +1,43 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"postOffice/internal/postman"
+	"testing"
+)
+
+func TestNewTunedTransport(t *testing.T) {
+	transport := NewTunedTransport(250)
+	if transport.MaxIdleConnsPerHost != 250 || transport.MaxIdleConns != 250 {
+		t.Errorf("Expected idle connections for every user, got %d per host, %d total", transport.MaxIdleConnsPerHost, transport.MaxIdleConns)
+	}
+	if transport := NewTunedTransport(0); transport.MaxIdleConnsPerHost != 1 || transport.MaxIdleConns != 100 {
+		t.Errorf("Expected sensible minimums, got %d per host, %d total", transport.MaxIdleConnsPerHost, transport.MaxIdleConns)
+	}
+}
+
+func TestExecutor_WithTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello"))
+	}))
+	defer server.Close()
+
+	base := NewExecutor()
+	base.SetMaxBodyBytes(2)
+	transport := NewTunedTransport(4)
+	executor := base.WithTransport(transport)
+
+	if executor.client.Transport != transport || base.client.Transport != nil {
+		t.Error("Expected only the copy to use the tuned transport")
+	}
+	if executor.client.CheckRedirect == nil || executor.client.Timeout != base.client.Timeout {
+		t.Error("Expected the copy to keep the client settings")
+	}
+
+	resp, _ := executor.Execute(&postman.Request{Method: "GET", URL: postman.URL{Raw: server.URL}}, nil, nil, nil, nil)
+	defer resp.RemoveBodyFile()
+	if resp.Error != nil || resp.BodyFile == "" {
+		t.Errorf("Expected the body limit to carry over, got body %q (%v)", resp.Body, resp.Error)
+	}
+}
@@ -0,0 +1,80 @@
+package postman
+
+import "slices"
+
+func (c *Collection) Clone() *Collection {
+	if c == nil {
+		return nil
+	}
+	copied := *c
+	copied.Items = cloneItems(c.Items)
+	copied.Variables = slices.Clone(c.Variables)
+	copied.Events = cloneEvents(c.Events)
+	return &copied
+}
+
+func (e *Environment) Clone() *Environment {
+	if e == nil {
+		return nil
+	}
+	copied := *e
+	copied.Values = slices.Clone(e.Values)
+	return &copied
+}
+
+func (r *Request) Clone() *Request {
+	if r == nil {
+		return nil
+	}
+	copied := *r
+	copied.Header = slices.Clone(r.Header)
+	copied.URL.Host = slices.Clone(r.URL.Host)
+	copied.URL.Path = slices.Clone(r.URL.Path)
+	if r.Body != nil {
+		body := *r.Body
+		body.FormData = slices.Clone(r.Body.FormData)
+		copied.Body = &body
+	}
+	return &copied
+}
+
+func cloneItems(items []Item) []Item {
+	if items == nil {
+		return nil
+	}
+	copied := make([]Item, len(items))
+	for i, item := range items {
+		copied[i] = item
+		copied[i].Request = item.Request.Clone()
+		copied[i].Items = cloneItems(item.Items)
+		copied[i].Variables = slices.Clone(item.Variables)
+		copied[i].Events = cloneEvents(item.Events)
+		copied[i].Responses = cloneResponses(item.Responses)
+	}
+	return copied
+}
+
+func cloneEvents(events []Event) []Event {
+	if events == nil {
+		return nil
+	}
+	copied := make([]Event, len(events))
+	for i, event := range events {
+		copied[i] = event
+		copied[i].Script.Exec = slices.Clone(event.Script.Exec)
+	}
+	return copied
+}
+
+func cloneResponses(responses []Response) []Response {
+	if responses == nil {
+		return nil
+	}
+	copied := make([]Response, len(responses))
+	for i, response := range responses {
+		copied[i] = response
+		copied[i].OriginalRequest = response.OriginalRequest.Clone()
+		copied[i].Header = slices.Clone(response.Header)
+	}
+	return copied
+}
@@ -0,0 +1,35 @@
+package postman
+
+import "testing"
+
+func TestCollectionClone_IsIndependent(t *testing.T) {
+	original := &Collection{
+		Info: Info{Name: "API"},
+		Items: []Item{
+			{Name: "Folder", Items: []Item{
+				{Name: "Get", Request: &Request{Method: "GET", Header: []Header{{Key: "A", Value: "1"}}, Body: &Body{Mode: "raw", Raw: "x"}}},
+			}},
+			NewFolder("Empty"),
+		},
+		Variables: []Variable{{Key: "base", Value: "a"}},
+	}
+
+	clone := original.Clone()
+	request := clone.Items[0].Items[0].Request
+	request.Method = "POST"
+	request.Header[0].Value = "2"
+	request.Body.Raw = "y"
+	clone.Variables[0].Value = "b"
+	clone.Items[0].Items = append(clone.Items[0].Items, Item{Name: "New"})
+
+	got := original.Items[0].Items[0].Request
+	if got.Method != "GET" || got.Header[0].Value != "1" || got.Body.Raw != "x" {
+		t.Errorf("Expected original request to be unchanged, got %+v", got)
+	}
+	if original.Variables[0].Value != "a" || len(original.Items[0].Items) != 1 {
+		t.Error("Expected original variables and items to be unchanged")
+	}
+	if !clone.Items[1].IsFolder() {
+		t.Error("Expected empty folder to stay a folder")
+	}
+}
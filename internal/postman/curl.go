@@ -0,0 +1,390 @@
+package postman
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var curlFlagsWithValue = map[string]bool{
+	"-X":                true,
+	"--request":         true,
+	"-H":                true,
+	"--header":          true,
+	"-d":                true,
+	"--data":            true,
+	"--data-raw":        true,
+	"--data-ascii":      true,
+	"--data-binary":     true,
+	"--data-urlencode":  true,
+	"--json":            true,
+	"-F":                true,
+	"--form":            true,
+	"--form-string":     true,
+	"-u":                true,
+	"--user":            true,
+	"-A":                true,
+	"--user-agent":      true,
+	"-e":                true,
+	"--referer":         true,
+	"-b":                true,
+	"--cookie":          true,
+	"--url":             true,
+	"-o":                true,
+	"--output":          true,
+	"-m":                true,
+	"--max-time":        true,
+	"-w":                true,
+	"--write-out":       true,
+	"-x":                true,
+	"--proxy":           true,
+	"-c":                true,
+	"--cookie-jar":      true,
+	"-E":                true,
+	"--cert":            true,
+	"--connect-timeout": true,
+	"--retry":           true,
+	"--cacert":          true,
+	"--key":             true,
+	"--resolve":         true,
+	"--limit-rate":      true,
+	"--max-redirs":      true,
+	"--proxy-user":      true,
+}
+
+func ParseCurlCommand(command string) (*Item, error) {
+	tokens, err := tokenizeCurl(command)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) > 0 && tokens[0] == "$" {
+		tokens = tokens[1:]
+	}
+	if len(tokens) == 0 || strings.ToLower(tokens[0]) != "curl" {
+		return nil, fmt.Errorf("not a curl command")
+	}
+
+	method := ""
+	rawURL := ""
+	getMode := false
+	var headers []Header
+	var data []string
+	var form []FormParam
+
+	for i := 1; i < len(tokens); i++ {
+		token := tokens[i]
+		name, value, hasValue := token, "", false
+
+		if strings.HasPrefix(token, "--") {
+			if eq := strings.Index(token, "="); eq > 0 {
+				name, value, hasValue = token[:eq], token[eq+1:], true
+			}
+		} else if strings.HasPrefix(token, "-") && len(token) > 2 && curlFlagsWithValue[token[:2]] {
+			name, value, hasValue = token[:2], token[2:], true
+		}
+
+		if curlFlagsWithValue[name] && !hasValue {
+			if i+1 >= len(tokens) {
+				return nil, fmt.Errorf("missing value for %s", name)
+			}
+			i++
+			value = tokens[i]
+		}
+
+		switch name {
+		case "-X", "--request":
+			method = strings.ToUpper(value)
+		case "-H", "--header":
+			key, headerValue, _ := strings.Cut(value, ":")
+			key = strings.TrimSuffix(strings.TrimSpace(key), ";")
+			if key != "" {
+				headers = append(headers, Header{Key: key, Value: strings.TrimSpace(headerValue)})
+			}
+		case "-d", "--data", "--data-ascii", "--data-binary", "--data-raw":
+			if name != "--data-raw" && strings.HasPrefix(value, "@") {
+				content, err := os.ReadFile(value[1:])
+				if err != nil {
+					return nil, fmt.Errorf("failed to read data file: %w", err)
+				}
+				value = string(content)
+				if name != "--data-binary" {
+					value = strings.NewReplacer("\r", "", "\n", "").Replace(value)
+				}
+			}
+			data = append(data, value)
+		case "--data-urlencode":
+			data = append(data, urlEncodeCurlData(value))
+		case "--json":
+			data = append(data, value)
+			if !hasHeader(headers, "Content-Type") {
+				headers = append(headers, Header{Key: "Content-Type", Value: "application/json"})
+			}
+			if !hasHeader(headers, "Accept") {
+				headers = append(headers, Header{Key: "Accept", Value: "application/json"})
+			}
+		case "-F", "--form", "--form-string":
+			key, fieldValue, _ := strings.Cut(value, "=")
+			if name != "--form-string" && strings.HasPrefix(fieldValue, "@") {
+				src, _, _ := strings.Cut(fieldValue[1:], ";")
+				form = append(form, FormParam{Key: key, Type: "file", Src: src})
+			} else {
+				form = append(form, FormParam{Key: key, Value: fieldValue, Type: "text"})
+			}
+		case "-u", "--user":
+			credentials := base64.StdEncoding.EncodeToString([]byte(value))
+			headers = append(headers, Header{Key: "Authorization", Value: "Basic " + credentials})
+		case "-A", "--user-agent":
+			headers = append(headers, Header{Key: "User-Agent", Value: value})
+		case "-e", "--referer":
+			headers = append(headers, Header{Key: "Referer", Value: value})
+		case "-b", "--cookie":
+			if strings.Contains(value, "=") {
+				headers = append(headers, Header{Key: "Cookie", Value: value})
+			}
+		case "-G", "--get":
+			getMode = true
+		case "-I", "--head":
+			method = "HEAD"
+		case "--url":
+			rawURL = value
+		default:
+			if strings.HasPrefix(token, "-") && len(token) > 1 {
+				continue
+			}
+			if rawURL == "" {
+				rawURL = token
+			}
+		}
+	}
+
+	if rawURL == "" {
+		return nil, fmt.Errorf("no URL found in curl command")
+	}
+	if !strings.Contains(rawURL, "://") && !strings.HasPrefix(rawURL, "{{") {
+		rawURL = "http://" + rawURL
+	}
+
+	if getMode && len(data) > 0 {
+		separator := "?"
+		if strings.Contains(rawURL, "?") {
+			separator = "&"
+		}
+		rawURL += separator + strings.Join(data, "&")
+		data = nil
+	}
+
+	var body *Body
+	if len(form) > 0 {
+		body = &Body{Mode: "formdata", FormData: form}
+	} else if len(data) > 0 {
+		body = &Body{Mode: "raw", Raw: strings.Join(data, "&")}
+		if !hasHeader(headers, "Content-Type") {
+			headers = append(headers, Header{Key: "Content-Type", Value: "application/x-www-form-urlencoded"})
+		}
+	}
+
+	if method == "" {
+		method = "GET"
+		if body != nil {
+			method = "POST"
+		}
+	}
+
+	if headers == nil {
+		headers = []Header{}
+	}
+
+	requestURL := URL{Raw: rawURL}
+	path := "/"
+	if parsed, err := url.Parse(rawURL); err == nil && parsed.Host != "" {
+		requestURL.Host = strings.Split(parsed.Hostname(), ".")
+		for _, segment := range strings.Split(parsed.Path, "/") {
+			if segment != "" {
+				requestURL.Path = append(requestURL.Path, segment)
+			}
+		}
+		if parsed.Path != "" {
+			path = parsed.Path
+		}
+	}
+
+	return &Item{
+		ID:   NewID(),
+		Name: method + " " + path,
+		Request: &Request{
+			Method: method,
+			Header: headers,
+			Body:   body,
+			URL:    requestURL,
+		},
+	}, nil
+}
+
+func hasHeader(headers []Header, key string) bool {
+	for _, header := range headers {
+		if strings.EqualFold(header.Key, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func urlEncodeCurlData(value string) string {
+	if name, content, found := strings.Cut(value, "="); found {
+		if name == "" {
+			return url.QueryEscape(content)
+		}
+		return name + "=" + url.QueryEscape(content)
+	}
+	return url.QueryEscape(value)
+}
+
+func tokenizeCurl(input string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+	inToken := false
+	runes := []rune(input)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\\':
+			if i+1 >= len(runes) {
+				continue
+			}
+			next := runes[i+1]
+			i++
+			if next == '\r' && i+1 < len(runes) && runes[i+1] == '\n' {
+				i++
+				continue
+			}
+			if next == '\n' || next == '\r' {
+				continue
+			}
+			if !inToken && (next == ' ' || next == '\t') {
+				continue
+			}
+			current.WriteRune(next)
+			inToken = true
+		case r == '\'':
+			end := i + 1
+			for end < len(runes) && runes[end] != '\'' {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("unterminated single quote")
+			}
+			current.WriteString(string(runes[i+1 : end]))
+			inToken = true
+			i = end
+		case r == '$' && i+1 < len(runes) && runes[i+1] == '\'':
+			decoded, end, err := decodeANSICString(runes, i+2)
+			if err != nil {
+				return nil, err
+			}
+			current.WriteString(decoded)
+			inToken = true
+			i = end
+		case r == '"':
+			end := i + 1
+			for ; end < len(runes) && runes[end] != '"'; end++ {
+				if runes[end] == '\\' && end+1 < len(runes) {
+					switch runes[end+1] {
+					case '"', '\\', '$', '`':
+						current.WriteRune(runes[end+1])
+						end++
+						continue
+					case '\n':
+						end++
+						continue
+					}
+				}
+				current.WriteRune(runes[end])
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("unterminated double quote")
+			}
+			inToken = true
+			i = end
+		case unicode.IsSpace(r):
+			if inToken {
+				tokens = append(tokens, current.String())
+				current.Reset()
+				inToken = false
+			}
+		default:
+			current.WriteRune(r)
+			inToken = true
+		}
+	}
+
+	if inToken {
+		tokens = append(tokens, current.String())
+	}
+
+	return tokens, nil
+}
+
+func decodeANSICString(runes []rune, start int) (string, int, error) {
+	var sb strings.Builder
+	for i := start; i < len(runes); i++ {
+		r := runes[i]
+		if r == '\'' {
+			return sb.String(), i, nil
+		}
+		if r != '\\' || i+1 >= len(runes) {
+			sb.WriteRune(r)
+			continue
+		}
+
+		i++
+		switch runes[i] {
+		case 'n':
+			sb.WriteRune('\n')
+		case 't':
+			sb.WriteRune('\t')
+		case 'r':
+			sb.WriteRune('\r')
+		case 'a':
+			sb.WriteRune('\a')
+		case 'b':
+			sb.WriteRune('\b')
+		case 'e', 'E':
+			sb.WriteRune('\x1b')
+		case 'f':
+			sb.WriteRune('\f')
+		case 'v':
+			sb.WriteRune('\v')
+		case 'x', 'u', 'U':
+			maxDigits := map[rune]int{'x': 2, 'u': 4, 'U': 8}[runes[i]]
+			end := i + 1
+			for end < len(runes) && end-i-1 < maxDigits && isHexDigit(runes[end]) {
+				end++
+			}
+			if end == i+1 {
+				sb.WriteRune('\\')
+				sb.WriteRune(runes[i])
+				continue
+			}
+			code, _ := strconv.ParseUint(string(runes[i+1:end]), 16, 32)
+			if runes[i] == 'x' {
+				sb.WriteByte(byte(code))
+			} else {
+				sb.WriteRune(rune(code))
+			}
+			i = end - 1
+		default:
+			sb.WriteRune(runes[i])
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated $'...' string")
+}
+
+func isHexDigit(r rune) bool {
+	return (r >= '0' && r <= '9') || (r >= 'a' && r <= 'f') || (r >= 'A' && r <= 'F')
+}
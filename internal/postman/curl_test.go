@@ -0,0 +1,193 @@
+package postman
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func findHeader(headers []Header, key string) (string, bool) {
+	for _, h := range headers {
+		if h.Key == key {
+			return h.Value, true
+		}
+	}
+	return "", false
+}
+
+func TestParseCurlCommand_Simple(t *testing.T) {
+	item, err := ParseCurlCommand("curl https://api.example.com/users?page=2")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if item.Request.Method != "GET" {
+		t.Errorf("Expected GET, got %s", item.Request.Method)
+	}
+	if item.Request.URL.Raw != "https://api.example.com/users?page=2" {
+		t.Errorf("Unexpected URL: %s", item.Request.URL.Raw)
+	}
+	if item.Name != "GET /users" {
+		t.Errorf("Expected name 'GET /users', got '%s'", item.Name)
+	}
+	if len(item.Request.URL.Host) != 3 || len(item.Request.URL.Path) != 1 {
+		t.Errorf("Unexpected host/path: %v %v", item.Request.URL.Host, item.Request.URL.Path)
+	}
+}
+
+func TestParseCurlCommand_MultiLineDevtools(t *testing.T) {
+	command := `curl 'https://api.example.com/items' \
+  -H 'accept: application/json' \
+  -H $'x-note: it\'s\there' \
+  --data-raw '{"name":"widget"}' \
+  --compressed \
+  -k`
+
+	item, err := ParseCurlCommand(command)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if item.Request.Method != "POST" {
+		t.Errorf("Expected POST for request with data, got %s", item.Request.Method)
+	}
+	if value, _ := findHeader(item.Request.Header, "accept"); value != "application/json" {
+		t.Errorf("Expected accept header, got '%s'", value)
+	}
+	if value, _ := findHeader(item.Request.Header, "x-note"); value != "it's\there" {
+		t.Errorf("Expected ANSI-C decoded header, got %q", value)
+	}
+	if item.Request.Body == nil || item.Request.Body.Raw != `{"name":"widget"}` {
+		t.Errorf("Unexpected body: %+v", item.Request.Body)
+	}
+	if _, ok := findHeader(item.Request.Header, "Content-Type"); !ok {
+		t.Error("Expected default Content-Type for data body")
+	}
+}
+
+func TestParseCurlCommand_FlattenedContinuations(t *testing.T) {
+	item, err := ParseCurlCommand(`curl -X PUT \ "https://api.example.com/a b" \ -H "X-Quote: say \"hi\""`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if item.Request.Method != "PUT" {
+		t.Errorf("Expected PUT, got %s", item.Request.Method)
+	}
+	if item.Request.URL.Raw != "https://api.example.com/a b" {
+		t.Errorf("Unexpected URL: %s", item.Request.URL.Raw)
+	}
+	if value, _ := findHeader(item.Request.Header, "X-Quote"); value != `say "hi"` {
+		t.Errorf("Unexpected header value: %s", value)
+	}
+}
+
+func TestParseCurlCommand_Flags(t *testing.T) {
+	tests := []struct {
+		name   string
+		cmd    string
+		method string
+		header string
+		value  string
+	}{
+		{"Attached method", "curl -XDELETE https://example.com/x", "DELETE", "", ""},
+		{"Long method with equals", "curl --request=PATCH https://example.com/x", "PATCH", "", ""},
+		{"Basic auth", "curl -u alice:secret https://example.com", "GET", "Authorization", "Basic YWxpY2U6c2VjcmV0"},
+		{"User agent", "curl -A agent/1.0 https://example.com", "GET", "User-Agent", "agent/1.0"},
+		{"Head", "curl -I https://example.com", "HEAD", "", ""},
+		{"Skips unknown flag values", "curl -o out.txt -sSL --max-time 5 https://example.com", "GET", "", ""},
+		{"JSON shorthand", `curl --json '{"a":1}' https://example.com`, "POST", "Content-Type", "application/json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, err := ParseCurlCommand(tt.cmd)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if item.Request.Method != tt.method {
+				t.Errorf("Expected method %s, got %s", tt.method, item.Request.Method)
+			}
+			if item.Request.URL.Raw != "https://example.com" && item.Request.URL.Raw != "https://example.com/x" {
+				t.Errorf("Unexpected URL: %s", item.Request.URL.Raw)
+			}
+			if tt.header != "" {
+				if value, _ := findHeader(item.Request.Header, tt.header); value != tt.value {
+					t.Errorf("Expected %s '%s', got '%s'", tt.header, tt.value, value)
+				}
+			}
+		})
+	}
+}
+
+func TestParseCurlCommand_FormData(t *testing.T) {
+	item, err := ParseCurlCommand(`curl -F name=alice -F "avatar=@/tmp/me.png;type=image/png" https://example.com/upload`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	body := item.Request.Body
+	if body == nil || body.Mode != "formdata" || len(body.FormData) != 2 {
+		t.Fatalf("Expected formdata body with 2 fields, got %+v", body)
+	}
+	if body.FormData[0].Type != "text" || body.FormData[0].Value != "alice" {
+		t.Errorf("Unexpected text field: %+v", body.FormData[0])
+	}
+	if body.FormData[1].Type != "file" || body.FormData[1].Src != "/tmp/me.png" {
+		t.Errorf("Unexpected file field: %+v", body.FormData[1])
+	}
+	if item.Request.Method != "POST" {
+		t.Errorf("Expected POST, got %s", item.Request.Method)
+	}
+}
+
+func TestParseCurlCommand_DataVariants(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "body.txt")
+	if err := os.WriteFile(dataFile, []byte("a=1\nb=2"), 0644); err != nil {
+		t.Fatalf("Failed to write data file: %v", err)
+	}
+
+	item, err := ParseCurlCommand("curl -d @" + dataFile + " --data-urlencode 'q=hello world' example.com/search")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if item.Request.Body.Raw != "a=1b=2&q=hello+world" {
+		t.Errorf("Unexpected body: %s", item.Request.Body.Raw)
+	}
+	if item.Request.URL.Raw != "http://example.com/search" {
+		t.Errorf("Expected http:// default scheme, got %s", item.Request.URL.Raw)
+	}
+
+	item, err = ParseCurlCommand("curl -G -d q=go https://example.com/search?lang=en")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if item.Request.Method != "GET" || item.Request.Body != nil {
+		t.Errorf("Expected GET without body for -G, got %s %+v", item.Request.Method, item.Request.Body)
+	}
+	if item.Request.URL.Raw != "https://example.com/search?lang=en&q=go" {
+		t.Errorf("Expected data in query string, got %s", item.Request.URL.Raw)
+	}
+}
+
+func TestParseCurlCommand_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  string
+	}{
+		{"Not curl", "wget https://example.com"},
+		{"Empty", ""},
+		{"No URL", "curl -H 'a: b'"},
+		{"Missing flag value", "curl https://example.com -H"},
+		{"Unterminated quote", "curl 'https://example.com"},
+		{"Unterminated ANSI-C", "curl $'https://example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseCurlCommand(tt.cmd); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ type CollectionFormat int
 const (
 	FormatPostman CollectionFormat = iota
 	FormatOpenAPI
+	FormatHAR
 	FormatUnknown
 )
 
@@ -26,6 +27,12 @@ func DetectFormat(data []byte) CollectionFormat {
 		return FormatOpenAPI
 	}
 
+	if log, ok := raw["log"].(map[string]interface{}); ok {
+		if _, hasEntries := log["entries"]; hasEntries {
+			return FormatHAR
+		}
+	}
+
 	if info, ok := raw["info"].(map[string]interface{}); ok {
 		if schema, ok := info["schema"].(string); ok {
 			if strings.Contains(schema, "postman") {
@@ -38,6 +38,11 @@ func TestDetectFormat(t *testing.T) {
 			jsonData: `{"info":{"title":"Test"},"paths":{"/users":{}}}`,
 			expected: FormatOpenAPI,
 		},
+		{
+			name:     "HAR 1.2",
+			jsonData: `{"log":{"version":"1.2","creator":{"name":"Chrome"},"entries":[]}}`,
+			expected: FormatHAR,
+		},
 		{
 			name:     "Invalid JSON",
 			jsonData: `{invalid}`,
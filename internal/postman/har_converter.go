@@ -0,0 +1,182 @@
+package postman
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"postOffice/internal/logger"
+	"strings"
+)
+
+var harSkippedHeaders = map[string]bool{
+	"content-length": true,
+	"host":           true,
+	"connection":     true,
+}
+
+func ConvertHARToCollection(har *HAR, name string) (*Collection, error) {
+	if har == nil {
+		return nil, fmt.Errorf("HAR cannot be nil")
+	}
+
+	if name == "" {
+		name = "HAR Import"
+	}
+
+	collection := &Collection{
+		Info: Info{
+			Name:   name,
+			Schema: postmanSchemaURL,
+		},
+		Items: []Item{},
+	}
+	if har.Log.Creator.Name != "" {
+		collection.Info.Description = fmt.Sprintf("Imported from HAR created by %s %s", har.Log.Creator.Name, har.Log.Creator.Version)
+	}
+
+	folderIndex := make(map[string]int)
+	usedNames := make(map[string]map[string]int)
+
+	for _, entry := range har.Log.Entries {
+		item, host, err := convertHAREntry(entry)
+		if err != nil {
+			return nil, err
+		}
+
+		idx, exists := folderIndex[host]
+		if !exists {
+			idx = len(collection.Items)
+			folderIndex[host] = idx
+			usedNames[host] = make(map[string]int)
+			collection.Items = append(collection.Items, NewFolder(host))
+		}
+
+		usedNames[host][item.Name]++
+		if count := usedNames[host][item.Name]; count > 1 {
+			item.Name = fmt.Sprintf("%s (%d)", item.Name, count)
+		}
+
+		collection.Items[idx].Items = append(collection.Items[idx].Items, item)
+	}
+	EnsureIDs(collection)
+
+	return collection, nil
+}
+
+func convertHAREntry(entry HAREntry) (Item, string, error) {
+	parsed, err := url.Parse(entry.Request.URL)
+	if err != nil || parsed.Host == "" {
+		return Item{}, "", fmt.Errorf("invalid request URL in HAR entry: %s", entry.Request.URL)
+	}
+
+	method := strings.ToUpper(entry.Request.Method)
+	if method == "" {
+		method = "GET"
+	}
+
+	path := parsed.Path
+	if path == "" {
+		path = "/"
+	}
+
+	request := &Request{
+		Method: method,
+		Header: []Header{},
+		URL: URL{
+			Raw:  entry.Request.URL,
+			Host: strings.Split(parsed.Hostname(), "."),
+		},
+	}
+	for _, segment := range strings.Split(parsed.Path, "/") {
+		if segment != "" {
+			request.URL.Path = append(request.URL.Path, segment)
+		}
+	}
+
+	for _, header := range entry.Request.Headers {
+		if strings.HasPrefix(header.Name, ":") || harSkippedHeaders[strings.ToLower(header.Name)] {
+			continue
+		}
+		request.Header = append(request.Header, Header{Key: header.Name, Value: header.Value})
+	}
+
+	if postData := entry.Request.PostData; postData != nil {
+		request.Body = convertHARPostData(postData)
+	}
+
+	item := Item{
+		Name:        method + " " + path,
+		Request:     request,
+		Description: entry.Comment,
+	}
+	return item, parsed.Host, nil
+}
+
+func convertHARPostData(postData *HARPostData) *Body {
+	if postData.Text != "" || len(postData.Params) == 0 {
+		return &Body{Mode: "raw", Raw: postData.Text}
+	}
+
+	if strings.HasPrefix(postData.MimeType, "multipart/form-data") {
+		body := &Body{Mode: "formdata"}
+		for _, param := range postData.Params {
+			if param.FileName != "" {
+				body.FormData = append(body.FormData, FormParam{Key: param.Name, Type: "file", Src: param.FileName})
+			} else {
+				body.FormData = append(body.FormData, FormParam{Key: param.Name, Value: param.Value, Type: "text"})
+			}
+		}
+		return body
+	}
+
+	values := url.Values{}
+	for _, param := range postData.Params {
+		values.Add(param.Name, param.Value)
+	}
+	return &Body{Mode: "raw", Raw: values.Encode()}
+}
+
+func parseHAR(data []byte, name string) (*Collection, error) {
+	var har HAR
+	if err := json.Unmarshal(data, &har); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal HAR: %w", err)
+	}
+
+	collection, err := ConvertHARToCollection(&har, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert HAR to collection: %w", err)
+	}
+
+	return collection, nil
+}
+
+func WriteHAR(har *HAR, path string) error {
+	expandedPath, err := expandPath(path)
+	if err != nil {
+		logger.LogError("WriteHAR", path, err)
+		return err
+	}
+
+	data, err := json.MarshalIndent(har, "", "  ")
+	if err != nil {
+		logger.LogError("WriteHAR", expandedPath, err)
+		return fmt.Errorf("failed to marshal HAR: %w", err)
+	}
+
+	tempPath := expandedPath + ".tmp"
+	logger.LogFileWrite(tempPath)
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		logger.LogError("WriteHAR", tempPath, err)
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+
+	logger.LogFileWrite(expandedPath)
+	if err := os.Rename(tempPath, expandedPath); err != nil {
+		os.Remove(tempPath)
+		logger.LogError("WriteHAR", expandedPath, err)
+		return fmt.Errorf("failed to rename temp file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package postman
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testHAR = `{
+  "log": {
+    "version": "1.2",
+    "creator": {"name": "Chrome", "version": "120"},
+    "entries": [
+      {
+        "startedDateTime": "2024-01-01T10:00:00.000Z",
+        "time": 12.5,
+        "request": {
+          "method": "GET",
+          "url": "https://api.example.com/users?page=1",
+          "httpVersion": "HTTP/2",
+          "cookies": [],
+          "headers": [
+            {"name": ":authority", "value": "api.example.com"},
+            {"name": "accept", "value": "application/json"},
+            {"name": "content-length", "value": "0"}
+          ],
+          "queryString": [{"name": "page", "value": "1"}],
+          "headersSize": -1,
+          "bodySize": 0
+        },
+        "response": {"status": 200, "statusText": "OK", "httpVersion": "HTTP/2", "cookies": [], "headers": [],
+          "content": {"size": 2, "mimeType": "application/json", "text": "[]"}, "redirectURL": "", "headersSize": -1, "bodySize": 2},
+        "cache": {},
+        "timings": {"send": 0, "wait": 12, "receive": 0.5}
+      },
+      {
+        "startedDateTime": "2024-01-01T10:00:01.000Z",
+        "time": 20,
+        "request": {
+          "method": "POST",
+          "url": "https://api.example.com/users",
+          "httpVersion": "HTTP/2",
+          "cookies": [],
+          "headers": [{"name": "content-type", "value": "application/json"}],
+          "queryString": [],
+          "postData": {"mimeType": "application/json", "text": "{\"name\":\"alice\"}"},
+          "headersSize": -1,
+          "bodySize": 16
+        },
+        "response": {"status": 201, "statusText": "Created", "httpVersion": "HTTP/2", "cookies": [], "headers": [],
+          "content": {"size": 0, "mimeType": ""}, "redirectURL": "", "headersSize": -1, "bodySize": 0},
+        "cache": {},
+        "timings": {"send": 0, "wait": 20, "receive": 0}
+      },
+      {
+        "startedDateTime": "2024-01-01T10:00:02.000Z",
+        "time": 5,
+        "request": {
+          "method": "GET",
+          "url": "https://cdn.example.com/app.js",
+          "httpVersion": "HTTP/2",
+          "cookies": [],
+          "headers": [],
+          "queryString": [],
+          "headersSize": -1,
+          "bodySize": 0
+        },
+        "response": {"status": 200, "statusText": "OK", "httpVersion": "HTTP/2", "cookies": [], "headers": [],
+          "content": {"size": 0, "mimeType": "text/javascript"}, "redirectURL": "", "headersSize": -1, "bodySize": 0},
+        "cache": {},
+        "timings": {"send": 0, "wait": 5, "receive": 0}
+      },
+      {
+        "startedDateTime": "2024-01-01T10:00:03.000Z",
+        "time": 11,
+        "request": {
+          "method": "GET",
+          "url": "https://api.example.com/users?page=2",
+          "httpVersion": "HTTP/2",
+          "cookies": [],
+          "headers": [],
+          "queryString": [],
+          "headersSize": -1,
+          "bodySize": 0
+        },
+        "response": {"status": 200, "statusText": "OK", "httpVersion": "HTTP/2", "cookies": [], "headers": [],
+          "content": {"size": 0, "mimeType": ""}, "redirectURL": "", "headersSize": -1, "bodySize": 0},
+        "cache": {},
+        "timings": {"send": 0, "wait": 11, "receive": 0}
+      }
+    ]
+  }
+}`
+
+func TestParseHAR_GroupsByHost(t *testing.T) {
+	collection, err := parseHAR([]byte(testHAR), "capture")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if collection.Info.Name != "capture" {
+		t.Errorf("Expected name 'capture', got '%s'", collection.Info.Name)
+	}
+	if len(collection.Items) != 2 {
+		t.Fatalf("Expected 2 host folders, got %d", len(collection.Items))
+	}
+
+	api := collection.Items[0]
+	if api.Name != "api.example.com" || len(api.Items) != 3 {
+		t.Fatalf("Expected api.example.com folder with 3 requests, got %s with %d", api.Name, len(api.Items))
+	}
+	if collection.Items[1].Name != "cdn.example.com" {
+		t.Errorf("Expected cdn.example.com folder, got %s", collection.Items[1].Name)
+	}
+
+	first := api.Items[0]
+	if first.Name != "GET /users" || first.Request.URL.Raw != "https://api.example.com/users?page=1" {
+		t.Errorf("Unexpected first request: %s %s", first.Name, first.Request.URL.Raw)
+	}
+	if len(first.Request.Header) != 1 || first.Request.Header[0].Key != "accept" {
+		t.Errorf("Expected pseudo and length headers to be dropped, got %+v", first.Request.Header)
+	}
+
+	if api.Items[1].Request.Body == nil || api.Items[1].Request.Body.Raw != `{"name":"alice"}` {
+		t.Errorf("Expected POST body, got %+v", api.Items[1].Request.Body)
+	}
+	if api.Items[2].Name != "GET /users (2)" {
+		t.Errorf("Expected duplicate name to be numbered, got '%s'", api.Items[2].Name)
+	}
+}
+
+func TestConvertHARPostData_Params(t *testing.T) {
+	body := convertHARPostData(&HARPostData{
+		MimeType: "application/x-www-form-urlencoded",
+		Params:   []HARParam{{Name: "a", Value: "1"}, {Name: "b", Value: "x y"}},
+	})
+	if body.Mode != "raw" || body.Raw != "a=1&b=x+y" {
+		t.Errorf("Unexpected urlencoded body: %+v", body)
+	}
+
+	body = convertHARPostData(&HARPostData{
+		MimeType: "multipart/form-data; boundary=abc",
+		Params:   []HARParam{{Name: "name", Value: "alice"}, {Name: "avatar", FileName: "me.png"}},
+	})
+	if body.Mode != "formdata" || len(body.FormData) != 2 || body.FormData[1].Type != "file" {
+		t.Errorf("Unexpected multipart body: %+v", body)
+	}
+}
+
+func TestConvertHARToCollection_InvalidURL(t *testing.T) {
+	har := &HAR{Log: HARLog{Entries: []HAREntry{{Request: HARRequest{Method: "GET", URL: "not a url"}}}}}
+	if _, err := ConvertHARToCollection(har, "bad"); err == nil {
+		t.Error("Expected error for invalid URL")
+	}
+	if _, err := ConvertHARToCollection(nil, "bad"); err == nil {
+		t.Error("Expected error for nil HAR")
+	}
+}
+
+func TestParser_LoadCollection_HAR(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.har")
+	if err := os.WriteFile(path, []byte(testHAR), 0644); err != nil {
+		t.Fatalf("Failed to write HAR: %v", err)
+	}
+
+	parser := NewParser()
+	collection, err := parser.LoadCollection(path)
+	if err != nil {
+		t.Fatalf("Failed to load HAR: %v", err)
+	}
+	if collection.Info.Name != "session" {
+		t.Errorf("Expected collection named after file, got '%s'", collection.Info.Name)
+	}
+	if _, exists := parser.GetCollection("session"); !exists {
+		t.Error("Expected HAR collection to be registered")
+	}
+}
+
+func TestParser_SaveCollection_KeepsHARSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.har")
+	if err := os.WriteFile(path, []byte(testHAR), 0644); err != nil {
+		t.Fatalf("Failed to write HAR: %v", err)
+	}
+
+	parser := NewParser()
+	collection, err := parser.LoadCollection(path)
+	if err != nil {
+		t.Fatalf("Failed to load HAR: %v", err)
+	}
+	collection.Items = append(collection.Items, Item{Name: "Added", Request: &Request{Method: "GET"}})
+
+	if err := parser.SaveCollection("session"); !errors.Is(err, ErrImportedCollection) {
+		t.Fatalf("Expected ErrImportedCollection, got %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != testHAR {
+		t.Fatal("Expected HAR capture to be left untouched")
+	}
+
+	newPath := filepath.Join(t.TempDir(), "session.json")
+	if err := parser.SaveCollectionAs("session", newPath); err != nil {
+		t.Fatalf("Expected save as to succeed, got %v", err)
+	}
+	data, _ := os.ReadFile(newPath)
+	if DetectFormat(data) != FormatPostman {
+		t.Error("Expected save as to write a Postman collection")
+	}
+}
+
+func TestWriteHAR(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.har")
+	har := &HAR{Log: HARLog{Version: "1.2", Creator: HARCreator{Name: "test"}, Entries: []HAREntry{}}}
+
+	if err := WriteHAR(har, path); err != nil {
+		t.Fatalf("Failed to write HAR: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read HAR: %v", err)
+	}
+	if DetectFormat(data) != FormatHAR {
+		t.Error("Expected written file to be detected as HAR")
+	}
+}
@@ -0,0 +1,58 @@
+package postman
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	mathrand "math/rand/v2"
+)
+
+func NewID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		binary.LittleEndian.PutUint64(b[0:8], mathrand.Uint64())
+		binary.LittleEndian.PutUint64(b[8:16], mathrand.Uint64())
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// EnsureIDs gives the collection and every item without an id a generated
+// one. Generated ids only key session state and are not written back, so
+// saving a file that had none leaves it unchanged.
+func EnsureIDs(collection *Collection) {
+	if collection == nil {
+		return
+	}
+	if collection.Info.PostmanID == "" {
+		collection.Info.PostmanID = NewID()
+		collection.Info.generatedID = true
+	}
+	ensureItemIDs(collection.Items)
+}
+
+func ensureItemIDs(items []Item) {
+	for i := range items {
+		if items[i].ID == "" {
+			items[i].ID = NewID()
+			items[i].generatedID = true
+		}
+		ensureItemIDs(items[i].Items)
+	}
+}
+
+func FindItemByID(items []Item, id string) (*Item, []string) {
+	if id == "" {
+		return nil, nil
+	}
+	for i := range items {
+		if items[i].ID == id {
+			return &items[i], []string{}
+		}
+		if found, path := FindItemByID(items[i].Items, id); found != nil {
+			return found, append([]string{items[i].Name}, path...)
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,124 @@
+package postman
+
+import (
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestNewID_IsUUIDv4(t *testing.T) {
+	pattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		id := NewID()
+		if !pattern.MatchString(id) {
+			t.Fatalf("Expected UUID v4, got %s", id)
+		}
+		if seen[id] {
+			t.Fatalf("Duplicate ID generated: %s", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestEnsureIDs_KeepsExistingAndFillsMissing(t *testing.T) {
+	collection := &Collection{
+		Info: Info{Name: "Test", PostmanID: "collection-id"},
+		Items: []Item{
+			{ID: "existing", Name: "Request", Request: &Request{Method: "GET"}},
+			{Name: "Folder", Items: []Item{
+				{Name: "Nested", Request: &Request{Method: "GET"}},
+			}},
+		},
+	}
+
+	EnsureIDs(collection)
+
+	if collection.Info.PostmanID != "collection-id" {
+		t.Errorf("Expected collection ID to be kept, got %s", collection.Info.PostmanID)
+	}
+	if collection.Items[0].ID != "existing" {
+		t.Errorf("Expected existing item ID to be kept, got %s", collection.Items[0].ID)
+	}
+	if collection.Items[1].ID == "" || collection.Items[1].Items[0].ID == "" {
+		t.Error("Expected folder and nested request to get IDs")
+	}
+}
+
+func TestFindItemByID(t *testing.T) {
+	collection := &Collection{
+		Items: []Item{
+			{ID: "a", Name: "Same", Request: &Request{Method: "GET"}},
+			{ID: "folder", Name: "Folder", Items: []Item{
+				{ID: "b", Name: "Same", Request: &Request{Method: "POST"}},
+			}},
+		},
+	}
+
+	item, path := FindItemByID(collection.Items, "b")
+	if item == nil {
+		t.Fatal("Expected to find nested item")
+	}
+	if item.Request.Method != "POST" {
+		t.Errorf("Expected POST request, got %s", item.Request.Method)
+	}
+	if strings.Join(path, "/") != "Folder" {
+		t.Errorf("Expected path Folder, got %v", path)
+	}
+
+	if item, _ := FindItemByID(collection.Items, "missing"); item != nil {
+		t.Error("Expected nil for unknown ID")
+	}
+}
+
+func TestLoadCollection_KeepsGeneratedIDsInMemory(t *testing.T) {
+	collection := &Collection{
+		Info: Info{Name: "IDs", Schema: "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+		Items: []Item{
+			{ID: "keep-me", Name: "Kept", Request: &Request{Method: "GET"}},
+			{Name: "Generated", Request: &Request{Method: "GET"}},
+		},
+	}
+	path := createTempCollection(t, collection)
+	original, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read collection: %v", err)
+	}
+
+	parser := NewParser()
+	loaded, err := parser.LoadCollection(path)
+	if err != nil {
+		t.Fatalf("Failed to load collection: %v", err)
+	}
+	if loaded.Items[0].ID != "keep-me" {
+		t.Errorf("Expected ID from file to be kept, got %s", loaded.Items[0].ID)
+	}
+	if loaded.Items[1].ID == "" || loaded.Info.PostmanID == "" {
+		t.Fatal("Expected missing IDs to be generated on load")
+	}
+
+	loaded.Items = append(loaded.Items, Item{ID: NewID(), Name: "Created", Request: &Request{Method: "GET"}})
+	if err := parser.SaveCollection("IDs"); err != nil {
+		t.Fatalf("Failed to save collection: %v", err)
+	}
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read saved collection: %v", err)
+	}
+	if strings.Contains(string(saved), loaded.Items[1].ID) || strings.Contains(string(saved), "_postman_id") {
+		t.Errorf("Expected generated IDs not to be saved, got %s", saved)
+	}
+	if !strings.Contains(string(saved), loaded.Items[2].ID) {
+		t.Error("Expected the ID of an item created in this session to be saved")
+	}
+
+	loaded.Items = loaded.Items[:2]
+	if err := parser.SaveCollection("IDs"); err != nil {
+		t.Fatalf("Failed to save collection: %v", err)
+	}
+	if saved, _ := os.ReadFile(path); string(saved) != string(original) {
+		t.Errorf("Expected file without edits to be unchanged, got %s", saved)
+	}
+}
@@ -31,6 +31,7 @@ func ConvertOpenAPIToCollection(spec *OpenAPISpec) (*Collection, error) {
 		Info:  convertInfo(spec.Info),
 		Items: items,
 	}
+	EnsureIDs(collection)
 
 	return collection, nil
 }
@@ -135,6 +136,7 @@ func convertOperation(method, path string, op *OpenAPIOperation, params []OpenAP
 			Body:   body,
 			URL:    url,
 		},
+		OperationID: op.OperationID,
 	}
 }
 
@@ -302,10 +304,9 @@ func organizeByTags(items []Item) []Item {
 			taggedItems[i].Description = strings.TrimPrefix(taggedItems[i].Description, fmt.Sprintf("[tag:%s] ", tag))
 		}
 
-		organized = append(organized, Item{
-			Name:  tag,
-			Items: taggedItems,
-		})
+		folder := NewFolder(tag)
+		folder.Items = taggedItems
+		organized = append(organized, folder)
 	}
 
 	organized = append(organized, untagged...)
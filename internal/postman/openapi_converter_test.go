@@ -384,6 +384,10 @@ func TestConvertOpenAPIToCollection_Complete(t *testing.T) {
 		t.Errorf("Expected method GET, got %s", getUserItem.Request.Method)
 	}
 
+	if getUserItem.ID == "" || collection.Info.PostmanID == "" {
+		t.Error("Expected converted items and collection to get IDs")
+	}
+
 	if !strings.Contains(getUserItem.Request.URL.Raw, "{{id}}") {
 		t.Errorf("Expected URL to contain path parameter template {{id}}, got '%s'", getUserItem.Request.URL.Raw)
 	}
@@ -0,0 +1,438 @@
+package postman
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const exportedOpenAPIVersion = "3.0.3"
+
+type RecordedResponse struct {
+	StatusCode  int
+	ContentType string
+	Body        string
+}
+
+type ResponseLookup func(folder []string, item *Item) *RecordedResponse
+
+var (
+	serverVariablePattern = regexp.MustCompile(`\{\{([^}]+)\}\}`)
+	pathSegmentVarPattern = regexp.MustCompile(`^(?:\{\{([^}]+)\}\}|:([A-Za-z_][A-Za-z0-9_]*))$`)
+	bareTemplatePattern   = regexp.MustCompile(`([:\[,]\s*)\{\{([^}]+)\}\}`)
+)
+
+type openAPIExporter struct {
+	collection   *Collection
+	lookup       ResponseLookup
+	spec         *OpenAPISpec
+	operationIDs map[string]bool
+	operations   map[string]string
+	skipped      []string
+	baseCounts   map[string]int
+	baseOrder    []string
+}
+
+// ConvertCollectionToOpenAPI builds an OpenAPI spec from collection. An
+// OpenAPI path holds one operation per method, so requests that repeat a
+// method and path already exported, or use a method OpenAPI cannot express,
+// are left out and described in the returned skipped list.
+func ConvertCollectionToOpenAPI(collection *Collection, lookup ResponseLookup) (*OpenAPISpec, []string, error) {
+	if collection == nil {
+		return nil, nil, fmt.Errorf("collection cannot be nil")
+	}
+
+	exporter := &openAPIExporter{
+		collection: collection,
+		lookup:     lookup,
+		spec: &OpenAPISpec{
+			OpenAPI: exportedOpenAPIVersion,
+			Info: OpenAPIInfo{
+				Title:       collection.Info.Name,
+				Description: collection.Info.Description,
+				Version:     "1.0.0",
+			},
+			Paths: make(map[string]OpenAPIPathItem),
+		},
+		operationIDs: make(map[string]bool),
+		operations:   make(map[string]string),
+		baseCounts:   make(map[string]int),
+	}
+
+	exporter.walk(collection.Items, nil)
+	exporter.spec.Servers = exporter.buildServers()
+
+	return exporter.spec, exporter.skipped, nil
+}
+
+func (e *openAPIExporter) walk(items []Item, folder []string) {
+	for i := range items {
+		item := &items[i]
+		if item.IsRequest() {
+			e.addOperation(item, folder)
+		} else if item.IsFolder() {
+			e.walk(item.Items, append(append([]string{}, folder...), item.Name))
+		}
+	}
+}
+
+func (e *openAPIExporter) addOperation(item *Item, folder []string) {
+	req := item.Request
+	rawURL := req.URL.Raw
+	if rawURL == "" && len(req.URL.Host) > 0 {
+		rawURL = "https://" + strings.Join(req.URL.Host, ".") + "/" + strings.Join(req.URL.Path, "/")
+	}
+
+	base, path, query := splitRequestURL(rawURL)
+	templatedPath, params := templatePath(path)
+
+	method := strings.ToUpper(req.Method)
+	itemPath := strings.Join(append(append([]string{}, folder...), item.Name), " / ")
+	if !isOpenAPIMethod(method) {
+		e.skipped = append(e.skipped, fmt.Sprintf("%s: method %s is not supported", itemPath, method))
+		return
+	}
+	key := method + " " + templatedPath
+	if first, exists := e.operations[key]; exists {
+		e.skipped = append(e.skipped, fmt.Sprintf("%s: %s is already exported from %s", itemPath, key, first))
+		return
+	}
+	e.operations[key] = itemPath
+
+	if base != "" {
+		if e.baseCounts[base] == 0 {
+			e.baseOrder = append(e.baseOrder, base)
+		}
+		e.baseCounts[base]++
+	}
+
+	op := &OpenAPIOperation{
+		OperationID: e.uniqueOperationID(item.Name),
+		Summary:     item.Name,
+		Description: item.Description,
+		Parameters:  params,
+		Responses:   make(map[string]OpenAPIResponse),
+	}
+	if len(folder) > 0 {
+		op.Tags = []string{folder[0]}
+	}
+
+	op.Parameters = append(op.Parameters, queryParameters(query)...)
+
+	contentType := ""
+	for _, header := range req.Header {
+		switch strings.ToLower(header.Key) {
+		case "content-type":
+			contentType = header.Value
+		case "accept":
+		case "authorization":
+			e.addAuthorization(op, header.Value)
+		default:
+			op.Parameters = append(op.Parameters, OpenAPIParameter{
+				Name:    header.Key,
+				In:      "header",
+				Schema:  &OpenAPISchema{Type: "string"},
+				Example: exampleValue(header.Value),
+			})
+		}
+	}
+
+	if req.Body != nil && req.Body.Raw != "" {
+		op.RequestBody = &OpenAPIRequestBody{
+			Content: map[string]OpenAPIMediaType{},
+		}
+		mediaType, media := buildMediaType(contentType, req.Body.Raw)
+		op.RequestBody.Content[mediaType] = media
+	}
+
+	var recorded *RecordedResponse
+	if e.lookup != nil {
+		recorded = e.lookup(folder, item)
+	}
+	if recorded != nil && recorded.StatusCode > 0 {
+		response := OpenAPIResponse{
+			Description: http.StatusText(recorded.StatusCode),
+		}
+		if response.Description == "" {
+			response.Description = "Recorded response"
+		}
+		if recorded.Body != "" {
+			mediaType, media := buildMediaType(recorded.ContentType, recorded.Body)
+			response.Content = map[string]OpenAPIMediaType{mediaType: media}
+		}
+		op.Responses[fmt.Sprintf("%d", recorded.StatusCode)] = response
+	} else {
+		op.Responses["200"] = OpenAPIResponse{Description: "Successful response"}
+	}
+
+	pathItem := e.spec.Paths[templatedPath]
+	setPathOperation(&pathItem, method, op)
+	e.spec.Paths[templatedPath] = pathItem
+}
+
+func (e *openAPIExporter) addAuthorization(op *OpenAPIOperation, value string) {
+	schemeName := ""
+	scheme := OpenAPISecurityScheme{Type: "http"}
+
+	switch {
+	case strings.HasPrefix(strings.ToLower(value), "bearer "):
+		schemeName = "bearerAuth"
+		scheme.Scheme = "bearer"
+	case strings.HasPrefix(strings.ToLower(value), "basic "):
+		schemeName = "basicAuth"
+		scheme.Scheme = "basic"
+	default:
+		op.Parameters = append(op.Parameters, OpenAPIParameter{
+			Name:   "Authorization",
+			In:     "header",
+			Schema: &OpenAPISchema{Type: "string"},
+		})
+		return
+	}
+
+	if e.spec.Components == nil {
+		e.spec.Components = &OpenAPIComponents{SecuritySchemes: make(map[string]OpenAPISecurityScheme)}
+	}
+	e.spec.Components.SecuritySchemes[schemeName] = scheme
+	op.Security = append(op.Security, map[string][]string{schemeName: {}})
+}
+
+func (e *openAPIExporter) uniqueOperationID(name string) string {
+	base := toOperationID(name)
+	if base == "" {
+		base = "operation"
+	}
+
+	id := base
+	for n := 2; e.operationIDs[id]; n++ {
+		id = fmt.Sprintf("%s%d", base, n)
+	}
+	e.operationIDs[id] = true
+	return id
+}
+
+func (e *openAPIExporter) buildServers() []OpenAPIServer {
+	bases := append([]string{}, e.baseOrder...)
+	sort.SliceStable(bases, func(i, j int) bool {
+		return e.baseCounts[bases[i]] > e.baseCounts[bases[j]]
+	})
+
+	servers := make([]OpenAPIServer, 0, len(bases))
+	for _, base := range bases {
+		server := OpenAPIServer{
+			URL: serverVariablePattern.ReplaceAllString(base, "{$1}"),
+		}
+		for _, match := range serverVariablePattern.FindAllStringSubmatch(base, -1) {
+			if server.Variables == nil {
+				server.Variables = make(map[string]OpenAPIServerVariable)
+			}
+			name := strings.TrimSpace(match[1])
+			server.Variables[name] = OpenAPIServerVariable{Default: e.collectionVariable(name)}
+		}
+		servers = append(servers, server)
+	}
+	return servers
+}
+
+func (e *openAPIExporter) collectionVariable(key string) string {
+	for _, v := range e.collection.Variables {
+		if v.Key == key {
+			return v.Value
+		}
+	}
+	return ""
+}
+
+func splitRequestURL(rawURL string) (base, path, query string) {
+	rest := rawURL
+	if idx := strings.Index(rest, "#"); idx >= 0 {
+		rest = rest[:idx]
+	}
+	if idx := strings.Index(rest, "?"); idx >= 0 {
+		query = rest[idx+1:]
+		rest = rest[:idx]
+	}
+
+	if idx := strings.Index(rest, "://"); idx >= 0 {
+		slash := strings.Index(rest[idx+3:], "/")
+		if slash >= 0 {
+			base = rest[:idx+3+slash]
+			path = rest[idx+3+slash:]
+		} else {
+			base = rest
+		}
+	} else if strings.HasPrefix(rest, "{{") {
+		if end := strings.Index(rest, "}}"); end >= 0 {
+			base = rest[:end+2]
+			path = rest[end+2:]
+		}
+	} else {
+		path = rest
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return base, path, query
+}
+
+func templatePath(path string) (string, []OpenAPIParameter) {
+	var params []OpenAPIParameter
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		match := pathSegmentVarPattern.FindStringSubmatch(segment)
+		if match == nil {
+			continue
+		}
+		name := strings.TrimSpace(match[1])
+		if name == "" {
+			name = match[2]
+		}
+		segments[i] = "{" + name + "}"
+		params = append(params, OpenAPIParameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   &OpenAPISchema{Type: "string"},
+		})
+	}
+	return strings.Join(segments, "/"), params
+}
+
+func queryParameters(query string) []OpenAPIParameter {
+	if query == "" {
+		return nil
+	}
+
+	var params []OpenAPIParameter
+	for _, pair := range strings.Split(query, "&") {
+		if pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		param := OpenAPIParameter{
+			Name:   parts[0],
+			In:     "query",
+			Schema: &OpenAPISchema{Type: "string"},
+		}
+		if len(parts) == 2 {
+			param.Example = exampleValue(parts[1])
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+func exampleValue(value string) interface{} {
+	if value == "" || serverVariablePattern.MatchString(value) {
+		return nil
+	}
+	return value
+}
+
+func buildMediaType(contentType, body string) (string, OpenAPIMediaType) {
+	mediaType := strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+
+	var parsed interface{}
+	isJSON := json.Unmarshal([]byte(body), &parsed) == nil
+	if !isJSON {
+		quoted := bareTemplatePattern.ReplaceAllString(body, `$1"{{$2}}"`)
+		isJSON = json.Unmarshal([]byte(quoted), &parsed) == nil
+	}
+
+	if isJSON && (mediaType == "" || strings.Contains(mediaType, "json")) {
+		if mediaType == "" {
+			mediaType = "application/json"
+		}
+		return mediaType, OpenAPIMediaType{
+			Schema:  inferSchema(parsed),
+			Example: parsed,
+		}
+	}
+
+	if mediaType == "" {
+		mediaType = "text/plain"
+	}
+	return mediaType, OpenAPIMediaType{
+		Schema:  &OpenAPISchema{Type: "string"},
+		Example: body,
+	}
+}
+
+func inferSchema(value interface{}) *OpenAPISchema {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		schema := &OpenAPISchema{Type: "object", Properties: make(map[string]*OpenAPISchema)}
+		for key, child := range v {
+			schema.Properties[key] = inferSchema(child)
+		}
+		return schema
+	case []interface{}:
+		schema := &OpenAPISchema{Type: "array", Items: &OpenAPISchema{}}
+		if len(v) > 0 {
+			schema.Items = inferSchema(v[0])
+		}
+		return schema
+	case string:
+		return &OpenAPISchema{Type: "string"}
+	case float64:
+		if v == math.Trunc(v) {
+			return &OpenAPISchema{Type: "integer"}
+		}
+		return &OpenAPISchema{Type: "number"}
+	case bool:
+		return &OpenAPISchema{Type: "boolean"}
+	default:
+		return &OpenAPISchema{Nullable: true}
+	}
+}
+
+func isOpenAPIMethod(method string) bool {
+	switch method {
+	case "GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS", "HEAD":
+		return true
+	}
+	return false
+}
+
+func setPathOperation(pathItem *OpenAPIPathItem, method string, op *OpenAPIOperation) {
+	switch method {
+	case "GET":
+		pathItem.Get = op
+	case "POST":
+		pathItem.Post = op
+	case "PUT":
+		pathItem.Put = op
+	case "DELETE":
+		pathItem.Delete = op
+	case "PATCH":
+		pathItem.Patch = op
+	case "OPTIONS":
+		pathItem.Options = op
+	case "HEAD":
+		pathItem.Head = op
+	}
+}
+
+func toOperationID(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var sb strings.Builder
+	for i, word := range words {
+		runes := []rune(word)
+		if i == 0 {
+			runes[0] = unicode.ToLower(runes[0])
+		} else {
+			runes[0] = unicode.ToUpper(runes[0])
+		}
+		sb.WriteString(string(runes))
+	}
+	return sb.String()
+}
@@ -0,0 +1,217 @@
+package postman
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func exporterTestCollection() *Collection {
+	return &Collection{
+		Info: Info{Name: "Pets API"},
+		Items: []Item{
+			{
+				Name: "pets",
+				Items: []Item{
+					{
+						Name: "List Pets",
+						Request: &Request{
+							Method: "GET",
+							URL:    URL{Raw: "{{baseUrl}}/pets?limit=10"},
+							Header: []Header{{Key: "Authorization", Value: "Bearer {{token}}"}},
+						},
+					},
+					{
+						Name: "Create Pet",
+						Request: &Request{
+							Method: "POST",
+							URL:    URL{Raw: "{{baseUrl}}/pets"},
+							Header: []Header{{Key: "Content-Type", Value: "application/json"}},
+							Body:   &Body{Mode: "raw", Raw: `{"name": "Rex", "age": 3, "tags": ["dog"], "owner": {{ownerId}}}`},
+						},
+					},
+					{
+						Name: "Get Pet",
+						Request: &Request{
+							Method: "GET",
+							URL:    URL{Raw: "{{baseUrl}}/pets/:petId"},
+						},
+					},
+				},
+			},
+		},
+		Variables: []Variable{{Key: "baseUrl", Value: "https://api.example.com"}},
+	}
+}
+
+func TestConvertCollectionToOpenAPI(t *testing.T) {
+	spec, skipped, err := ConvertCollectionToOpenAPI(exporterTestCollection(), nil)
+	if err != nil || len(skipped) != 0 {
+		t.Fatalf("Unexpected error: %v (skipped %v)", err, skipped)
+	}
+
+	if spec.OpenAPI != exportedOpenAPIVersion {
+		t.Errorf("Expected openapi %s, got %s", exportedOpenAPIVersion, spec.OpenAPI)
+	}
+	if len(spec.Servers) != 1 || spec.Servers[0].URL != "{baseUrl}" {
+		t.Fatalf("Expected single templated server, got %+v", spec.Servers)
+	}
+	if spec.Servers[0].Variables["baseUrl"].Default != "https://api.example.com" {
+		t.Errorf("Expected server variable default from collection, got %+v", spec.Servers[0].Variables)
+	}
+
+	pets, ok := spec.Paths["/pets"]
+	if !ok || pets.Get == nil || pets.Post == nil {
+		t.Fatalf("Expected GET and POST on /pets, got %+v", spec.Paths)
+	}
+	if pets.Get.OperationID != "listPets" || pets.Get.Tags[0] != "pets" {
+		t.Errorf("Unexpected operation: %+v", pets.Get)
+	}
+	if len(pets.Get.Parameters) != 1 || pets.Get.Parameters[0].In != "query" {
+		t.Errorf("Expected limit query parameter, got %+v", pets.Get.Parameters)
+	}
+	if len(pets.Get.Security) != 1 || spec.Components.SecuritySchemes["bearerAuth"].Scheme != "bearer" {
+		t.Errorf("Expected bearer security, got %+v", pets.Get.Security)
+	}
+
+	media, ok := pets.Post.RequestBody.Content["application/json"]
+	if !ok {
+		t.Fatalf("Expected JSON request body, got %+v", pets.Post.RequestBody)
+	}
+	if media.Schema.Type != "object" || media.Schema.Properties["age"].Type != "integer" ||
+		media.Schema.Properties["tags"].Items.Type != "string" {
+		t.Errorf("Unexpected inferred schema: %+v", media.Schema)
+	}
+
+	petByID, ok := spec.Paths["/pets/{petId}"]
+	if !ok || petByID.Get == nil {
+		t.Fatalf("Expected /pets/{petId}, got %+v", spec.Paths)
+	}
+	if len(petByID.Get.Parameters) != 1 || !petByID.Get.Parameters[0].Required {
+		t.Errorf("Expected required path parameter, got %+v", petByID.Get.Parameters)
+	}
+}
+
+func TestConvertCollectionToOpenAPI_RecordedResponses(t *testing.T) {
+	lookup := func(folder []string, item *Item) *RecordedResponse {
+		if item.Name == "Get Pet" {
+			return &RecordedResponse{StatusCode: 404, ContentType: "application/json", Body: `{"error": "not found"}`}
+		}
+		return nil
+	}
+
+	spec, _, err := ConvertCollectionToOpenAPI(exporterTestCollection(), lookup)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	responses := spec.Paths["/pets/{petId}"].Get.Responses
+	notFound, ok := responses["404"]
+	if !ok {
+		t.Fatalf("Expected recorded 404 response, got %+v", responses)
+	}
+	if notFound.Description != "Not Found" || notFound.Content["application/json"].Schema.Type != "object" {
+		t.Errorf("Unexpected response: %+v", notFound)
+	}
+	if _, ok := spec.Paths["/pets"].Get.Responses["200"]; !ok {
+		t.Error("Expected default 200 response when nothing was recorded")
+	}
+}
+
+func TestConvertCollectionToOpenAPI_RoundTrip(t *testing.T) {
+	spec, _, _ := ConvertCollectionToOpenAPI(exporterTestCollection(), nil)
+
+	collection, err := ConvertOpenAPIToCollection(spec)
+	if err != nil {
+		t.Fatalf("Failed to convert exported spec back: %v", err)
+	}
+	if countRequests(collection.Items) != 3 {
+		t.Errorf("Expected 3 requests after round trip, got %d", countRequests(collection.Items))
+	}
+}
+
+func TestConvertCollectionToOpenAPI_ReportsDuplicateOperations(t *testing.T) {
+	collection := exporterTestCollection()
+	collection.Items = append(collection.Items,
+		Item{
+			Name: "admin",
+			Items: []Item{
+				{Name: "List All Pets", Request: &Request{Method: "GET", URL: URL{Raw: "{{baseUrl}}/pets?all=true"}}},
+			},
+		},
+		Item{Name: "Trace Pets", Request: &Request{Method: "TRACE", URL: URL{Raw: "{{baseUrl}}/pets"}}},
+	)
+
+	spec, skipped, err := ConvertCollectionToOpenAPI(collection, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if spec.Paths["/pets"].Get.Summary != "List Pets" {
+		t.Errorf("Expected the first request to keep GET /pets, got %q", spec.Paths["/pets"].Get.Summary)
+	}
+	expected := []string{
+		"admin / List All Pets: GET /pets is already exported from pets / List Pets",
+		"Trace Pets: method TRACE is not supported",
+	}
+	if len(skipped) != len(expected) {
+		t.Fatalf("Expected %d skipped requests, got %v", len(expected), skipped)
+	}
+	for i := range expected {
+		if skipped[i] != expected[i] {
+			t.Errorf("Expected %q, got %q", expected[i], skipped[i])
+		}
+	}
+	if _, exists := spec.Paths["/pets"]; !exists || len(spec.Paths) != 2 {
+		t.Errorf("Expected skipped requests not to add paths, got %d", len(spec.Paths))
+	}
+}
+
+func TestConvertCollectionToOpenAPI_NilCollection(t *testing.T) {
+	if _, _, err := ConvertCollectionToOpenAPI(nil, nil); err == nil {
+		t.Error("Expected error for nil collection")
+	}
+}
+
+func TestParser_ExportOpenAPI(t *testing.T) {
+	dir := t.TempDir()
+	collectionPath := filepath.Join(dir, "collection.json")
+	data, _ := json.Marshal(exporterTestCollection())
+	if err := os.WriteFile(collectionPath, data, 0644); err != nil {
+		t.Fatalf("Failed to write collection: %v", err)
+	}
+
+	parser := NewParser()
+	if _, err := parser.LoadCollection(collectionPath); err != nil {
+		t.Fatalf("Failed to load collection: %v", err)
+	}
+
+	specPath := filepath.Join(dir, "openapi.json")
+	if _, err := parser.ExportOpenAPI("Pets API", specPath, nil); err != nil {
+		t.Fatalf("Failed to export: %v", err)
+	}
+
+	spec, err := parser.LoadOpenAPISpec(specPath)
+	if err != nil {
+		t.Fatalf("Exported spec could not be loaded: %v", err)
+	}
+	if len(spec.Paths) != 2 {
+		t.Errorf("Expected 2 paths, got %d", len(spec.Paths))
+	}
+
+	if _, err := parser.ExportOpenAPI("Missing", specPath, nil); err == nil {
+		t.Error("Expected error for unknown collection")
+	}
+}
+
+func countRequests(items []Item) int {
+	count := 0
+	for _, item := range items {
+		if item.IsRequest() {
+			count++
+		}
+		count += countRequests(item.Items)
+	}
+	return count
+}
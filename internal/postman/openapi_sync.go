@@ -0,0 +1,349 @@
+package postman
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const removedPrefix = "[removed] "
+
+type SyncChangeType int
+
+const (
+	SyncAdded SyncChangeType = iota
+	SyncUpdated
+	SyncRemoved
+)
+
+type SyncChange struct {
+	Type    SyncChangeType
+	Name    string
+	Method  string
+	Folder  []string
+	Details []string
+
+	indexPath []int
+	item      Item
+}
+
+type SyncPlan struct {
+	CollectionName string
+	Changes        []SyncChange
+}
+
+type syncEntry struct {
+	key       string
+	name      string
+	folder    []string
+	indexPath []int
+	item      *Item
+}
+
+var templateVarPattern = regexp.MustCompile(`\{\{[^}]+\}\}`)
+
+// specHeaderValuePattern matches the placeholder values the OpenAPI converter
+// gives header parameters and security headers. A header the spec no longer
+// declares is only dropped while it still has such a value; anything else was
+// set by the user and is kept.
+var specHeaderValuePattern = regexp.MustCompile(`^((Bearer|Basic) )?\{\{[^}]+\}\}$`)
+
+func PlanOpenAPISync(existing *Collection, spec *OpenAPISpec) (*SyncPlan, error) {
+	if existing == nil {
+		return nil, fmt.Errorf("collection cannot be nil")
+	}
+
+	fresh, err := ConvertOpenAPIToCollection(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	existingEntries := collectSyncEntries(existing.Items, nil, nil)
+	freshEntries := collectSyncEntries(fresh.Items, nil, nil)
+
+	matched := make(map[int]int)
+	used := make(map[int]bool)
+
+	for fi, fe := range freshEntries {
+		for ei, ee := range existingEntries {
+			if !used[ei] && ee.key == fe.key {
+				matched[fi] = ei
+				used[ei] = true
+				break
+			}
+		}
+	}
+
+	// Items imported from a spec remember their operationId, so renamed
+	// items and moved paths still match. Collections imported before the id
+	// was stored fall back to the generated name.
+	sameOperation := []func(ee, fe syncEntry) bool{
+		func(ee, fe syncEntry) bool {
+			return fe.item.OperationID != "" && ee.item.OperationID == fe.item.OperationID
+		},
+		func(ee, fe syncEntry) bool {
+			return ee.item.OperationID == "" && strings.TrimPrefix(ee.name, removedPrefix) == fe.name
+		},
+	}
+	for _, same := range sameOperation {
+		for fi, fe := range freshEntries {
+			if _, ok := matched[fi]; ok {
+				continue
+			}
+			for ei, ee := range existingEntries {
+				if !used[ei] && same(ee, fe) {
+					matched[fi] = ei
+					used[ei] = true
+					break
+				}
+			}
+		}
+	}
+
+	plan := &SyncPlan{CollectionName: existing.Info.Name}
+
+	for fi, fe := range freshEntries {
+		ei, ok := matched[fi]
+		if !ok {
+			plan.Changes = append(plan.Changes, SyncChange{
+				Type:   SyncAdded,
+				Name:   fe.name,
+				Method: fe.item.Request.Method,
+				Folder: fe.folder,
+				item:   *fe.item,
+			})
+			continue
+		}
+
+		ee := existingEntries[ei]
+		updated, details := mergeSyncedItem(*ee.item, *fe.item)
+		if len(details) == 0 {
+			continue
+		}
+		plan.Changes = append(plan.Changes, SyncChange{
+			Type:      SyncUpdated,
+			Name:      ee.name,
+			Method:    updated.Request.Method,
+			Folder:    ee.folder,
+			Details:   details,
+			indexPath: ee.indexPath,
+			item:      updated,
+		})
+	}
+
+	for ei, ee := range existingEntries {
+		if used[ei] || strings.HasPrefix(ee.name, removedPrefix) {
+			continue
+		}
+		plan.Changes = append(plan.Changes, SyncChange{
+			Type:      SyncRemoved,
+			Name:      ee.name,
+			Method:    ee.item.Request.Method,
+			Folder:    ee.folder,
+			Details:   []string{"Operation no longer present in spec"},
+			indexPath: ee.indexPath,
+		})
+	}
+
+	return plan, nil
+}
+
+func ApplyOpenAPISync(collection *Collection, plan *SyncPlan) error {
+	if collection == nil || plan == nil {
+		return fmt.Errorf("collection and plan are required")
+	}
+
+	for _, change := range plan.Changes {
+		if change.Type == SyncAdded {
+			continue
+		}
+		item := itemAtIndexPath(collection.Items, change.indexPath)
+		if item == nil || !item.IsRequest() {
+			return fmt.Errorf("item not found: %s", change.Name)
+		}
+
+		switch change.Type {
+		case SyncUpdated:
+			*item = change.item
+		case SyncRemoved:
+			item.Name = removedPrefix + item.Name
+		}
+	}
+
+	for _, change := range plan.Changes {
+		if change.Type != SyncAdded {
+			continue
+		}
+		items := ensureFolderPath(&collection.Items, change.Folder)
+		*items = append(*items, change.item)
+	}
+
+	return nil
+}
+
+func (c SyncChange) Label() string {
+	prefix := "~ "
+	switch c.Type {
+	case SyncAdded:
+		prefix = "+ "
+	case SyncRemoved:
+		prefix = "- "
+	}
+
+	name := c.Name
+	if len(c.Folder) > 0 {
+		name = strings.Join(c.Folder, " / ") + " / " + name
+	}
+	return fmt.Sprintf("%s[%s] %s", prefix, c.Method, name)
+}
+
+func collectSyncEntries(items []Item, folder []string, indexPath []int) []syncEntry {
+	var entries []syncEntry
+	for i := range items {
+		path := append(append([]int{}, indexPath...), i)
+		item := &items[i]
+		if item.IsRequest() {
+			entries = append(entries, syncEntry{
+				key:       operationKey(item.Request.Method, item.Request.URL.Raw),
+				name:      item.Name,
+				folder:    append([]string{}, folder...),
+				indexPath: path,
+				item:      item,
+			})
+		} else if item.IsFolder() {
+			subFolder := append(append([]string{}, folder...), item.Name)
+			entries = append(entries, collectSyncEntries(item.Items, subFolder, path)...)
+		}
+	}
+	return entries
+}
+
+func operationKey(method, rawURL string) string {
+	path := rawURL
+	if idx := strings.IndexAny(path, "?#"); idx >= 0 {
+		path = path[:idx]
+	}
+
+	if idx := strings.Index(path, "://"); idx >= 0 {
+		path = path[idx+3:]
+		if slash := strings.Index(path, "/"); slash >= 0 {
+			path = path[slash:]
+		} else {
+			path = "/"
+		}
+	} else if strings.HasPrefix(path, "{{") {
+		if end := strings.Index(path, "}}"); end >= 0 {
+			path = path[end+2:]
+		}
+	}
+
+	path = templateVarPattern.ReplaceAllString(path, "{}")
+	path = strings.TrimSuffix(path, "/")
+	return strings.ToUpper(method) + " " + path
+}
+
+func mergeSyncedItem(existing Item, fresh Item) (Item, []string) {
+	var details []string
+	merged := existing
+	req := *existing.Request
+
+	if strings.HasPrefix(merged.Name, removedPrefix) {
+		merged.Name = strings.TrimPrefix(merged.Name, removedPrefix)
+		details = append(details, "Operation restored in spec")
+	}
+
+	if !strings.EqualFold(req.Method, fresh.Request.Method) {
+		details = append(details, fmt.Sprintf("Method: %s → %s", req.Method, fresh.Request.Method))
+		req.Method = fresh.Request.Method
+	}
+
+	if req.URL.Raw != fresh.Request.URL.Raw {
+		details = append(details, fmt.Sprintf("URL: %s → %s", req.URL.Raw, fresh.Request.URL.Raw))
+		req.URL = fresh.Request.URL
+	}
+
+	existingHeaders := make(map[string]Header)
+	for _, h := range req.Header {
+		existingHeaders[strings.ToLower(h.Key)] = h
+	}
+
+	var headers []Header
+	freshKeys := make(map[string]bool)
+	for _, h := range fresh.Request.Header {
+		key := strings.ToLower(h.Key)
+		freshKeys[key] = true
+		if old, ok := existingHeaders[key]; ok {
+			headers = append(headers, old)
+		} else {
+			headers = append(headers, h)
+			details = append(details, "Header added: "+h.Key)
+		}
+	}
+	for _, h := range req.Header {
+		if freshKeys[strings.ToLower(h.Key)] {
+			continue
+		}
+		if specHeaderValuePattern.MatchString(h.Value) {
+			details = append(details, "Header removed: "+h.Key)
+			continue
+		}
+		headers = append(headers, h)
+	}
+	req.Header = headers
+
+	if fresh.OperationID != "" && fresh.OperationID != existing.OperationID {
+		if existing.OperationID == "" {
+			details = append(details, "Operation ID recorded: "+fresh.OperationID)
+		} else {
+			details = append(details, fmt.Sprintf("Operation ID: %s → %s", existing.OperationID, fresh.OperationID))
+		}
+		merged.OperationID = fresh.OperationID
+	}
+
+	if fresh.Description != "" && fresh.Description != existing.Description {
+		details = append(details, "Description updated")
+		merged.Description = fresh.Description
+	}
+
+	merged.Request = &req
+	return merged, details
+}
+
+func itemAtIndexPath(items []Item, indexPath []int) *Item {
+	if len(indexPath) == 0 {
+		return nil
+	}
+
+	current := items
+	for depth, idx := range indexPath {
+		if idx < 0 || idx >= len(current) {
+			return nil
+		}
+		if depth == len(indexPath)-1 {
+			return &current[idx]
+		}
+		current = current[idx].Items
+	}
+	return nil
+}
+
+func ensureFolderPath(items *[]Item, folder []string) *[]Item {
+	current := items
+	for _, name := range folder {
+		found := false
+		for i := range *current {
+			if (*current)[i].Name == name && (*current)[i].Request == nil {
+				current = &(*current)[i].Items
+				found = true
+				break
+			}
+		}
+		if !found {
+			folder := NewFolder(name)
+			folder.ID = NewID()
+			*current = append(*current, folder)
+			current = &(*current)[len(*current)-1].Items
+		}
+	}
+	return current
+}
@@ -0,0 +1,345 @@
+package postman
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func syncTestSpec() *OpenAPISpec {
+	return &OpenAPISpec{
+		OpenAPI: "3.0.0",
+		Info:    OpenAPIInfo{Title: "Pets"},
+		Servers: []OpenAPIServer{{URL: "https://api.example.com"}},
+		Paths: map[string]OpenAPIPathItem{
+			"/pets": {
+				Get: &OpenAPIOperation{
+					OperationID: "listPets",
+					Parameters:  []OpenAPIParameter{{Name: "limit", In: "query"}},
+				},
+				Post: &OpenAPIOperation{OperationID: "createPet"},
+			},
+			"/pets/{petId}": {
+				Get: &OpenAPIOperation{OperationID: "getPet"},
+			},
+		},
+	}
+}
+
+func TestOperationKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		url      string
+		expected string
+	}{
+		{"Absolute URL", "get", "https://api.example.com/pets", "GET /pets"},
+		{"Query stripped", "GET", "https://api.example.com/pets?limit={{limit}}", "GET /pets"},
+		{"Variable base URL", "GET", "{{baseUrl}}/pets/{{petId}}", "GET /pets/{}"},
+		{"Path params normalized", "DELETE", "https://api.example.com/pets/{{id}}/", "DELETE /pets/{}"},
+		{"Host only", "GET", "https://api.example.com", "GET "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := operationKey(tt.method, tt.url); got != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestPlanOpenAPISync_NoChanges(t *testing.T) {
+	spec := syncTestSpec()
+	collection, _ := ConvertOpenAPIToCollection(spec)
+
+	plan, err := PlanOpenAPISync(collection, spec)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(plan.Changes) != 0 {
+		t.Errorf("Expected no changes, got %d: %+v", len(plan.Changes), plan.Changes)
+	}
+}
+
+func TestPlanOpenAPISync_AddedUpdatedRemoved(t *testing.T) {
+	spec := syncTestSpec()
+	collection, _ := ConvertOpenAPIToCollection(spec)
+
+	newSpec := syncTestSpec()
+	delete(newSpec.Paths, "/pets/{petId}")
+	newSpec.Paths["/owners"] = OpenAPIPathItem{Get: &OpenAPIOperation{OperationID: "listOwners"}}
+	pets := newSpec.Paths["/pets"]
+	pets.Get.Parameters = append(pets.Get.Parameters, OpenAPIParameter{Name: "offset", In: "query"})
+	newSpec.Paths["/pets"] = pets
+
+	plan, err := PlanOpenAPISync(collection, newSpec)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	counts := map[SyncChangeType]int{}
+	for _, change := range plan.Changes {
+		counts[change.Type]++
+	}
+	if counts[SyncAdded] != 1 || counts[SyncUpdated] != 1 || counts[SyncRemoved] != 1 {
+		t.Fatalf("Expected 1 added, 1 updated, 1 removed, got %+v", counts)
+	}
+}
+
+func TestApplyOpenAPISync_PreservesUserContent(t *testing.T) {
+	spec := syncTestSpec()
+	collection, _ := ConvertOpenAPIToCollection(spec)
+
+	for i := range collection.Items {
+		if collection.Items[i].Name == "listPets" {
+			collection.Items[i].Request.Body = &Body{Mode: "raw", Raw: "user body"}
+			collection.Items[i].Events = []Event{{Listen: "test", Script: Script{Exec: []string{"pm.test('ok', function() {})"}}}}
+			collection.Items[i].Request.Header = append(collection.Items[i].Request.Header, Header{Key: "X-Custom", Value: "1"})
+		}
+	}
+
+	newSpec := syncTestSpec()
+	pets := newSpec.Paths["/pets"]
+	pets.Get.Parameters = append(pets.Get.Parameters, OpenAPIParameter{Name: "X-Trace", In: "header"})
+	newSpec.Paths["/pets"] = pets
+	delete(newSpec.Paths, "/pets/{petId}")
+
+	plan, err := PlanOpenAPISync(collection, newSpec)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := ApplyOpenAPISync(collection, plan); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var listPets, getPet *Item
+	for i := range collection.Items {
+		switch strings.TrimPrefix(collection.Items[i].Name, removedPrefix) {
+		case "listPets":
+			listPets = &collection.Items[i]
+		case "getPet":
+			getPet = &collection.Items[i]
+		}
+	}
+
+	if listPets == nil || getPet == nil {
+		t.Fatalf("Expected listPets and getPet to remain in collection")
+	}
+	if listPets.Request.Body == nil || listPets.Request.Body.Raw != "user body" {
+		t.Error("Expected user body to be preserved")
+	}
+	if len(listPets.Events) != 1 {
+		t.Error("Expected user script to be preserved")
+	}
+
+	headerKeys := []string{}
+	for _, h := range listPets.Request.Header {
+		headerKeys = append(headerKeys, h.Key)
+	}
+	joined := strings.Join(headerKeys, ",")
+	if !strings.Contains(joined, "X-Trace") || !strings.Contains(joined, "X-Custom") {
+		t.Errorf("Expected spec and user headers, got %s", joined)
+	}
+
+	if !strings.HasPrefix(getPet.Name, removedPrefix) {
+		t.Errorf("Expected removed operation to be marked, got '%s'", getPet.Name)
+	}
+
+	plan, _ = PlanOpenAPISync(collection, newSpec)
+	if len(plan.Changes) != 0 {
+		t.Errorf("Expected re-sync to be idempotent, got %d changes", len(plan.Changes))
+	}
+}
+
+func TestApplyOpenAPISync_MatchesByOperationID(t *testing.T) {
+	spec := syncTestSpec()
+	collection, _ := ConvertOpenAPIToCollection(spec)
+
+	newSpec := syncTestSpec()
+	newSpec.Paths["/v2/pets/{petId}"] = newSpec.Paths["/pets/{petId}"]
+	delete(newSpec.Paths, "/pets/{petId}")
+
+	plan, err := PlanOpenAPISync(collection, newSpec)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(plan.Changes) != 1 || plan.Changes[0].Type != SyncUpdated {
+		t.Fatalf("Expected a single update, got %+v", plan.Changes)
+	}
+	if !strings.Contains(plan.Changes[0].Details[0], "/v2/pets/") {
+		t.Errorf("Expected URL change detail, got %v", plan.Changes[0].Details)
+	}
+}
+
+func TestPlanOpenAPISync_MatchesRenamedItemsByStoredOperationID(t *testing.T) {
+	spec := syncTestSpec()
+	pets := spec.Paths["/pets"]
+	pets.Post.Summary = "Create a pet"
+	spec.Paths["/pets"] = pets
+
+	data, err := json.Marshal(mustConvertOpenAPI(t, spec))
+	if err != nil {
+		t.Fatalf("Failed to marshal collection: %v", err)
+	}
+	var collection Collection
+	if err := json.Unmarshal(data, &collection); err != nil {
+		t.Fatalf("Failed to reload collection: %v", err)
+	}
+
+	renamed := findItemByName(collection.Items, "getPet")
+	renamed.Name = "Fetch one pet"
+	if renamed.OperationID != "getPet" {
+		t.Fatalf("Expected operationId to survive a save, got %q", renamed.OperationID)
+	}
+
+	newSpec := syncTestSpec()
+	newSpec.Paths["/v2/pets/{petId}"] = newSpec.Paths["/pets/{petId}"]
+	delete(newSpec.Paths, "/pets/{petId}")
+	newPets := newSpec.Paths["/pets"]
+	newPets.Post.Summary = "Add a pet"
+	newSpec.Paths["/pets"] = newPets
+
+	plan, err := PlanOpenAPISync(&collection, newSpec)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(plan.Changes) != 1 {
+		t.Fatalf("Expected a single update, got %+v", plan.Changes)
+	}
+	change := plan.Changes[0]
+	if change.Type != SyncUpdated || change.Name != "Fetch one pet" {
+		t.Errorf("Expected the renamed item to be updated, got %+v", change)
+	}
+}
+
+func TestPlanOpenAPISync_RecordsOperationIDOnLegacyItems(t *testing.T) {
+	spec := syncTestSpec()
+	collection := mustConvertOpenAPI(t, spec)
+	for _, name := range []string{"listPets", "createPet", "getPet"} {
+		findItemByName(collection.Items, name).OperationID = ""
+	}
+
+	plan, err := PlanOpenAPISync(collection, spec)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(plan.Changes) != 3 {
+		t.Fatalf("Expected every item to be updated, got %+v", plan.Changes)
+	}
+	for _, change := range plan.Changes {
+		if change.Type != SyncUpdated || change.Details[0] != "Operation ID recorded: "+change.Name {
+			t.Errorf("Unexpected change: %+v", change)
+		}
+	}
+
+	if err := ApplyOpenAPISync(collection, plan); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if plan, _ := PlanOpenAPISync(collection, spec); len(plan.Changes) != 0 {
+		t.Errorf("Expected no changes after recording operation IDs, got %+v", plan.Changes)
+	}
+}
+
+func mustConvertOpenAPI(t *testing.T, spec *OpenAPISpec) *Collection {
+	t.Helper()
+	collection, err := ConvertOpenAPIToCollection(spec)
+	if err != nil {
+		t.Fatalf("Failed to convert spec: %v", err)
+	}
+	return collection
+}
+
+func TestApplyOpenAPISync_AddsIntoTagFolder(t *testing.T) {
+	collection := &Collection{
+		Info:  Info{Name: "Pets"},
+		Items: []Item{},
+	}
+	spec := syncTestSpec()
+	pets := spec.Paths["/pets"]
+	pets.Get.Tags = []string{"pets"}
+	spec.Paths["/pets"] = pets
+
+	plan, _ := PlanOpenAPISync(collection, spec)
+	if err := ApplyOpenAPISync(collection, plan); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	found := false
+	for _, item := range collection.Items {
+		if item.Name == "pets" && item.IsFolder() && item.Items[0].Name == "listPets" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected listPets to be added into 'pets' folder")
+	}
+}
+
+func TestPlanOpenAPISync_NilCollection(t *testing.T) {
+	if _, err := PlanOpenAPISync(nil, syncTestSpec()); err == nil {
+		t.Error("Expected error for nil collection")
+	}
+}
+
+func TestApplyOpenAPISync_UpdatesMethodMatchedByOperationID(t *testing.T) {
+	spec := syncTestSpec()
+	collection, _ := ConvertOpenAPIToCollection(spec)
+
+	newSpec := syncTestSpec()
+	pathItem := newSpec.Paths["/pets/{petId}"]
+	pathItem.Patch, pathItem.Get = pathItem.Get, nil
+	newSpec.Paths["/pets/{petId}"] = pathItem
+
+	plan, err := PlanOpenAPISync(collection, newSpec)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(plan.Changes) != 1 || plan.Changes[0].Type != SyncUpdated {
+		t.Fatalf("Expected a single update, got %+v", plan.Changes)
+	}
+	if plan.Changes[0].Details[0] != "Method: GET → PATCH" {
+		t.Errorf("Expected method change detail, got %v", plan.Changes[0].Details)
+	}
+
+	if err := ApplyOpenAPISync(collection, plan); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if item := findItemByName(collection.Items, "getPet"); item == nil || item.Request.Method != "PATCH" {
+		t.Errorf("Expected getPet to be sent as PATCH, got %+v", item)
+	}
+}
+
+func TestApplyOpenAPISync_DropsHeadersRemovedFromSpec(t *testing.T) {
+	spec := syncTestSpec()
+	get := *spec.Paths["/pets"].Get
+	get.Parameters = append(get.Parameters, OpenAPIParameter{Name: "X-Tenant", In: "header"})
+	pathItem := spec.Paths["/pets"]
+	pathItem.Get = &get
+	spec.Paths["/pets"] = pathItem
+	collection, _ := ConvertOpenAPIToCollection(spec)
+
+	listPets := findItemByName(collection.Items, "listPets")
+	listPets.Request.Header = append(listPets.Request.Header, Header{Key: "X-Debug", Value: "1"})
+
+	plan, err := PlanOpenAPISync(collection, syncTestSpec())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(plan.Changes) != 1 || !strings.Contains(strings.Join(plan.Changes[0].Details, ";"), "Header removed: X-Tenant") {
+		t.Fatalf("Expected header removal detail, got %+v", plan.Changes)
+	}
+
+	if err := ApplyOpenAPISync(collection, plan); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	headers := findItemByName(collection.Items, "listPets").Request.Header
+	if len(headers) != 1 || headers[0].Key != "X-Debug" {
+		t.Errorf("Expected only the user-added header to remain, got %+v", headers)
+	}
+
+	plan, _ = PlanOpenAPISync(collection, syncTestSpec())
+	if len(plan.Changes) != 0 {
+		t.Errorf("Expected re-sync to be idempotent, got %+v", plan.Changes)
+	}
+}
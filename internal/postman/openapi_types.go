@@ -1,80 +1,96 @@
 package postman
 
 type OpenAPISpec struct {
-	OpenAPI    string                        `json:"openapi"`
-	Swagger    string                        `json:"swagger"`
-	Info       OpenAPIInfo                   `json:"info"`
-	Servers    []OpenAPIServer               `json:"servers"`
-	Paths      map[string]OpenAPIPathItem    `json:"paths"`
-	Components *OpenAPIComponents            `json:"components"`
-	Security   []map[string][]string         `json:"security"`
+	OpenAPI    string                     `json:"openapi,omitempty"`
+	Swagger    string                     `json:"swagger,omitempty"`
+	Info       OpenAPIInfo                `json:"info"`
+	Servers    []OpenAPIServer            `json:"servers,omitempty"`
+	Paths      map[string]OpenAPIPathItem `json:"paths"`
+	Components *OpenAPIComponents         `json:"components,omitempty"`
+	Security   []map[string][]string      `json:"security,omitempty"`
 }
 
 type OpenAPIInfo struct {
 	Title       string `json:"title"`
-	Description string `json:"description"`
+	Description string `json:"description,omitempty"`
 	Version     string `json:"version"`
 }
 
 type OpenAPIServer struct {
-	URL         string `json:"url"`
-	Description string `json:"description"`
+	URL         string                           `json:"url"`
+	Description string                           `json:"description,omitempty"`
+	Variables   map[string]OpenAPIServerVariable `json:"variables,omitempty"`
+}
+
+type OpenAPIServerVariable struct {
+	Default     string `json:"default"`
+	Description string `json:"description,omitempty"`
 }
 
 type OpenAPIPathItem struct {
-	Get        *OpenAPIOperation   `json:"get"`
-	Post       *OpenAPIOperation   `json:"post"`
-	Put        *OpenAPIOperation   `json:"put"`
-	Delete     *OpenAPIOperation   `json:"delete"`
-	Patch      *OpenAPIOperation   `json:"patch"`
-	Options    *OpenAPIOperation   `json:"options"`
-	Head       *OpenAPIOperation   `json:"head"`
-	Parameters []OpenAPIParameter  `json:"parameters"`
+	Get        *OpenAPIOperation  `json:"get,omitempty"`
+	Post       *OpenAPIOperation  `json:"post,omitempty"`
+	Put        *OpenAPIOperation  `json:"put,omitempty"`
+	Delete     *OpenAPIOperation  `json:"delete,omitempty"`
+	Patch      *OpenAPIOperation  `json:"patch,omitempty"`
+	Options    *OpenAPIOperation  `json:"options,omitempty"`
+	Head       *OpenAPIOperation  `json:"head,omitempty"`
+	Parameters []OpenAPIParameter `json:"parameters,omitempty"`
 }
 
 type OpenAPIOperation struct {
-	OperationID string                `json:"operationId"`
-	Summary     string                `json:"summary"`
-	Description string                `json:"description"`
-	Tags        []string              `json:"tags"`
-	Parameters  []OpenAPIParameter    `json:"parameters"`
-	RequestBody *OpenAPIRequestBody   `json:"requestBody"`
-	Security    []map[string][]string `json:"security"`
+	OperationID string                     `json:"operationId,omitempty"`
+	Summary     string                     `json:"summary,omitempty"`
+	Description string                     `json:"description,omitempty"`
+	Tags        []string                   `json:"tags,omitempty"`
+	Parameters  []OpenAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]OpenAPIResponse `json:"responses,omitempty"`
+	Security    []map[string][]string      `json:"security,omitempty"`
 }
 
 type OpenAPIParameter struct {
-	Name        string          `json:"name"`
-	In          string          `json:"in"`
-	Description string          `json:"description"`
-	Required    bool            `json:"required"`
-	Schema      *OpenAPISchema  `json:"schema"`
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required,omitempty"`
+	Schema      *OpenAPISchema `json:"schema,omitempty"`
+	Example     interface{}    `json:"example,omitempty"`
 }
 
 type OpenAPIRequestBody struct {
-	Description string                       `json:"description"`
-	Required    bool                         `json:"required"`
-	Content     map[string]OpenAPIMediaType  `json:"content"`
+	Description string                      `json:"description,omitempty"`
+	Required    bool                        `json:"required,omitempty"`
+	Content     map[string]OpenAPIMediaType `json:"content"`
+}
+
+type OpenAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]OpenAPIMediaType `json:"content,omitempty"`
 }
 
 type OpenAPIMediaType struct {
-	Schema  *OpenAPISchema `json:"schema"`
-	Example interface{}    `json:"example"`
+	Schema  *OpenAPISchema `json:"schema,omitempty"`
+	Example interface{}    `json:"example,omitempty"`
 }
 
 type OpenAPISchema struct {
-	Type    string      `json:"type"`
-	Format  string      `json:"format"`
-	Example interface{} `json:"example"`
+	Type       string                    `json:"type,omitempty"`
+	Format     string                    `json:"format,omitempty"`
+	Example    interface{}               `json:"example,omitempty"`
+	Properties map[string]*OpenAPISchema `json:"properties,omitempty"`
+	Items      *OpenAPISchema            `json:"items,omitempty"`
+	Nullable   bool                      `json:"nullable,omitempty"`
 }
 
 type OpenAPIComponents struct {
-	SecuritySchemes map[string]OpenAPISecurityScheme `json:"securitySchemes"`
+	SecuritySchemes map[string]OpenAPISecurityScheme `json:"securitySchemes,omitempty"`
 }
 
 type OpenAPISecurityScheme struct {
 	Type        string `json:"type"`
-	Name        string `json:"name"`
-	In          string `json:"in"`
-	Scheme      string `json:"scheme"`
-	Description string `json:"description"`
+	Name        string `json:"name,omitempty"`
+	In          string `json:"in,omitempty"`
+	Scheme      string `json:"scheme,omitempty"`
+	Description string `json:"description,omitempty"`
 }
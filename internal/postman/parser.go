@@ -2,6 +2,7 @@ package postman
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -9,19 +10,28 @@ import (
 	"strings"
 )
 
+// ErrImportedCollection is returned by SaveCollection for a collection whose
+// file is an OpenAPI spec or HAR capture rather than a Postman collection.
+// Such collections are written with SaveCollectionAs instead.
+var ErrImportedCollection = errors.New("collection was imported from a non-Postman file")
+
 type Parser struct {
-	collections  map[string]*Collection
-	pathMap      map[string]string
-	environments map[string]*Environment
-	envPathMap   map[string]string
+	collections   map[string]*Collection
+	pathMap       map[string]string
+	environments  map[string]*Environment
+	envPathMap    map[string]string
+	specPathMap   map[string]string
+	importFormats map[string]CollectionFormat
 }
 
 func NewParser() *Parser {
 	return &Parser{
-		collections:  make(map[string]*Collection),
-		pathMap:      make(map[string]string),
-		environments: make(map[string]*Environment),
-		envPathMap:   make(map[string]string),
+		collections:   make(map[string]*Collection),
+		pathMap:       make(map[string]string),
+		environments:  make(map[string]*Environment),
+		envPathMap:    make(map[string]string),
+		specPathMap:   make(map[string]string),
+		importFormats: make(map[string]CollectionFormat),
 	}
 }
 
@@ -32,11 +42,43 @@ func (p *Parser) LoadCollection(path string) (*Collection, error) {
 		return nil, fmt.Errorf("failed to expand path: %w", err)
 	}
 
-	logger.LogFileOpen(expandedPath)
-	data, err := os.ReadFile(expandedPath)
+	collection, format, err := readCollectionFile(expandedPath)
 	if err != nil {
 		logger.LogError("LoadCollection", expandedPath, err)
-		return nil, fmt.Errorf("failed to read collection file: %w", err)
+		return nil, err
+	}
+
+	p.collections[collection.Info.Name] = collection
+	p.pathMap[collection.Info.Name] = expandedPath
+	delete(p.importFormats, collection.Info.Name)
+	if format != FormatPostman {
+		p.importFormats[collection.Info.Name] = format
+	}
+	if format == FormatOpenAPI {
+		p.specPathMap[collection.Info.Name] = expandedPath
+	}
+	return collection, nil
+}
+
+func (p *Parser) LoadCollectionSnapshot(name string) (*Collection, error) {
+	path, exists := p.pathMap[name]
+	if !exists {
+		return nil, fmt.Errorf("no file path for collection: %s", name)
+	}
+
+	collection, _, err := readCollectionFile(path)
+	if err != nil {
+		logger.LogError("LoadCollectionSnapshot", path, err)
+		return nil, err
+	}
+	return collection, nil
+}
+
+func readCollectionFile(path string) (*Collection, CollectionFormat, error) {
+	logger.LogFileOpen(path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, FormatUnknown, fmt.Errorf("failed to read collection file: %w", err)
 	}
 
 	format := DetectFormat(data)
@@ -47,18 +89,18 @@ func (p *Parser) LoadCollection(path string) (*Collection, error) {
 		collection, err = parsePostmanCollection(data)
 	case FormatOpenAPI:
 		collection, err = parseOpenAPISpec(data)
+	case FormatHAR:
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		collection, err = parseHAR(data, name)
 	default:
 		err = fmt.Errorf("unknown collection format")
 	}
 
 	if err != nil {
-		logger.LogError("LoadCollection", expandedPath, err)
-		return nil, fmt.Errorf("failed to parse collection: %w", err)
+		return nil, format, fmt.Errorf("failed to parse collection: %w", err)
 	}
-
-	p.collections[collection.Info.Name] = collection
-	p.pathMap[collection.Info.Name] = expandedPath
-	return collection, nil
+	EnsureIDs(collection)
+	return collection, format, nil
 }
 
 func parsePostmanCollection(data []byte) (*Collection, error) {
@@ -66,6 +108,7 @@ func parsePostmanCollection(data []byte) (*Collection, error) {
 	if err := json.Unmarshal(data, &collection); err != nil {
 		return nil, err
 	}
+	collection.layout = detectLayout(data)
 	return &collection, nil
 }
 
@@ -83,6 +126,35 @@ func parseOpenAPISpec(data []byte) (*Collection, error) {
 	return collection, nil
 }
 
+func (p *Parser) LoadOpenAPISpec(path string) (*OpenAPISpec, error) {
+	expandedPath, err := expandPath(path)
+	if err != nil {
+		logger.LogError("LoadOpenAPISpec", path, err)
+		return nil, fmt.Errorf("failed to expand path: %w", err)
+	}
+
+	logger.LogFileOpen(expandedPath)
+	data, err := os.ReadFile(expandedPath)
+	if err != nil {
+		logger.LogError("LoadOpenAPISpec", expandedPath, err)
+		return nil, fmt.Errorf("failed to read spec file: %w", err)
+	}
+
+	if DetectFormat(data) != FormatOpenAPI {
+		err := fmt.Errorf("not an OpenAPI spec: %s", expandedPath)
+		logger.LogError("LoadOpenAPISpec", expandedPath, err)
+		return nil, err
+	}
+
+	var spec OpenAPISpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		logger.LogError("LoadOpenAPISpec", expandedPath, err)
+		return nil, fmt.Errorf("failed to unmarshal OpenAPI spec: %w", err)
+	}
+
+	return &spec, nil
+}
+
 func expandPath(path string) (string, error) {
 	path = strings.TrimSpace(path)
 	if strings.HasPrefix(path, "~/") {
@@ -105,6 +177,18 @@ func (p *Parser) GetCollectionPath(name string) (string, bool) {
 	return path, exists
 }
 
+func (p *Parser) GetSpecPath(name string) (string, bool) {
+	path, exists := p.specPathMap[name]
+	return path, exists
+}
+
+// IsImported reports whether the collection's file is not a Postman
+// collection, so SaveCollection would refuse to overwrite it.
+func (p *Parser) IsImported(name string) bool {
+	_, imported := p.importFormats[name]
+	return imported
+}
+
 func (p *Parser) ListCollections() []string {
 	names := make([]string, 0, len(p.collections))
 	for name := range p.collections {
@@ -132,6 +216,7 @@ func (p *Parser) LoadEnvironment(path string) (*Environment, error) {
 		logger.LogError("LoadEnvironment", expandedPath, err)
 		return nil, fmt.Errorf("failed to parse environment: %w", err)
 	}
+	environment.layout = detectLayout(data)
 
 	p.environments[environment.Name] = &environment
 	p.envPathMap[environment.Name] = expandedPath
@@ -152,8 +237,7 @@ func (p *Parser) ListEnvironments() []string {
 }
 
 func (p *Parser) SaveCollection(name string) error {
-	collection, exists := p.collections[name]
-	if !exists {
+	if _, exists := p.collections[name]; !exists {
 		err := fmt.Errorf("collection not found: %s", name)
 		logger.LogError("SaveCollection", name, err)
 		return err
@@ -166,7 +250,18 @@ func (p *Parser) SaveCollection(name string) error {
 		return err
 	}
 
-	data, err := json.MarshalIndent(collection, "", "  ")
+	if p.IsImported(name) {
+		err := fmt.Errorf("%w: %s (use :saveas <path> to save it as a Postman collection)", ErrImportedCollection, path)
+		logger.LogError("SaveCollection", path, err)
+		return err
+	}
+
+	return p.writeCollection(name, path)
+}
+
+func (p *Parser) writeCollection(name string, path string) error {
+	collection := p.collections[name]
+	data, err := encodeDocument(collection, collection.layout)
 	if err != nil {
 		logger.LogError("SaveCollection", path, err)
 		return fmt.Errorf("failed to marshal collection: %w", err)
@@ -204,7 +299,7 @@ func (p *Parser) SaveEnvironment(name string) error {
 		return err
 	}
 
-	data, err := json.MarshalIndent(environment, "", "  ")
+	data, err := encodeDocument(environment, environment.layout)
 	if err != nil {
 		logger.LogError("SaveEnvironment", path, err)
 		return fmt.Errorf("failed to marshal environment: %w", err)
@@ -226,3 +321,165 @@ func (p *Parser) SaveEnvironment(name string) error {
 
 	return nil
 }
+
+func (p *Parser) NewCollection(name string, path string) (*Collection, error) {
+	if _, exists := p.collections[name]; exists {
+		return nil, fmt.Errorf("collection already loaded: %s", name)
+	}
+
+	expandedPath, err := newFilePath(path)
+	if err != nil {
+		logger.LogError("NewCollection", path, err)
+		return nil, err
+	}
+
+	collection := &Collection{
+		Info: Info{
+			PostmanID: NewID(),
+			Name:      name,
+			Schema:    postmanSchemaURL,
+		},
+		Items: []Item{},
+	}
+
+	p.collections[name] = collection
+	p.pathMap[name] = expandedPath
+	if err := p.SaveCollection(name); err != nil {
+		delete(p.collections, name)
+		delete(p.pathMap, name)
+		return nil, err
+	}
+	return collection, nil
+}
+
+func (p *Parser) NewEnvironment(name string, path string) (*Environment, error) {
+	if _, exists := p.environments[name]; exists {
+		return nil, fmt.Errorf("environment already loaded: %s", name)
+	}
+
+	expandedPath, err := newFilePath(path)
+	if err != nil {
+		logger.LogError("NewEnvironment", path, err)
+		return nil, err
+	}
+
+	environment := &Environment{
+		ID:     NewID(),
+		Name:   name,
+		Values: []EnvVariable{},
+	}
+
+	p.environments[name] = environment
+	p.envPathMap[name] = expandedPath
+	if err := p.SaveEnvironment(name); err != nil {
+		delete(p.environments, name)
+		delete(p.envPathMap, name)
+		return nil, err
+	}
+	return environment, nil
+}
+
+func (p *Parser) SaveCollectionAs(name string, path string) error {
+	if _, exists := p.collections[name]; !exists {
+		return fmt.Errorf("collection not found: %s", name)
+	}
+
+	expandedPath, err := newFilePath(path)
+	if err != nil {
+		logger.LogError("SaveCollectionAs", path, err)
+		return err
+	}
+
+	if err := p.writeCollection(name, expandedPath); err != nil {
+		return err
+	}
+	p.pathMap[name] = expandedPath
+	delete(p.importFormats, name)
+	return nil
+}
+
+func (p *Parser) SaveEnvironmentAs(name string, path string) error {
+	if _, exists := p.environments[name]; !exists {
+		return fmt.Errorf("environment not found: %s", name)
+	}
+
+	expandedPath, err := newFilePath(path)
+	if err != nil {
+		logger.LogError("SaveEnvironmentAs", path, err)
+		return err
+	}
+
+	previousPath, hadPath := p.envPathMap[name]
+	p.envPathMap[name] = expandedPath
+	if err := p.SaveEnvironment(name); err != nil {
+		if hadPath {
+			p.envPathMap[name] = previousPath
+		} else {
+			delete(p.envPathMap, name)
+		}
+		return err
+	}
+	return nil
+}
+
+func newFilePath(path string) (string, error) {
+	expandedPath, err := expandPath(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to expand path: %w", err)
+	}
+	if expandedPath == "" {
+		return "", fmt.Errorf("no file path given")
+	}
+	if _, err := os.Stat(expandedPath); err == nil {
+		return "", fmt.Errorf("file already exists: %s", expandedPath)
+	}
+	if info, err := os.Stat(filepath.Dir(expandedPath)); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("directory does not exist: %s", filepath.Dir(expandedPath))
+	}
+	return expandedPath, nil
+}
+
+// ExportOpenAPI writes the named collection to path as an OpenAPI spec and
+// returns the requests that could not be represented in it.
+func (p *Parser) ExportOpenAPI(name string, path string, lookup ResponseLookup) ([]string, error) {
+	collection, exists := p.collections[name]
+	if !exists {
+		err := fmt.Errorf("collection not found: %s", name)
+		logger.LogError("ExportOpenAPI", name, err)
+		return nil, err
+	}
+
+	path, err := expandPath(path)
+	if err != nil {
+		logger.LogError("ExportOpenAPI", path, err)
+		return nil, err
+	}
+
+	spec, skipped, err := ConvertCollectionToOpenAPI(collection, lookup)
+	if err != nil {
+		logger.LogError("ExportOpenAPI", name, err)
+		return nil, fmt.Errorf("failed to convert collection: %w", err)
+	}
+
+	data, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		logger.LogError("ExportOpenAPI", path, err)
+		return nil, fmt.Errorf("failed to marshal spec: %w", err)
+	}
+
+	tempPath := path + ".tmp"
+	logger.LogFileWrite(tempPath)
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		logger.LogError("ExportOpenAPI", tempPath, err)
+		return nil, fmt.Errorf("failed to write temp file: %w", err)
+	}
+
+	logger.LogFileWrite(path)
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath)
+		logger.LogError("ExportOpenAPI", path, err)
+		return nil, fmt.Errorf("failed to rename temp file: %w", err)
+	}
+
+	return skipped, nil
+}
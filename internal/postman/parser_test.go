@@ -2,6 +2,7 @@ package postman
 
 import (
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -929,3 +930,116 @@ func findItemByName(items []Item, name string) *Item {
 	}
 	return nil
 }
+
+func TestNewCollection_CreatesLoadableFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scratch.postman_collection.json")
+
+	parser := NewParser()
+	collection, err := parser.NewCollection("Scratch", path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	collection.Items = append(collection.Items, Item{ID: NewID(), Name: "Ping", Request: &Request{Method: "GET", Header: []Header{}}})
+	if err := parser.SaveCollection("Scratch"); err != nil {
+		t.Fatalf("Expected new collection to be saveable, got %v", err)
+	}
+
+	reloaded, err := NewParser().LoadCollection(path)
+	if err != nil {
+		t.Fatalf("Failed to reload collection: %v", err)
+	}
+	if reloaded.Info.Schema != postmanSchemaURL || reloaded.Info.PostmanID == "" || len(reloaded.Items) != 1 {
+		t.Errorf("Unexpected collection: %+v", reloaded)
+	}
+
+	if _, err := parser.NewCollection("Other", path); err == nil {
+		t.Error("Expected an existing file not to be overwritten")
+	}
+	if _, err := parser.NewCollection("Scratch", filepath.Join(t.TempDir(), "x.json")); err == nil {
+		t.Error("Expected a duplicate collection name to be rejected")
+	}
+}
+
+func TestNewEnvironment_CreatesLoadableFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "local.postman_environment.json")
+
+	parser := NewParser()
+	if _, err := parser.NewEnvironment("Local", path); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	reloaded, err := NewParser().LoadEnvironment(path)
+	if err != nil {
+		t.Fatalf("Failed to reload environment: %v", err)
+	}
+	if reloaded.Name != "Local" || reloaded.ID == "" || reloaded.Values == nil {
+		t.Errorf("Unexpected environment: %+v", reloaded)
+	}
+}
+
+func TestSaveCollectionAs_SwitchesPath(t *testing.T) {
+	parser := NewParser()
+	originalPath := createTempCollection(t, &Collection{Info: Info{Name: "Original"}})
+	parser.LoadCollection(originalPath)
+
+	newPath := filepath.Join(t.TempDir(), "copy.json")
+	if err := parser.SaveCollectionAs("Original", newPath); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if path, _ := parser.GetCollectionPath("Original"); path != newPath {
+		t.Errorf("Expected collection path %s, got %s", newPath, path)
+	}
+	if _, err := os.Stat(newPath); err != nil {
+		t.Errorf("Expected new file to be written: %v", err)
+	}
+
+	if err := parser.SaveCollectionAs("Original", originalPath); err == nil {
+		t.Error("Expected save as onto an existing file to fail")
+	}
+	if path, _ := parser.GetCollectionPath("Original"); path != newPath {
+		t.Error("Expected failed save as to keep the current path")
+	}
+}
+
+func TestSaveCollection_RefusesOpenAPISource(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "spec.json")
+	original, err := os.ReadFile("../../testdata/openapi_petstore.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(specPath, original, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	parser := NewParser()
+	collection, err := parser.LoadCollection(specPath)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !parser.IsImported(collection.Info.Name) {
+		t.Fatal("Expected OpenAPI collection to be marked as imported")
+	}
+
+	if err := parser.SaveCollection(collection.Info.Name); !errors.Is(err, ErrImportedCollection) {
+		t.Fatalf("Expected ErrImportedCollection, got %v", err)
+	}
+	if data, _ := os.ReadFile(specPath); string(data) != string(original) {
+		t.Fatal("Expected spec file to be left untouched")
+	}
+
+	newPath := filepath.Join(t.TempDir(), "collection.json")
+	if err := parser.SaveCollectionAs(collection.Info.Name, newPath); err != nil {
+		t.Fatalf("Expected save as to succeed, got %v", err)
+	}
+	if parser.IsImported(collection.Info.Name) {
+		t.Error("Expected collection saved as Postman to no longer be imported")
+	}
+	if err := parser.SaveCollection(collection.Info.Name); err != nil {
+		t.Errorf("Expected save to the new path to succeed, got %v", err)
+	}
+	if specPathAfter, _ := parser.GetSpecPath(collection.Info.Name); specPathAfter != specPath {
+		t.Errorf("Expected spec path to be kept for :resync, got %s", specPathAfter)
+	}
+}
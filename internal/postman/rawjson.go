@@ -0,0 +1,287 @@
+package postman
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+const defaultIndent = "  "
+
+type rawFields struct {
+	order   []string
+	members map[string]json.RawMessage
+	opaque  map[string]bool
+}
+
+type documentLayout struct {
+	detected        bool
+	indent          string
+	trailingNewline bool
+}
+
+var knownFieldsCache sync.Map
+
+func unmarshalPreserving(data []byte, v any, fields *rawFields) error {
+	var typeErr *json.UnmarshalTypeError
+	if err := json.Unmarshal(data, v); err != nil && !errors.As(err, &typeErr) {
+		return err
+	}
+
+	order, members, err := decodeObjectMembers(data)
+	if err != nil {
+		return err
+	}
+	fields.order = order
+	fields.members = members
+	if typeErr != nil {
+		fields.opaque = mismatchedFields(reflect.TypeOf(v).Elem(), members)
+	}
+	return nil
+}
+
+func mismatchedFields(t reflect.Type, members map[string]json.RawMessage) map[string]bool {
+	mismatched := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := jsonFieldName(field)
+		member, exists := members[name]
+		if name == "" || !exists {
+			continue
+		}
+		target := reflect.New(field.Type)
+		var typeErr *json.UnmarshalTypeError
+		if err := json.Unmarshal(member, target.Interface()); errors.As(err, &typeErr) {
+			mismatched[name] = true
+		}
+	}
+	return mismatched
+}
+
+func marshalPreserving(v any, fields rawFields) ([]byte, error) {
+	current, err := encodeJSON(v)
+	if err != nil || len(fields.order) == 0 {
+		return current, err
+	}
+
+	order, members, err := decodeObjectMembers(current)
+	if err != nil {
+		return nil, err
+	}
+	known := knownFields(reflect.TypeOf(v))
+
+	var buf bytes.Buffer
+	written := make(map[string]bool)
+	write := func(key string, value json.RawMessage) error {
+		if len(written) > 0 {
+			buf.WriteByte(',')
+		}
+		encodedKey, err := encodeJSON(key)
+		if err != nil {
+			return err
+		}
+		buf.Write(encodedKey)
+		buf.WriteByte(':')
+		buf.Write(value)
+		written[key] = true
+		return nil
+	}
+
+	buf.WriteByte('{')
+	for _, key := range fields.order {
+		original := fields.members[key]
+		value, present := members[key]
+
+		var err error
+		switch {
+		case !known[key]:
+			err = write(key, original)
+		case fields.opaque[key] && (!present || isEmptyJSON(value)):
+			err = write(key, original)
+		case present && sameJSON(original, value):
+			err = write(key, original)
+		case present:
+			err = write(key, value)
+		case isEmptyJSON(original):
+			err = write(key, original)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, key := range order {
+		if written[key] || isEmptyJSON(members[key]) {
+			continue
+		}
+		if err := write(key, members[key]); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+func decodeObjectMembers(data []byte) ([]string, map[string]json.RawMessage, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return nil, nil, fmt.Errorf("expected JSON object")
+	}
+
+	var order []string
+	members := make(map[string]json.RawMessage)
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key, ok := token.(string)
+		if !ok {
+			return nil, nil, fmt.Errorf("expected object key")
+		}
+
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+		if _, exists := members[key]; !exists {
+			order = append(order, key)
+		}
+		members[key] = value
+	}
+
+	return order, members, nil
+}
+
+func knownFields(t reflect.Type) map[string]bool {
+	if cached, ok := knownFieldsCache.Load(t); ok {
+		return cached.(map[string]bool)
+	}
+
+	known := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Tag.Get("json") == "" && field.Type.Kind() == reflect.Struct {
+			for name := range knownFields(field.Type) {
+				known[name] = true
+			}
+			continue
+		}
+		if name := jsonFieldName(field); name != "" {
+			known[name] = true
+		}
+	}
+
+	knownFieldsCache.Store(t, known)
+	return known
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	tag := field.Tag.Get("json")
+	if !field.IsExported() || tag == "-" {
+		return ""
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	return name
+}
+
+func sameJSON(original, current json.RawMessage) bool {
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, original); err == nil && bytes.Equal(compacted.Bytes(), current) {
+		return true
+	}
+
+	var a, b any
+	if err := unmarshalUseNumber(original, &a); err != nil {
+		return false
+	}
+	if err := unmarshalUseNumber(current, &b); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func unmarshalUseNumber(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+func isEmptyJSON(data json.RawMessage) bool {
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, data); err != nil {
+		return false
+	}
+	switch compacted.String() {
+	case `""`, `[]`, `{}`, `null`, `false`, `0`:
+		return true
+	}
+	return false
+}
+
+func encodeJSON(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func detectLayout(data []byte) documentLayout {
+	layout := documentLayout{
+		detected:        true,
+		trailingNewline: bytes.HasSuffix(data, []byte("\n")),
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	newline := bytes.IndexByte(trimmed, '\n')
+	if newline < 0 {
+		return layout
+	}
+
+	line := trimmed[newline+1:]
+	end := 0
+	for end < len(line) && (line[end] == ' ' || line[end] == '\t') {
+		end++
+	}
+	layout.indent = string(line[:end])
+	if layout.indent == "" {
+		layout.indent = defaultIndent
+	}
+	return layout
+}
+
+func encodeDocument(v any, layout documentLayout) ([]byte, error) {
+	compact, err := encodeJSON(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if !layout.detected {
+		layout.indent = defaultIndent
+	}
+
+	var buf bytes.Buffer
+	if layout.indent == "" {
+		buf.Write(compact)
+	} else if err := json.Indent(&buf, compact, "", layout.indent); err != nil {
+		return nil, err
+	}
+	if layout.trailingNewline {
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,167 @@
+package postman
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func copyGolden(t *testing.T, name string) (string, []byte) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "roundtrip", name))
+	if err != nil {
+		t.Fatalf("Failed to read golden file: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("Failed to copy golden file: %v", err)
+	}
+	return path, data
+}
+
+func TestRoundTrip_CollectionsAreByteForByteStable(t *testing.T) {
+	for _, name := range []string{"bookstore.postman_collection.json", "two-space.json", "no-ids.json"} {
+		t.Run(name, func(t *testing.T) {
+			path, original := copyGolden(t, name)
+
+			parser := NewParser()
+			collection, err := parser.LoadCollection(path)
+			if err != nil {
+				t.Fatalf("Failed to load collection: %v", err)
+			}
+			if err := parser.SaveCollection(collection.Info.Name); err != nil {
+				t.Fatalf("Failed to save collection: %v", err)
+			}
+
+			saved, _ := os.ReadFile(path)
+			if string(saved) != string(original) {
+				t.Errorf("Round trip changed the file:\n%s", firstDifference(string(original), string(saved)))
+			}
+		})
+	}
+}
+
+func TestRoundTrip_EnvironmentIsByteForByteStable(t *testing.T) {
+	path, original := copyGolden(t, "staging.postman_environment.json")
+
+	parser := NewParser()
+	environment, err := parser.LoadEnvironment(path)
+	if err != nil {
+		t.Fatalf("Failed to load environment: %v", err)
+	}
+	if err := parser.SaveEnvironment(environment.Name); err != nil {
+		t.Fatalf("Failed to save environment: %v", err)
+	}
+
+	saved, _ := os.ReadFile(path)
+	if string(saved) != string(original) {
+		t.Errorf("Round trip changed the file:\n%s", firstDifference(string(original), string(saved)))
+	}
+}
+
+func TestRoundTrip_EditOnlyChangesEditedValue(t *testing.T) {
+	path, original := copyGolden(t, "bookstore.postman_collection.json")
+
+	parser := NewParser()
+	collection, err := parser.LoadCollection(path)
+	if err != nil {
+		t.Fatalf("Failed to load collection: %v", err)
+	}
+
+	request := collection.Items[0].Items[0].Request
+	request.Header[0].Value = "application/xml"
+	request.Header = append(request.Header, Header{Key: "X-Trace", Value: "on"})
+
+	if err := parser.SaveCollection(collection.Info.Name); err != nil {
+		t.Fatalf("Failed to save collection: %v", err)
+	}
+
+	saved, _ := os.ReadFile(path)
+	indent := strings.Repeat("\t", 7)
+	expected := strings.Replace(string(original), `"value": "application/json"`, `"value": "application/xml"`, 1)
+	expected = strings.Replace(expected,
+		"\"Enable verbose logs\"\n"+indent+"}\n",
+		"\"Enable verbose logs\"\n"+indent+"},\n"+
+			indent+"{\n"+
+			indent+"\t\"key\": \"X-Trace\",\n"+
+			indent+"\t\"value\": \"on\"\n"+
+			indent+"}\n", 1)
+
+	if string(saved) != expected {
+		t.Errorf("Unexpected changes after edit:\n%s", firstDifference(expected, string(saved)))
+	}
+}
+
+func TestRoundTrip_PreservesUnmodeledValues(t *testing.T) {
+	path, _ := copyGolden(t, "bookstore.postman_collection.json")
+
+	collection, err := NewParser().LoadCollection(path)
+	if err != nil {
+		t.Fatalf("Failed to load collection: %v", err)
+	}
+
+	health := collection.Items[1].Request
+	if health == nil || health.Method != "GET" || health.URL.Raw != "{{baseUrl}}/health" {
+		t.Errorf("Expected string request to load as GET {{baseUrl}}/health, got %+v", health)
+	}
+
+	login := collection.Items[2].Request
+	if login.URL.Raw != "{{baseUrl}}/login" {
+		t.Errorf("Expected string URL to load into Raw, got %q", login.URL.Raw)
+	}
+
+	if collection.Variables[1].Key != "limit" {
+		t.Errorf("Expected variable with non-string value to still load, got %+v", collection.Variables[1])
+	}
+
+	collection.Variables[1].Value = "25"
+	data, err := encodeJSON(collection.Variables[1])
+	if err != nil {
+		t.Fatalf("Failed to marshal variable: %v", err)
+	}
+	if string(data) != `{"key":"limit","value":"25","type":"number"}` {
+		t.Errorf("Expected edited value to replace the unmodeled one, got %s", data)
+	}
+}
+
+func TestRoundTrip_LoadsSavedExamples(t *testing.T) {
+	path, _ := copyGolden(t, "bookstore.postman_collection.json")
+
+	collection, err := NewParser().LoadCollection(path)
+	if err != nil {
+		t.Fatalf("Failed to load collection: %v", err)
+	}
+
+	examples := collection.Items[0].Items[0].Responses
+	if len(examples) != 1 {
+		t.Fatalf("Expected 1 saved example, got %d", len(examples))
+	}
+	example := examples[0]
+	if example.Name != "OK" || example.Code != 200 || example.PreviewLanguage != "json" {
+		t.Errorf("Unexpected example: %+v", example)
+	}
+	if example.OriginalRequest == nil || example.OriginalRequest.URL.Raw != "{{baseUrl}}/books?limit=10&sort=title" {
+		t.Errorf("Expected original request with string URL, got %+v", example.OriginalRequest)
+	}
+}
+
+func firstDifference(expected, actual string) string {
+	expectedLines := strings.Split(expected, "\n")
+	actualLines := strings.Split(actual, "\n")
+	for i := 0; i < len(expectedLines) || i < len(actualLines); i++ {
+		var want, got string
+		if i < len(expectedLines) {
+			want = expectedLines[i]
+		}
+		if i < len(actualLines) {
+			got = actualLines[i]
+		}
+		if want != got {
+			return fmt.Sprintf("line %d:\n  want: %s\n  got:  %s", i+1, want, got)
+		}
+	}
+	return "files differ only in trailing bytes"
+}
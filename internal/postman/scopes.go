@@ -0,0 +1,81 @@
+package postman
+
+import "slices"
+
+type VariableScopes struct {
+	Collection  *Collection
+	Environment *Environment
+
+	collectionBase  []Variable
+	environmentBase []EnvVariable
+}
+
+type VariableChanges struct {
+	Collection  []Variable
+	Environment []EnvVariable
+}
+
+func SnapshotVariableScopes(collection *Collection, environment *Environment) *VariableScopes {
+	scopes := &VariableScopes{}
+	if collection != nil {
+		copied := *collection
+		copied.Variables = slices.Clone(collection.Variables)
+		scopes.Collection = &copied
+		scopes.collectionBase = slices.Clone(collection.Variables)
+	}
+	if environment != nil {
+		copied := *environment
+		copied.Values = slices.Clone(environment.Values)
+		scopes.Environment = &copied
+		scopes.environmentBase = slices.Clone(environment.Values)
+	}
+	return scopes
+}
+
+func (s *VariableScopes) Changes() VariableChanges {
+	var changes VariableChanges
+	if s.Collection != nil {
+		for _, variable := range s.Collection.Variables {
+			index := slices.IndexFunc(s.collectionBase, func(v Variable) bool { return v.Key == variable.Key })
+			if index == -1 || s.collectionBase[index].Value != variable.Value {
+				changes.Collection = append(changes.Collection, variable)
+			}
+		}
+	}
+	if s.Environment != nil {
+		for _, variable := range s.Environment.Values {
+			index := slices.IndexFunc(s.environmentBase, func(v EnvVariable) bool { return v.Key == variable.Key })
+			if index == -1 || s.environmentBase[index].Value != variable.Value {
+				changes.Environment = append(changes.Environment, variable)
+			}
+		}
+	}
+	return changes
+}
+
+func (c VariableChanges) Empty() bool {
+	return len(c.Collection) == 0 && len(c.Environment) == 0
+}
+
+func (c VariableChanges) ApplyTo(collection *Collection, environment *Environment) {
+	if collection != nil {
+		for _, variable := range c.Collection {
+			index := slices.IndexFunc(collection.Variables, func(v Variable) bool { return v.Key == variable.Key })
+			if index == -1 {
+				collection.Variables = append(collection.Variables, variable)
+				continue
+			}
+			collection.Variables[index].Value = variable.Value
+		}
+	}
+	if environment != nil {
+		for _, variable := range c.Environment {
+			index := slices.IndexFunc(environment.Values, func(v EnvVariable) bool { return v.Key == variable.Key })
+			if index == -1 {
+				environment.Values = append(environment.Values, variable)
+				continue
+			}
+			environment.Values[index].Value = variable.Value
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package postman
+
+import "testing"
+
+func TestSnapshotVariableScopes(t *testing.T) {
+	collection := &Collection{Info: Info{Name: "API"}, Variables: []Variable{{Key: "base", Value: "v1"}, {Key: "token", Value: "old"}}}
+	environment := &Environment{Name: "Dev", Values: []EnvVariable{{Key: "user", Value: "alice", Enabled: true}}}
+
+	scopes := SnapshotVariableScopes(collection, environment)
+	if scopes.Collection == collection || scopes.Environment == environment {
+		t.Fatal("Expected the snapshot to copy the scopes")
+	}
+	if changes := scopes.Changes(); !changes.Empty() {
+		t.Errorf("Expected no changes for an untouched snapshot, got %+v", changes)
+	}
+
+	scopes.Collection.Variables[1].Value = "new"
+	scopes.Collection.Variables = append(scopes.Collection.Variables, Variable{Key: "id", Value: "42"})
+	scopes.Environment.Values = append(scopes.Environment.Values, EnvVariable{Key: "session", Value: "abc", Enabled: true, Type: "default"})
+
+	if collection.Variables[1].Value != "old" || len(collection.Variables) != 2 || len(environment.Values) != 1 {
+		t.Fatal("Expected writes to the snapshot to leave the originals untouched")
+	}
+
+	changes := scopes.Changes()
+	if len(changes.Collection) != 2 || changes.Collection[0].Key != "token" || changes.Collection[1].Key != "id" {
+		t.Errorf("Unexpected collection changes: %+v", changes.Collection)
+	}
+	if len(changes.Environment) != 1 || changes.Environment[0].Key != "session" {
+		t.Errorf("Unexpected environment changes: %+v", changes.Environment)
+	}
+
+	collection.Variables = append(collection.Variables, Variable{Key: "other", Value: "kept"})
+	changes.ApplyTo(collection, environment)
+	expected := []Variable{{Key: "base", Value: "v1"}, {Key: "token", Value: "new"}, {Key: "other", Value: "kept"}, {Key: "id", Value: "42"}}
+	if len(collection.Variables) != len(expected) {
+		t.Fatalf("Expected %d variables, got %+v", len(expected), collection.Variables)
+	}
+	for i, variable := range expected {
+		if collection.Variables[i].Key != variable.Key || collection.Variables[i].Value != variable.Value {
+			t.Errorf("Variable %d: expected %s=%s, got %s=%s", i, variable.Key, variable.Value, collection.Variables[i].Key, collection.Variables[i].Value)
+		}
+	}
+	if len(environment.Values) != 2 || !environment.Values[1].Enabled || environment.Values[1].Value != "abc" {
+		t.Errorf("Expected the new environment variable to be added, got %+v", environment.Values)
+	}
+}
+
+func TestSnapshotVariableScopes_Nil(t *testing.T) {
+	scopes := SnapshotVariableScopes(nil, nil)
+	if scopes.Collection != nil || scopes.Environment != nil || !scopes.Changes().Empty() {
+		t.Errorf("Expected empty scopes, got %+v", scopes)
+	}
+	VariableChanges{Collection: []Variable{{Key: "a"}}}.ApplyTo(nil, nil)
+}
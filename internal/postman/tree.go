@@ -0,0 +1,95 @@
+package postman
+
+import (
+	"fmt"
+	"strings"
+)
+
+func FindFolder(items *[]Item, path []string) (*[]Item, error) {
+	current := items
+	for _, folderName := range path {
+		found := false
+		for i := range *current {
+			if (*current)[i].Name == folderName && (*current)[i].IsFolder() {
+				current = &(*current)[i].Items
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("folder not found: %s", strings.Join(path, "/"))
+		}
+	}
+	return current, nil
+}
+
+func SplitFolderPath(path string) []string {
+	var parts []string
+	for _, part := range strings.Split(path, "/") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+func HasFolder(items []Item, name string) bool {
+	for i := range items {
+		if items[i].Name == name && items[i].IsFolder() {
+			return true
+		}
+	}
+	return false
+}
+
+func RemoveItem(items *[]Item, id string) (Item, bool) {
+	for i := range *items {
+		if (*items)[i].ID == id {
+			removed := (*items)[i]
+			*items = append((*items)[:i], (*items)[i+1:]...)
+			return removed, true
+		}
+	}
+	return Item{}, false
+}
+
+func InsertItem(items *[]Item, index int, item Item) {
+	if index < 0 || index > len(*items) {
+		index = len(*items)
+	}
+	if item.Request == nil {
+		item.folder = true
+	}
+	*items = append(*items, Item{})
+	copy((*items)[index+1:], (*items)[index:])
+	(*items)[index] = item
+}
+
+func MoveItem(items []Item, from, to int) bool {
+	if from < 0 || from >= len(items) || to < 0 || to >= len(items) || from == to {
+		return false
+	}
+	item := items[from]
+	if from < to {
+		copy(items[from:to], items[from+1:to+1])
+	} else {
+		copy(items[to+1:from+1], items[to:from])
+	}
+	items[to] = item
+	return true
+}
+
+func FolderPathIDs(items []Item, path []string) []string {
+	var ids []string
+	current := items
+	for _, folderName := range path {
+		for i := range current {
+			if current[i].Name == folderName && current[i].IsFolder() {
+				ids = append(ids, current[i].ID)
+				current = current[i].Items
+				break
+			}
+		}
+	}
+	return ids
+}
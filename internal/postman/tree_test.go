@@ -0,0 +1,92 @@
+package postman
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func itemNames(items []Item) []string {
+	var names []string
+	for _, item := range items {
+		names = append(names, item.Name)
+	}
+	return names
+}
+
+func TestFindFolder(t *testing.T) {
+	items := []Item{
+		{Name: "Users", Items: []Item{
+			NewFolder("Admin"),
+		}},
+		{Name: "Health", Request: &Request{Method: "GET"}},
+	}
+
+	folder, err := FindFolder(&items, SplitFolderPath(" Users / Admin/"))
+	if err != nil || len(*folder) != 0 {
+		t.Fatalf("Expected empty Admin folder, got %v, %v", folder, err)
+	}
+
+	if _, err := FindFolder(&items, []string{"Health"}); err == nil {
+		t.Error("Expected a request not to resolve as a folder")
+	}
+}
+
+func TestInsertAndMoveItem(t *testing.T) {
+	items := []Item{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+
+	InsertItem(&items, 1, Item{Name: "x"})
+	if got := itemNames(items); len(got) != 4 || got[1] != "x" {
+		t.Fatalf("Unexpected order after insert: %v", got)
+	}
+
+	MoveItem(items, 0, 3)
+	expected := []string{"x", "b", "c", "a"}
+	for i, name := range itemNames(items) {
+		if name != expected[i] {
+			t.Fatalf("Expected %v, got %v", expected, itemNames(items))
+		}
+	}
+
+	if MoveItem(items, 3, 4) {
+		t.Error("Expected moving past the end to fail")
+	}
+}
+
+func TestItem_EmptyFolderKeepsItemArray(t *testing.T) {
+	data, err := json.Marshal(NewFolder("Empty"))
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	if string(data) != `{"name":"Empty","item":[]}` {
+		t.Errorf("Expected empty folder to keep its item array, got %s", data)
+	}
+
+	var item Item
+	if err := json.Unmarshal(data, &item); err != nil || !item.IsFolder() {
+		t.Errorf("Expected empty folder to load as a folder, got %+v", item)
+	}
+}
+
+func TestItem_LoadedFolderStaysFolderWhenEmptied(t *testing.T) {
+	var folder Item
+	if err := json.Unmarshal([]byte(`{"name":"Users","item":[{"name":"Get","request":"https://example.com"}],"x-extra":1}`), &folder); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+
+	InsertItem(&folder.Items, 0, Item{Name: "Sub"})
+	if !folder.Items[0].IsFolder() {
+		t.Error("Expected an inserted item without a request to be a folder")
+	}
+
+	folder.Items = nil
+	if !folder.IsFolder() {
+		t.Fatal("Expected folder to stay a folder without children")
+	}
+	data, err := json.Marshal(folder)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	if string(data) != `{"name":"Users","item":[],"x-extra":1}` {
+		t.Errorf("Expected item array in place and unknown fields kept, got %s", data)
+	}
+}
@@ -1,25 +1,56 @@
 package postman
 
+import (
+	"encoding/json"
+)
+
 type Collection struct {
 	Info      Info       `json:"info"`
 	Items     []Item     `json:"item"`
 	Variables []Variable `json:"variable,omitempty"`
 	Events    []Event    `json:"event,omitempty"`
+
+	fields rawFields
+	layout documentLayout
 }
 
 type Info struct {
+	PostmanID   string `json:"_postman_id,omitempty"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	Schema      string `json:"schema"`
+
+	fields      rawFields
+	generatedID bool
 }
 
 type Item struct {
+	ID          string     `json:"id,omitempty"`
 	Name        string     `json:"name"`
 	Request     *Request   `json:"request,omitempty"`
 	Items       []Item     `json:"item,omitempty"`
 	Description string     `json:"description,omitempty"`
 	Variables   []Variable `json:"variable,omitempty"`
 	Events      []Event    `json:"event,omitempty"`
+	Responses   []Response `json:"response,omitempty"`
+	OperationID string     `json:"_openapi_operation_id,omitempty"`
+
+	fields      rawFields
+	generatedID bool
+	folder      bool
+}
+
+type Response struct {
+	ID              string   `json:"id,omitempty"`
+	Name            string   `json:"name"`
+	OriginalRequest *Request `json:"originalRequest,omitempty"`
+	Status          string   `json:"status,omitempty"`
+	Code            int      `json:"code,omitempty"`
+	PreviewLanguage string   `json:"_postman_previewlanguage,omitempty"`
+	Header          []Header `json:"header"`
+	Body            string   `json:"body,omitempty"`
+
+	fields rawFields
 }
 
 type Request struct {
@@ -27,27 +58,53 @@ type Request struct {
 	Header []Header `json:"header"`
 	Body   *Body    `json:"body,omitempty"`
 	URL    URL      `json:"url"`
+
+	fields  rawFields
+	literal bool
 }
 
 type Header struct {
 	Key   string `json:"key"`
 	Value string `json:"value"`
 	Type  string `json:"type,omitempty"`
+
+	fields rawFields
 }
 
 type Body struct {
-	Mode string `json:"mode"`
-	Raw  string `json:"raw,omitempty"`
+	Mode     string      `json:"mode"`
+	Raw      string      `json:"raw,omitempty"`
+	FormData []FormParam `json:"formdata,omitempty"`
+
+	fields rawFields
+}
+
+type FormParam struct {
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+	Type  string `json:"type,omitempty"`
+	Src   string `json:"src,omitempty"`
+
+	fields rawFields
 }
 
 type URL struct {
 	Raw  string   `json:"raw"`
 	Host []string `json:"host,omitempty"`
 	Path []string `json:"path,omitempty"`
+
+	fields  rawFields
+	literal bool
+}
+
+// NewFolder returns an empty folder. Unlike a bare Item, it stays a folder
+// while it has no children.
+func NewFolder(name string) Item {
+	return Item{Name: name, Items: []Item{}, folder: true}
 }
 
 func (i *Item) IsFolder() bool {
-	return i.Request == nil && len(i.Items) > 0
+	return i.Request == nil && (len(i.Items) > 0 || i.folder)
 }
 
 func (i *Item) IsRequest() bool {
@@ -58,6 +115,9 @@ type Environment struct {
 	ID     string        `json:"id"`
 	Name   string        `json:"name"`
 	Values []EnvVariable `json:"values"`
+
+	fields rawFields
+	layout documentLayout
 }
 
 type EnvVariable struct {
@@ -65,6 +125,8 @@ type EnvVariable struct {
 	Value   string `json:"value"`
 	Enabled bool   `json:"enabled"`
 	Type    string `json:"type"`
+
+	fields rawFields
 }
 
 type Variable struct {
@@ -72,14 +134,195 @@ type Variable struct {
 	Value   string `json:"value"`
 	Type    string `json:"type,omitempty"`
 	Enabled bool   `json:"enabled,omitempty"`
+
+	fields rawFields
 }
 
 type Event struct {
 	Listen string `json:"listen"`
 	Script Script `json:"script"`
+
+	fields rawFields
 }
 
 type Script struct {
 	Type string   `json:"type"`
 	Exec []string `json:"exec"`
+
+	fields rawFields
+}
+
+func (c *Collection) UnmarshalJSON(data []byte) error {
+	type plain Collection
+	return unmarshalPreserving(data, (*plain)(c), &c.fields)
+}
+
+func (c Collection) MarshalJSON() ([]byte, error) {
+	type plain Collection
+	return marshalPreserving(plain(c), c.fields)
+}
+
+func (i *Info) UnmarshalJSON(data []byte) error {
+	type plain Info
+	return unmarshalPreserving(data, (*plain)(i), &i.fields)
+}
+
+func (i Info) MarshalJSON() ([]byte, error) {
+	type plain Info
+	if i.generatedID {
+		i.PostmanID = ""
+	}
+	return marshalPreserving(plain(i), i.fields)
+}
+
+func (i *Item) UnmarshalJSON(data []byte) error {
+	type plain Item
+	if err := unmarshalPreserving(data, (*plain)(i), &i.fields); err != nil {
+		return err
+	}
+	_, hasItems := i.fields.members["item"]
+	i.folder = i.Request == nil && hasItems
+	return nil
+}
+
+func (i Item) MarshalJSON() ([]byte, error) {
+	type plain Item
+	if i.generatedID {
+		i.ID = ""
+	}
+	if !i.IsFolder() || len(i.Items) > 0 {
+		return marshalPreserving(plain(i), i.fields)
+	}
+
+	// An empty folder keeps its item array so it loads as a folder again.
+	return marshalPreserving(struct {
+		plain
+		Items []Item `json:"item"`
+	}{plain(i), []Item{}}, i.fields)
+}
+
+func (r *Response) UnmarshalJSON(data []byte) error {
+	type plain Response
+	return unmarshalPreserving(data, (*plain)(r), &r.fields)
+}
+
+func (r Response) MarshalJSON() ([]byte, error) {
+	type plain Response
+	return marshalPreserving(plain(r), r.fields)
+}
+
+func (r *Request) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err == nil {
+		*r = Request{Method: "GET", URL: URL{Raw: raw, literal: true}, literal: true}
+		return nil
+	}
+	type plain Request
+	return unmarshalPreserving(data, (*plain)(r), &r.fields)
+}
+
+func (r Request) MarshalJSON() ([]byte, error) {
+	if r.literal && r.Method == "GET" && len(r.Header) == 0 && r.Body == nil {
+		return r.URL.MarshalJSON()
+	}
+	type plain Request
+	return marshalPreserving(plain(r), r.fields)
+}
+
+func (h *Header) UnmarshalJSON(data []byte) error {
+	type plain Header
+	return unmarshalPreserving(data, (*plain)(h), &h.fields)
+}
+
+func (h Header) MarshalJSON() ([]byte, error) {
+	type plain Header
+	return marshalPreserving(plain(h), h.fields)
+}
+
+func (b *Body) UnmarshalJSON(data []byte) error {
+	type plain Body
+	return unmarshalPreserving(data, (*plain)(b), &b.fields)
+}
+
+func (b Body) MarshalJSON() ([]byte, error) {
+	type plain Body
+	return marshalPreserving(plain(b), b.fields)
+}
+
+func (f *FormParam) UnmarshalJSON(data []byte) error {
+	type plain FormParam
+	return unmarshalPreserving(data, (*plain)(f), &f.fields)
+}
+
+func (f FormParam) MarshalJSON() ([]byte, error) {
+	type plain FormParam
+	return marshalPreserving(plain(f), f.fields)
+}
+
+func (u *URL) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err == nil {
+		*u = URL{Raw: raw, literal: true}
+		return nil
+	}
+	type plain URL
+	return unmarshalPreserving(data, (*plain)(u), &u.fields)
+}
+
+func (u URL) MarshalJSON() ([]byte, error) {
+	if u.literal && len(u.Host) == 0 && len(u.Path) == 0 {
+		return encodeJSON(u.Raw)
+	}
+	type plain URL
+	return marshalPreserving(plain(u), u.fields)
+}
+
+func (e *Environment) UnmarshalJSON(data []byte) error {
+	type plain Environment
+	return unmarshalPreserving(data, (*plain)(e), &e.fields)
+}
+
+func (e Environment) MarshalJSON() ([]byte, error) {
+	type plain Environment
+	return marshalPreserving(plain(e), e.fields)
+}
+
+func (e *EnvVariable) UnmarshalJSON(data []byte) error {
+	type plain EnvVariable
+	return unmarshalPreserving(data, (*plain)(e), &e.fields)
+}
+
+func (e EnvVariable) MarshalJSON() ([]byte, error) {
+	type plain EnvVariable
+	return marshalPreserving(plain(e), e.fields)
+}
+
+func (v *Variable) UnmarshalJSON(data []byte) error {
+	type plain Variable
+	return unmarshalPreserving(data, (*plain)(v), &v.fields)
+}
+
+func (v Variable) MarshalJSON() ([]byte, error) {
+	type plain Variable
+	return marshalPreserving(plain(v), v.fields)
+}
+
+func (e *Event) UnmarshalJSON(data []byte) error {
+	type plain Event
+	return unmarshalPreserving(data, (*plain)(e), &e.fields)
+}
+
+func (e Event) MarshalJSON() ([]byte, error) {
+	type plain Event
+	return marshalPreserving(plain(e), e.fields)
+}
+
+func (s *Script) UnmarshalJSON(data []byte) error {
+	type plain Script
+	return unmarshalPreserving(data, (*plain)(s), &s.fields)
+}
+
+func (s Script) MarshalJSON() ([]byte, error) {
+	type plain Script
+	return marshalPreserving(plain(s), s.fields)
 }
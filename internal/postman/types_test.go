@@ -30,6 +30,11 @@ func TestItem_IsFolder(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name:     "Folder created empty",
+			item:     NewFolder("Empty Folder"),
+			expected: true,
+		},
 		{
 			name: "Request item",
 			item: Item{
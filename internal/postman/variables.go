@@ -2,6 +2,7 @@ package postman
 
 import (
 	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -67,6 +68,29 @@ func (p *Parser) GetAllVariables(collection *Collection, breadcrumb []string, en
 	return variables
 }
 
+func WithIterationData(variables []VariableSource, data map[string]string) []VariableSource {
+	if len(data) == 0 {
+		return variables
+	}
+
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make([]VariableSource, 0, len(keys)+len(variables))
+	for _, key := range keys {
+		result = append(result, VariableSource{Key: key, Value: data[key], Source: "Iteration data"})
+	}
+	for _, v := range variables {
+		if _, overridden := data[v.Key]; !overridden {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
 func ResolveVariables(text string, variables []VariableSource) string {
 	variableMap := make(map[string]string)
 	for _, v := range variables {
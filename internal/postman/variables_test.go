@@ -566,3 +566,28 @@ func TestResolveVariables_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestWithIterationData_TakesPrecedence(t *testing.T) {
+	variables := []VariableSource{
+		{Key: "user", Value: "env-user", Source: "Environment: Dev"},
+		{Key: "baseUrl", Value: "https://api.example.com", Source: "Collection: API"},
+	}
+
+	result := WithIterationData(variables, map[string]string{"user": "alice", "id": "7"})
+
+	if len(result) != 3 {
+		t.Fatalf("Expected 3 variables, got %d: %+v", len(result), result)
+	}
+	if result[0].Source != "Iteration data" || result[1].Source != "Iteration data" {
+		t.Errorf("Expected iteration data first, got %+v", result)
+	}
+
+	text := ResolveVariables("{{baseUrl}}/users/{{id}}?name={{user}}", result)
+	if text != "https://api.example.com/users/7?name=alice" {
+		t.Errorf("Expected iteration data to win, got %s", text)
+	}
+
+	if unchanged := WithIterationData(variables, nil); len(unchanged) != len(variables) {
+		t.Errorf("Expected variables unchanged without data, got %+v", unchanged)
+	}
+}
@@ -0,0 +1,96 @@
+package runner
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func LoadData(path string) ([]map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read data file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return parseCSVData(data)
+	case ".json":
+		return parseJSONData(data)
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		return parseJSONData(data)
+	}
+	return parseCSVData(data)
+}
+
+func parseCSVData(data []byte) ([]map[string]string, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV data file: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("CSV data file has no header row")
+	}
+
+	header := records[0]
+	var rows []map[string]string
+	for i, record := range records[1:] {
+		if len(record) > len(header) {
+			return nil, fmt.Errorf("CSV data file row %d has %d values but the header has %d columns", i+2, len(record), len(header))
+		}
+		row := make(map[string]string, len(header))
+		for j, key := range header {
+			if j < len(record) {
+				row[strings.TrimSpace(key)] = record[j]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func parseJSONData(data []byte) ([]map[string]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var objects []map[string]interface{}
+	if err := decoder.Decode(&objects); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON data file (expected an array of objects): %w", err)
+	}
+
+	rows := make([]map[string]string, 0, len(objects))
+	for _, object := range objects {
+		row := make(map[string]string, len(object))
+		for key, value := range object {
+			row[key] = dataValueString(value)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func dataValueString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number, bool:
+		return fmt.Sprint(v)
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
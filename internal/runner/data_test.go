@@ -0,0 +1,76 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeDataFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write data file: %v", err)
+	}
+	return path
+}
+
+func TestLoadData(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		expected []map[string]string
+	}{
+		{
+			name:    "csv",
+			file:    "users.csv",
+			content: "\xef\xbb\xbfuser,id\nalice,1\n\"bob, jr\",2\n",
+			expected: []map[string]string{
+				{"user": "alice", "id": "1"},
+				{"user": "bob, jr", "id": "2"},
+			},
+		},
+		{
+			name:    "json",
+			file:    "users.json",
+			content: `[{"user":"alice","id":1,"admin":true,"tags":["a"]},{"user":"bob","id":2.5,"note":null}]`,
+			expected: []map[string]string{
+				{"user": "alice", "id": "1", "admin": "true", "tags": `["a"]`},
+				{"user": "bob", "id": "2.5", "note": ""},
+			},
+		},
+		{
+			name:     "sniffed json",
+			file:     "users.data",
+			content:  `[{"user":"alice"}]`,
+			expected: []map[string]string{{"user": "alice"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := LoadData(writeDataFile(t, tt.file, tt.content))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(rows, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, rows)
+			}
+		})
+	}
+}
+
+func TestLoadData_Errors(t *testing.T) {
+	for name, content := range map[string]string{
+		"bad.csv":    "user\nalice,extra\n",
+		"empty.csv":  "",
+		"bad.json":   `{"user":"alice"}`,
+		"trunc.json": `[{"user":`,
+	} {
+		if _, err := LoadData(writeDataFile(t, name, content)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package runner
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"time"
+)
+
+type htmlTest struct {
+	Name   string
+	Passed bool
+	Error  string
+}
+
+type htmlRequest struct {
+	Name     string
+	Method   string
+	URL      string
+	Status   string
+	Duration string
+	Failed   bool
+	Error    string
+	Tests    []htmlTest
+	Errors   []string
+}
+
+type htmlIteration struct {
+	Number   int
+	Data     string
+	Failed   bool
+	Requests []htmlRequest
+}
+
+type htmlReport struct {
+	Collection string
+	Started    string
+	Duration   string
+	Summary    Summary
+	Iterations []htmlIteration
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Collection}} - run report</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; margin: 2rem; color: #222; }
+h1 { margin-bottom: 0.2rem; }
+.meta { color: #666; margin-bottom: 1.5rem; }
+.summary { display: flex; gap: 1rem; margin-bottom: 2rem; }
+.card { border: 1px solid #ddd; border-radius: 6px; padding: 0.8rem 1.2rem; min-width: 8rem; }
+.card .value { font-size: 1.6rem; font-weight: bold; }
+.failed { color: #c62828; }
+.passed { color: #2e7d32; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 1.5rem; }
+th, td { text-align: left; padding: 0.4rem 0.6rem; border-bottom: 1px solid #eee; vertical-align: top; }
+th { background: #f6f6f6; }
+tr.failed-row { background: #fff3f3; }
+.data { font-family: monospace; color: #555; }
+ul { margin: 0; padding-left: 1.2rem; }
+code { word-break: break-all; }
+</style>
+</head>
+<body>
+<h1>{{.Collection}}</h1>
+<div class="meta">Started {{.Started}} &middot; {{.Duration}}</div>
+<div class="summary">
+  <div class="card"><div>Iterations</div><div class="value">{{.Summary.Iterations}}</div></div>
+  <div class="card"><div>Requests</div><div class="value">{{.Summary.Requests}}</div>{{if .Summary.FailedRequests}}<div class="failed">{{.Summary.FailedRequests}} failed</div>{{end}}</div>
+  <div class="card"><div>Tests</div><div class="value">{{.Summary.Tests}}</div>{{if .Summary.FailedTests}}<div class="failed">{{.Summary.FailedTests}} failed</div>{{else}}<div class="passed">all passed</div>{{end}}</div>
+</div>
+{{range .Iterations}}
+<h2 class="{{if .Failed}}failed{{end}}">Iteration {{.Number}}</h2>
+{{if .Data}}<div class="data">{{.Data}}</div>{{end}}
+<table>
+  <tr><th>Request</th><th>Status</th><th>Duration</th><th>Tests</th></tr>
+  {{range .Requests}}
+  <tr class="{{if .Failed}}failed-row{{end}}">
+    <td><strong>{{.Method}}</strong> {{.Name}}<br><code>{{.URL}}</code></td>
+    <td>{{if .Error}}<span class="failed">{{.Error}}</span>{{else}}{{.Status}}{{end}}</td>
+    <td>{{.Duration}}</td>
+    <td>
+      <ul>
+      {{range .Tests}}<li class="{{if .Passed}}passed{{else}}failed{{end}}">{{if .Passed}}&#10003;{{else}}&#10007;{{end}} {{.Name}}{{if .Error}}: {{.Error}}{{end}}</li>{{end}}
+      {{range .Errors}}<li class="failed">{{.}}</li>{{end}}
+      </ul>
+    </td>
+  </tr>
+  {{end}}
+</table>
+{{end}}
+</body>
+</html>
+`))
+
+func buildHTMLRequest(request RequestResult) htmlRequest {
+	resp := request.Response
+	row := htmlRequest{
+		Name:     request.Target.Name(),
+		Method:   request.Target.Item.Request.Method,
+		URL:      resp.RequestURL,
+		Status:   resp.Status,
+		Duration: resp.Duration.Round(time.Millisecond).String(),
+		Failed:   request.Failed(),
+	}
+	if resp.Error != nil {
+		row.Error = resp.Error.Error()
+	}
+	if request.TestResult != nil {
+		for _, test := range request.TestResult.Tests {
+			row.Tests = append(row.Tests, htmlTest{Name: test.Name, Passed: test.Passed, Error: test.Error})
+		}
+		row.Errors = request.TestResult.Errors
+	}
+	return row
+}
+
+func writeHTMLReport(w io.Writer, result *Result) error {
+	report := htmlReport{
+		Collection: result.Collection,
+		Started:    result.StartedAt.Format(time.RFC1123),
+		Duration:   result.Duration.Round(time.Millisecond).String(),
+		Summary:    result.Summary(),
+	}
+	for _, iteration := range result.Iterations {
+		htmlIter := htmlIteration{
+			Number: iteration.Index + 1,
+			Data:   FormatData(iteration.Data),
+			Failed: iteration.Failed(),
+		}
+		for _, request := range iteration.Requests {
+			htmlIter.Requests = append(htmlIter.Requests, buildHTMLRequest(request))
+		}
+		report.Iterations = append(report.Iterations, htmlIter)
+	}
+
+	if err := htmlReportTemplate.Execute(w, report); err != nil {
+		return fmt.Errorf("failed to write HTML report: %w", err)
+	}
+	return nil
+}
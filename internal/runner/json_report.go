@@ -0,0 +1,266 @@
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+type newmanReport struct {
+	Collection newmanCollection `json:"collection"`
+	Run        newmanRun        `json:"run"`
+}
+
+type newmanCollection struct {
+	Info newmanInfo `json:"info"`
+}
+
+type newmanInfo struct {
+	Name string `json:"name"`
+}
+
+type newmanRun struct {
+	Stats      newmanStats       `json:"stats"`
+	Timings    newmanTimings     `json:"timings"`
+	Executions []newmanExecution `json:"executions"`
+	Failures   []newmanFailure   `json:"failures"`
+}
+
+type newmanStat struct {
+	Total   int `json:"total"`
+	Pending int `json:"pending"`
+	Failed  int `json:"failed"`
+}
+
+type newmanStats struct {
+	Iterations  newmanStat `json:"iterations"`
+	Items       newmanStat `json:"items"`
+	Requests    newmanStat `json:"requests"`
+	TestScripts newmanStat `json:"testScripts"`
+	Assertions  newmanStat `json:"assertions"`
+}
+
+type newmanTimings struct {
+	Started         int64   `json:"started"`
+	Completed       int64   `json:"completed"`
+	ResponseAverage float64 `json:"responseAverage"`
+	ResponseMin     float64 `json:"responseMin"`
+	ResponseMax     float64 `json:"responseMax"`
+}
+
+type newmanCursor struct {
+	Iteration int `json:"iteration"`
+	Position  int `json:"position"`
+}
+
+type newmanHeader struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type newmanRequest struct {
+	Method string         `json:"method"`
+	URL    string         `json:"url"`
+	Header []newmanHeader `json:"header"`
+}
+
+type newmanResponse struct {
+	Code         int            `json:"code"`
+	Status       string         `json:"status"`
+	ResponseTime int64          `json:"responseTime"`
+	ResponseSize int64          `json:"responseSize"`
+	Header       []newmanHeader `json:"header"`
+}
+
+type newmanError struct {
+	Name    string `json:"name"`
+	Message string `json:"message"`
+	Test    string `json:"test,omitempty"`
+}
+
+type newmanAssertion struct {
+	Assertion string       `json:"assertion"`
+	Skipped   bool         `json:"skipped"`
+	Error     *newmanError `json:"error,omitempty"`
+}
+
+type newmanItem struct {
+	Name string `json:"name"`
+}
+
+type newmanExecution struct {
+	Cursor        newmanCursor      `json:"cursor"`
+	Item          newmanItem        `json:"item"`
+	IterationData map[string]string `json:"iterationData,omitempty"`
+	Request       newmanRequest     `json:"request"`
+	Response      *newmanResponse   `json:"response,omitempty"`
+	RequestError  *newmanError      `json:"requestError,omitempty"`
+	Assertions    []newmanAssertion `json:"assertions"`
+}
+
+type newmanFailure struct {
+	Error  newmanError  `json:"error"`
+	At     string       `json:"at"`
+	Source newmanItem   `json:"source"`
+	Cursor newmanCursor `json:"cursor"`
+}
+
+func newmanHeaders(headers map[string][]string) []newmanHeader {
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := []newmanHeader{}
+	for _, key := range keys {
+		for _, value := range headers[key] {
+			result = append(result, newmanHeader{Key: key, Value: value})
+		}
+	}
+	return result
+}
+
+func buildNewmanExecution(request RequestResult, position int) (newmanExecution, []newmanFailure) {
+	cursor := newmanCursor{Iteration: request.Iteration, Position: position}
+	source := newmanItem{Name: request.Target.Name()}
+	resp := request.Response
+
+	requestHeaders := make(map[string][]string, len(resp.RequestHeaders))
+	for key, value := range resp.RequestHeaders {
+		requestHeaders[key] = []string{value}
+	}
+	method, url := resp.RequestMethod, resp.RequestURL
+	if method == "" {
+		method, url = request.Target.Item.Request.Method, request.Target.Item.Request.URL.Raw
+	}
+
+	execution := newmanExecution{
+		Cursor:        cursor,
+		Item:          source,
+		IterationData: request.Data,
+		Request:       newmanRequest{Method: method, URL: url, Header: newmanHeaders(requestHeaders)},
+		Assertions:    []newmanAssertion{},
+	}
+
+	var failures []newmanFailure
+	if resp.Error != nil {
+		execution.RequestError = &newmanError{Name: "Error", Message: resp.Error.Error()}
+		failures = append(failures, newmanFailure{Error: *execution.RequestError, At: "request", Source: source, Cursor: cursor})
+	} else {
+		execution.Response = &newmanResponse{
+			Code:         resp.StatusCode,
+			Status:       statusText(resp.Status),
+			ResponseTime: resp.Duration.Milliseconds(),
+			ResponseSize: resp.Size(),
+			Header:       newmanHeaders(resp.Headers),
+		}
+	}
+
+	if request.TestResult == nil {
+		return execution, failures
+	}
+	for i, test := range request.TestResult.Tests {
+		assertion := newmanAssertion{Assertion: test.Name}
+		if !test.Passed {
+			assertion.Error = &newmanError{Name: "AssertionError", Message: test.Error, Test: test.Name}
+			failures = append(failures, newmanFailure{
+				Error:  *assertion.Error,
+				At:     fmt.Sprintf("assertion:%d in test-script", i),
+				Source: source,
+				Cursor: cursor,
+			})
+		}
+		execution.Assertions = append(execution.Assertions, assertion)
+	}
+	for _, scriptErr := range request.TestResult.Errors {
+		failures = append(failures, newmanFailure{
+			Error:  newmanError{Name: "ScriptError", Message: scriptErr},
+			At:     "test-script",
+			Source: source,
+			Cursor: cursor,
+		})
+	}
+	return execution, failures
+}
+
+func statusText(status string) string {
+	for i, r := range status {
+		if r == ' ' {
+			return status[i+1:]
+		}
+	}
+	return status
+}
+
+func writeJSONReport(w io.Writer, result *Result) error {
+	report := newmanReport{
+		Collection: newmanCollection{Info: newmanInfo{Name: result.Collection}},
+		Run: newmanRun{
+			Timings: newmanTimings{
+				Started:   result.StartedAt.UnixMilli(),
+				Completed: result.StartedAt.Add(result.Duration).UnixMilli(),
+			},
+			Executions: []newmanExecution{},
+			Failures:   []newmanFailure{},
+		},
+	}
+
+	stats := &report.Run.Stats
+	stats.Iterations.Total = len(result.Iterations)
+	var totalTime time.Duration
+	responses := 0
+	for _, iteration := range result.Iterations {
+		if iteration.Failed() {
+			stats.Iterations.Failed++
+		}
+		for position, request := range iteration.Requests {
+			execution, failures := buildNewmanExecution(request, position)
+			report.Run.Executions = append(report.Run.Executions, execution)
+			report.Run.Failures = append(report.Run.Failures, failures...)
+
+			stats.Items.Total++
+			stats.Requests.Total++
+			if request.Failed() {
+				stats.Items.Failed++
+			}
+			if request.Response.Error != nil {
+				stats.Requests.Failed++
+			} else {
+				duration := request.Response.Duration
+				ms := float64(duration.Microseconds()) / 1000
+				if responses == 0 || ms < report.Run.Timings.ResponseMin {
+					report.Run.Timings.ResponseMin = ms
+				}
+				report.Run.Timings.ResponseMax = max(report.Run.Timings.ResponseMax, ms)
+				totalTime += duration
+				responses++
+			}
+
+			if request.TestResult != nil {
+				stats.TestScripts.Total++
+				if len(request.TestResult.Errors) > 0 {
+					stats.TestScripts.Failed++
+				}
+				for _, test := range request.TestResult.Tests {
+					stats.Assertions.Total++
+					if !test.Passed {
+						stats.Assertions.Failed++
+					}
+				}
+			}
+		}
+	}
+	if responses > 0 {
+		report.Run.Timings.ResponseAverage = float64(totalTime.Microseconds()) / 1000 / float64(responses)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to write JSON report: %w", err)
+	}
+	return nil
+}
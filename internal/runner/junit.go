@@ -0,0 +1,129 @@
+package runner
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Time       string          `xml:"time,attr"`
+	Timestamp  string          `xml:"timestamp,attr,omitempty"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Cases      []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+func buildJUnitSuite(request RequestResult, iterations int) junitTestSuite {
+	className := strings.Join(append(append([]string{}, request.Target.Path...), request.Target.Item.Name), ".")
+	suite := junitTestSuite{
+		Name: request.suiteName(iterations),
+		Time: junitSeconds(request.Response.Duration),
+	}
+	if !request.Response.StartedAt.IsZero() {
+		suite.Timestamp = request.Response.StartedAt.UTC().Format("2006-01-02T15:04:05")
+	}
+	if len(request.Data) > 0 {
+		suite.Properties = append(suite.Properties, junitProperty{Name: "iterationData", Value: FormatData(request.Data)})
+	}
+
+	if request.Response.Error != nil {
+		suite.Errors++
+		suite.Cases = append(suite.Cases, junitTestCase{
+			Name:      "request",
+			ClassName: className,
+			Time:      suite.Time,
+			Error:     &junitProblem{Message: request.Response.Error.Error(), Type: "RequestError", Text: request.Response.Error.Error()},
+		})
+	}
+
+	if request.TestResult == nil {
+		suite.Tests = len(suite.Cases)
+		return suite
+	}
+
+	for _, test := range request.TestResult.Tests {
+		testCase := junitTestCase{Name: test.Name, ClassName: className, Time: junitSeconds(0)}
+		if !test.Passed {
+			suite.Failures++
+			testCase.Failure = &junitProblem{Message: test.Error, Type: "AssertionFailure", Text: test.Error}
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+	for _, scriptErr := range request.TestResult.Errors {
+		suite.Errors++
+		suite.Cases = append(suite.Cases, junitTestCase{
+			Name:      "test script",
+			ClassName: className,
+			Time:      junitSeconds(0),
+			Error:     &junitProblem{Message: scriptErr, Type: "ScriptError", Text: scriptErr},
+		})
+	}
+	suite.Tests = len(suite.Cases)
+	return suite
+}
+
+func writeJUnitReport(w io.Writer, result *Result) error {
+	report := junitTestSuites{
+		Name: result.Collection,
+		Time: junitSeconds(result.Duration),
+	}
+	for _, iteration := range result.Iterations {
+		for _, request := range iteration.Requests {
+			suite := buildJUnitSuite(request, len(result.Iterations))
+			report.Tests += suite.Tests
+			report.Failures += suite.Failures
+			report.Errors += suite.Errors
+			report.Suites = append(report.Suites, suite)
+		}
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
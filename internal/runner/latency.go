@@ -0,0 +1,97 @@
+package runner
+
+import (
+	"math"
+	"math/bits"
+	"time"
+)
+
+const (
+	latencyLinearBuckets = 64
+	latencySubBuckets    = 32
+	latencyMaxShift      = 35
+	latencyBucketCount   = latencyLinearBuckets + latencyMaxShift*latencySubBuckets
+)
+
+type LatencySummary struct {
+	Min  time.Duration
+	Mean time.Duration
+	P50  time.Duration
+	P90  time.Duration
+	P99  time.Duration
+	Max  time.Duration
+}
+
+type latencyHistogram struct {
+	counts [latencyBucketCount]int64
+	count  int64
+	sum    time.Duration
+	min    time.Duration
+	max    time.Duration
+}
+
+func latencyBucket(d time.Duration) int {
+	us := max(d.Microseconds(), 0)
+	if us < latencyLinearBuckets {
+		return int(us)
+	}
+	shift := bits.Len64(uint64(us)) - 6
+	if shift > latencyMaxShift {
+		return latencyBucketCount - 1
+	}
+	return latencyLinearBuckets + (shift-1)*latencySubBuckets + int(us>>shift) - latencySubBuckets
+}
+
+func latencyBucketValue(index int) time.Duration {
+	if index < latencyLinearBuckets {
+		return time.Duration(index) * time.Microsecond
+	}
+	shift := (index-latencyLinearBuckets)/latencySubBuckets + 1
+	sub := int64((index-latencyLinearBuckets)%latencySubBuckets + latencySubBuckets)
+	low := sub << shift
+	high := (sub+1)<<shift - 1
+	return time.Duration((low+high)/2) * time.Microsecond
+}
+
+func (h *latencyHistogram) record(d time.Duration) {
+	h.counts[latencyBucket(d)]++
+	if h.count == 0 || d < h.min {
+		h.min = d
+	}
+	if d > h.max {
+		h.max = d
+	}
+	h.count++
+	h.sum += d
+}
+
+func (h *latencyHistogram) percentile(q float64) time.Duration {
+	if h.count == 0 {
+		return 0
+	}
+	rank := int64(math.Ceil(q * float64(h.count)))
+	rank = min(max(rank, 1), h.count)
+
+	var seen int64
+	for index, count := range h.counts {
+		seen += count
+		if seen >= rank {
+			return min(max(latencyBucketValue(index), h.min), h.max)
+		}
+	}
+	return h.max
+}
+
+func (h *latencyHistogram) summary() LatencySummary {
+	if h.count == 0 {
+		return LatencySummary{}
+	}
+	return LatencySummary{
+		Min:  h.min,
+		Mean: h.sum / time.Duration(h.count),
+		P50:  h.percentile(0.50),
+		P90:  h.percentile(0.90),
+		P99:  h.percentile(0.99),
+		Max:  h.max,
+	}
+}
@@ -0,0 +1,53 @@
+package runner
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatencyBucket_Contiguous(t *testing.T) {
+	previous := 0
+	for us := int64(1); us < 1<<24; us += max(us/97, 1) {
+		bucket := latencyBucket(time.Duration(us) * time.Microsecond)
+		if bucket != previous && bucket != previous+1 {
+			t.Fatalf("Bucket jumped from %d to %d at %dµs", previous, bucket, us)
+		}
+		previous = bucket
+	}
+	if bucket := latencyBucket(1000 * time.Hour); bucket != latencyBucketCount-1 {
+		t.Errorf("Expected huge latencies in the last bucket, got %d", bucket)
+	}
+}
+
+func TestLatencyHistogram_Summary(t *testing.T) {
+	var histogram latencyHistogram
+	if summary := histogram.summary(); summary != (LatencySummary{}) {
+		t.Errorf("Expected an empty summary, got %+v", summary)
+	}
+
+	for ms := 1; ms <= 1000; ms++ {
+		histogram.record(time.Duration(ms) * time.Millisecond)
+	}
+
+	summary := histogram.summary()
+	if summary.Min != time.Millisecond || summary.Max != time.Second {
+		t.Errorf("Expected exact min and max, got %v and %v", summary.Min, summary.Max)
+	}
+	if summary.Mean != 500500*time.Microsecond {
+		t.Errorf("Expected exact mean, got %v", summary.Mean)
+	}
+
+	for _, check := range []struct {
+		name     string
+		got      time.Duration
+		expected time.Duration
+	}{
+		{"p50", summary.P50, 500 * time.Millisecond},
+		{"p90", summary.P90, 900 * time.Millisecond},
+		{"p99", summary.P99, 990 * time.Millisecond},
+	} {
+		if diff := check.got - check.expected; diff < -check.expected/30 || diff > check.expected/30 {
+			t.Errorf("%s: expected about %v, got %v", check.name, check.expected, check.got)
+		}
+	}
+}
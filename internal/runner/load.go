@@ -0,0 +1,426 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"postOffice/internal/http"
+	"postOffice/internal/postman"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const DefaultLoadDuration = 30 * time.Second
+
+const loadThroughputWindow = 60
+
+type LoadOptions struct {
+	VUs        int
+	Duration   time.Duration
+	Iterations int
+	RampUp     time.Duration
+	RPS        float64
+}
+
+type StatusCount struct {
+	Code  int
+	Count int
+}
+
+type ErrorCount struct {
+	Message string
+	Count   int
+}
+
+type TargetStats struct {
+	Name     string
+	Method   string
+	Requests int
+	Failures int
+	Latency  LatencySummary
+}
+
+type LoadSnapshot struct {
+	Name        string
+	Options     LoadOptions
+	Elapsed     time.Duration
+	ActiveVUs   int
+	Iterations  int
+	Requests    int
+	Errors      int
+	HTTPErrors  int
+	FailedTests int
+	Latency     LatencySummary
+	RPS         float64
+	CurrentRPS  float64
+	Throughput  []int
+	Statuses    []StatusCount
+	TopErrors   []ErrorCount
+	Targets     []TargetStats
+	Done        bool
+	Stopped     bool
+}
+
+func (s LoadSnapshot) Failed() int {
+	return s.Errors + s.HTTPErrors
+}
+
+func (s LoadSnapshot) ErrorRate() float64 {
+	if s.Requests == 0 {
+		return 0
+	}
+	return float64(s.Failed()) / float64(s.Requests)
+}
+
+func (s LoadSnapshot) Progress() float64 {
+	if s.Done {
+		return 1
+	}
+	progress := 0.0
+	if s.Options.Duration > 0 {
+		progress = float64(s.Elapsed) / float64(s.Options.Duration)
+	}
+	if s.Options.Iterations > 0 {
+		progress = max(progress, float64(s.Iterations)/float64(s.Options.Iterations))
+	}
+	return min(progress, 1)
+}
+
+type targetCounters struct {
+	requests int
+	failures int
+	latency  latencyHistogram
+}
+
+type Load struct {
+	executor    *http.Executor
+	parser      *postman.Parser
+	collection  *postman.Collection
+	environment *postman.Environment
+	targets     []Target
+	options     LoadOptions
+	name        string
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	pacer   *pacer
+	started atomic.Int64
+	active  atomic.Int64
+	done    chan struct{}
+
+	mu          sync.Mutex
+	startedAt   time.Time
+	finishedAt  time.Time
+	stopped     bool
+	iterations  int
+	requests    int
+	errors      int
+	httpErrors  int
+	failedTests int
+	latency     latencyHistogram
+	statuses    map[int]int
+	errorCounts map[string]int
+	perTarget   []targetCounters
+	perSecond   []int
+}
+
+func NewLoad(
+	executor *http.Executor,
+	parser *postman.Parser,
+	collection *postman.Collection,
+	environment *postman.Environment,
+	name string,
+	targets []Target,
+	options LoadOptions,
+) *Load {
+	options.VUs = max(options.VUs, 1)
+	if options.Duration <= 0 && options.Iterations <= 0 {
+		options.Duration = DefaultLoadDuration
+	}
+
+	scopes := postman.SnapshotVariableScopes(collection, environment)
+	return &Load{
+		executor:    executor,
+		parser:      parser,
+		collection:  scopes.Collection,
+		environment: scopes.Environment,
+		targets:     targets,
+		options:     options,
+		name:        name,
+		pacer:       newPacer(options.RPS),
+		done:        make(chan struct{}),
+		statuses:    make(map[int]int),
+		errorCounts: make(map[string]int),
+		perTarget:   make([]targetCounters, len(targets)),
+	}
+}
+
+func (l *Load) Options() LoadOptions {
+	return l.options
+}
+
+func (l *Load) Start() {
+	if l.options.Duration > 0 {
+		l.ctx, l.cancel = context.WithTimeout(context.Background(), l.options.Duration)
+	} else {
+		l.ctx, l.cancel = context.WithCancel(context.Background())
+	}
+
+	l.mu.Lock()
+	l.startedAt = time.Now()
+	l.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for vu := 0; vu < l.options.VUs; vu++ {
+		wg.Add(1)
+		go func(delay time.Duration) {
+			defer wg.Done()
+			l.runVU(delay)
+		}(l.options.RampUp * time.Duration(vu) / time.Duration(l.options.VUs))
+	}
+
+	go func() {
+		wg.Wait()
+		l.cancel()
+		l.mu.Lock()
+		l.finishedAt = time.Now()
+		l.mu.Unlock()
+		close(l.done)
+	}()
+}
+
+func (l *Load) Stop() {
+	l.mu.Lock()
+	l.stopped = true
+	l.mu.Unlock()
+	if l.cancel != nil {
+		l.cancel()
+	}
+}
+
+func (l *Load) Wait() LoadSnapshot {
+	<-l.done
+	return l.Snapshot()
+}
+
+func (l *Load) Finished() <-chan struct{} {
+	return l.done
+}
+
+func (l *Load) Done() bool {
+	select {
+	case <-l.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (l *Load) runVU(delay time.Duration) {
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-l.ctx.Done():
+			return
+		case <-timer.C:
+		}
+	}
+
+	l.active.Add(1)
+	defer l.active.Add(-1)
+
+	scopes := postman.SnapshotVariableScopes(l.collection, l.environment)
+	collection, environment := scopes.Collection, scopes.Environment
+	for l.ctx.Err() == nil {
+		if l.options.Iterations > 0 && l.started.Add(1) > int64(l.options.Iterations) {
+			return
+		}
+
+		for index, target := range l.targets {
+			if !l.pacer.wait(l.ctx) {
+				return
+			}
+			item := target.Item
+			variables := l.parser.GetAllVariables(collection, target.Path, environment)
+			response, testResult := l.executor.ExecuteContext(l.ctx, item.Request, &item, collection, environment, variables)
+			response.RemoveBodyFile()
+			if response.Error != nil && l.ctx.Err() != nil {
+				return
+			}
+			l.record(index, RequestResult{Target: target, Response: response, TestResult: testResult})
+		}
+
+		l.mu.Lock()
+		l.iterations++
+		l.mu.Unlock()
+	}
+}
+
+func (l *Load) record(index int, result RequestResult) {
+	response := result.Response
+	finished := response.StartedAt.Add(response.Duration)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.requests++
+	counters := &l.perTarget[index]
+	counters.requests++
+
+	second := max(int(finished.Sub(l.startedAt)/time.Second), 0)
+	for len(l.perSecond) <= second {
+		l.perSecond = append(l.perSecond, 0)
+	}
+	l.perSecond[second]++
+
+	if response.Error != nil {
+		l.errors++
+		l.errorCounts[response.Error.Error()]++
+		counters.failures++
+		return
+	}
+
+	l.latency.record(response.Duration)
+	counters.latency.record(response.Duration)
+	l.statuses[response.StatusCode]++
+	if response.StatusCode >= 400 {
+		l.httpErrors++
+		counters.failures++
+	}
+	if result.TestResult != nil && result.TestResult.HasFailures() {
+		l.failedTests++
+	}
+}
+
+func (l *Load) Snapshot() LoadSnapshot {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	end := time.Now()
+	done := !l.finishedAt.IsZero()
+	if done {
+		end = l.finishedAt
+	}
+	var elapsed time.Duration
+	if !l.startedAt.IsZero() {
+		elapsed = end.Sub(l.startedAt)
+	}
+
+	snapshot := LoadSnapshot{
+		Name:        l.name,
+		Options:     l.options,
+		Elapsed:     elapsed,
+		ActiveVUs:   int(l.active.Load()),
+		Iterations:  l.iterations,
+		Requests:    l.requests,
+		Errors:      l.errors,
+		HTTPErrors:  l.httpErrors,
+		FailedTests: l.failedTests,
+		Latency:     l.latency.summary(),
+		Done:        done,
+		Stopped:     l.stopped,
+	}
+	if elapsed > 0 {
+		snapshot.RPS = float64(l.requests) / elapsed.Seconds()
+	}
+
+	current := int(elapsed / time.Second)
+	if done {
+		current = len(l.perSecond)
+	}
+	if current > 0 && current <= len(l.perSecond) {
+		snapshot.CurrentRPS = float64(l.perSecond[current-1])
+	}
+	completed := l.perSecond[:min(current, len(l.perSecond))]
+	snapshot.Throughput = append([]int(nil), completed[max(len(completed)-loadThroughputWindow, 0):]...)
+
+	for code, count := range l.statuses {
+		snapshot.Statuses = append(snapshot.Statuses, StatusCount{Code: code, Count: count})
+	}
+	sort.Slice(snapshot.Statuses, func(i, j int) bool {
+		return snapshot.Statuses[i].Code < snapshot.Statuses[j].Code
+	})
+
+	for message, count := range l.errorCounts {
+		snapshot.TopErrors = append(snapshot.TopErrors, ErrorCount{Message: message, Count: count})
+	}
+	sort.Slice(snapshot.TopErrors, func(i, j int) bool {
+		if snapshot.TopErrors[i].Count != snapshot.TopErrors[j].Count {
+			return snapshot.TopErrors[i].Count > snapshot.TopErrors[j].Count
+		}
+		return snapshot.TopErrors[i].Message < snapshot.TopErrors[j].Message
+	})
+	snapshot.TopErrors = snapshot.TopErrors[:min(len(snapshot.TopErrors), 5)]
+
+	for i, target := range l.targets {
+		counters := &l.perTarget[i]
+		snapshot.Targets = append(snapshot.Targets, TargetStats{
+			Name:     target.Name(),
+			Method:   target.Item.Request.Method,
+			Requests: counters.requests,
+			Failures: counters.failures,
+			Latency:  counters.latency.summary(),
+		})
+	}
+	return snapshot
+}
+
+type pacer struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newPacer(rps float64) *pacer {
+	if rps <= 0 {
+		return nil
+	}
+	return &pacer{interval: time.Duration(float64(time.Second) / rps)}
+}
+
+func (p *pacer) wait(ctx context.Context) bool {
+	if p == nil {
+		return ctx.Err() == nil
+	}
+
+	p.mu.Lock()
+	now := time.Now()
+	if p.next.Before(now) {
+		p.next = now
+	}
+	slot := p.next
+	p.next = slot.Add(p.interval)
+	p.mu.Unlock()
+
+	delay := time.Until(slot)
+	if delay <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func (o LoadOptions) String() string {
+	description := fmt.Sprintf("%d VUs", o.VUs)
+	if o.Duration > 0 {
+		description += fmt.Sprintf(", %v", o.Duration)
+	}
+	if o.Iterations > 0 {
+		description += fmt.Sprintf(", %d iterations", o.Iterations)
+	}
+	if o.RampUp > 0 {
+		description += fmt.Sprintf(", ramp-up %v", o.RampUp)
+	}
+	if o.RPS > 0 {
+		description += fmt.Sprintf(", %g rps", o.RPS)
+	}
+	return description
+}
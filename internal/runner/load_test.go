@@ -0,0 +1,190 @@
+package runner
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"postOffice/internal/postman"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	httpclient "postOffice/internal/http"
+)
+
+func createLoadCollection(serverURL string) *postman.Collection {
+	return &postman.Collection{
+		Info:      postman.Info{Name: "Load Test"},
+		Variables: []postman.Variable{{Key: "counter", Value: "0"}},
+		Items: []postman.Item{
+			{
+				Name:    "Ok",
+				Request: &postman.Request{Method: "GET", URL: postman.URL{Raw: serverURL + "/ok"}},
+				Events: []postman.Event{{
+					Listen: "test",
+					Script: postman.Script{Exec: []string{
+						"pm.collectionVariables.set('counter', String(Number(pm.collectionVariables.get('counter')) + 1));",
+					}},
+				}},
+			},
+			{
+				Name:    "Broken",
+				Request: &postman.Request{Method: "POST", URL: postman.URL{Raw: serverURL + "/broken"}},
+			},
+		},
+	}
+}
+
+func createLoadServer(t *testing.T) (*httptest.Server, *atomic.Int64) {
+	t.Helper()
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestLoad_Iterations(t *testing.T) {
+	server, requests := createLoadServer(t)
+	collection := createLoadCollection(server.URL)
+
+	executor := httpclient.NewExecutor().WithTransport(httpclient.NewTunedTransport(4))
+	load := NewLoad(executor, postman.NewParser(), collection, nil, "Load Test", CollectTargets(collection.Items, nil), LoadOptions{VUs: 4, Iterations: 10})
+	if load.Options().Duration != 0 {
+		t.Errorf("Expected no default duration when iterations are set, got %v", load.Options().Duration)
+	}
+	load.Start()
+	snapshot := load.Wait()
+
+	if !snapshot.Done || snapshot.Stopped || snapshot.ActiveVUs != 0 {
+		t.Errorf("Expected a finished load test, got %+v", snapshot)
+	}
+	if snapshot.Iterations != 10 || snapshot.Requests != 20 || requests.Load() != 20 {
+		t.Fatalf("Expected 10 iterations of 2 requests, got %d iterations, %d requests (%d on the server)", snapshot.Iterations, snapshot.Requests, requests.Load())
+	}
+	if snapshot.Errors != 0 || snapshot.HTTPErrors != 10 || snapshot.ErrorRate() != 0.5 {
+		t.Errorf("Expected half of the requests to fail, got %d errors, %d HTTP errors", snapshot.Errors, snapshot.HTTPErrors)
+	}
+
+	expected := []StatusCount{{Code: 200, Count: 10}, {Code: 503, Count: 10}}
+	if len(snapshot.Statuses) != 2 || snapshot.Statuses[0] != expected[0] || snapshot.Statuses[1] != expected[1] {
+		t.Errorf("Unexpected status histogram: %+v", snapshot.Statuses)
+	}
+	if len(snapshot.Targets) != 2 || snapshot.Targets[0].Requests != 10 || snapshot.Targets[1].Failures != 10 {
+		t.Errorf("Unexpected per-request stats: %+v", snapshot.Targets)
+	}
+	if snapshot.Latency.P50 <= 0 || snapshot.Latency.P99 < snapshot.Latency.P50 || snapshot.Latency.Max < snapshot.Latency.P99 {
+		t.Errorf("Unexpected latency summary: %+v", snapshot.Latency)
+	}
+
+	if collection.Variables[0].Value != "0" {
+		t.Errorf("Expected script writes to stay private to each virtual user, got counter=%s", collection.Variables[0].Value)
+	}
+}
+
+func TestLoad_DurationAndRate(t *testing.T) {
+	server, _ := createLoadServer(t)
+	collection := createLoadCollection(server.URL)
+	targets := CollectTargets(collection.Items[:1], nil)
+
+	load := NewLoad(httpclient.NewExecutor(), postman.NewParser(), collection, nil, "Load Test", targets, LoadOptions{
+		VUs:      5,
+		Duration: 500 * time.Millisecond,
+		RampUp:   100 * time.Millisecond,
+		RPS:      40,
+	})
+	load.Start()
+	snapshot := load.Wait()
+
+	if snapshot.Elapsed < 500*time.Millisecond {
+		t.Errorf("Expected the load test to run for its duration, got %v", snapshot.Elapsed)
+	}
+	if snapshot.Requests < 10 || snapshot.Requests > 22 {
+		t.Errorf("Expected about 20 requests at 40 req/s for 500ms, got %d", snapshot.Requests)
+	}
+	if snapshot.Progress() != 1 {
+		t.Errorf("Expected a finished load test to report full progress, got %v", snapshot.Progress())
+	}
+}
+
+func TestLoad_Stop(t *testing.T) {
+	server, _ := createLoadServer(t)
+	collection := createLoadCollection(server.URL)
+
+	load := NewLoad(httpclient.NewExecutor(), postman.NewParser(), collection, nil, "Load Test", CollectTargets(collection.Items, nil), LoadOptions{VUs: 2})
+	if load.Options().Duration != DefaultLoadDuration || load.Options().VUs != 2 {
+		t.Errorf("Expected the default duration, got %+v", load.Options())
+	}
+	load.Start()
+	time.Sleep(50 * time.Millisecond)
+	load.Stop()
+
+	select {
+	case <-load.Finished():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the load test to stop")
+	}
+	snapshot := load.Snapshot()
+	if !snapshot.Stopped || snapshot.Elapsed > time.Second {
+		t.Errorf("Expected a stopped load test, got %+v", snapshot)
+	}
+
+	var buf bytes.Buffer
+	WriteLoadSummary(&buf, snapshot)
+	for _, expected := range []string{"Stopped load test of Load Test (2 VUs, 30s)", "Status codes:", "503", "GET Ok:", "POST Broken:"} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Expected %q in summary, got:\n%s", expected, buf.String())
+		}
+	}
+}
+
+func TestLoad_RequestErrors(t *testing.T) {
+	collection := &postman.Collection{
+		Info:  postman.Info{Name: "Unreachable"},
+		Items: []postman.Item{{Name: "Down", Request: &postman.Request{Method: "GET", URL: postman.URL{Raw: "http://127.0.0.1:1/down"}}}},
+	}
+
+	load := NewLoad(httpclient.NewExecutor(), postman.NewParser(), collection, nil, "Unreachable", CollectTargets(collection.Items, nil), LoadOptions{Iterations: 3})
+	load.Start()
+	snapshot := load.Wait()
+
+	if snapshot.Errors != 3 || snapshot.ErrorRate() != 1 || len(snapshot.Statuses) != 0 {
+		t.Errorf("Expected 3 request errors, got %+v", snapshot)
+	}
+	if len(snapshot.TopErrors) != 1 || snapshot.TopErrors[0].Count != 3 {
+		t.Errorf("Expected identical errors to be grouped, got %+v", snapshot.TopErrors)
+	}
+}
+
+func TestLoad_DurationCancelsInFlightRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	collection := &postman.Collection{
+		Info:  postman.Info{Name: "Slow"},
+		Items: []postman.Item{{Name: "Slow", Request: &postman.Request{Method: "GET", URL: postman.URL{Raw: server.URL}}}},
+	}
+	load := NewLoad(httpclient.NewExecutor(), postman.NewParser(), collection, nil, "Slow", CollectTargets(collection.Items, nil), LoadOptions{VUs: 2, Duration: 100 * time.Millisecond})
+	load.Start()
+
+	select {
+	case <-load.Finished():
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected in-flight requests to be cancelled when the duration ends")
+	}
+	if snapshot := load.Snapshot(); snapshot.Requests != 0 || snapshot.Errors != 0 {
+		t.Errorf("Expected cancelled requests not to be counted, got %+v", snapshot)
+	}
+}
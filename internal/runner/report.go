@@ -0,0 +1,63 @@
+package runner
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+var ReportFormats = []string{"junit", "json", "html"}
+
+func WriteReport(w io.Writer, format string, result *Result) error {
+	switch strings.ToLower(format) {
+	case "junit":
+		return writeJUnitReport(w, result)
+	case "json":
+		return writeJSONReport(w, result)
+	case "html":
+		return writeHTMLReport(w, result)
+	}
+	return unknownFormatError(format)
+}
+
+func unknownFormatError(format string) error {
+	return fmt.Errorf("unknown report format: %s (expected %s)", format, strings.Join(ReportFormats, ", "))
+}
+
+// WriteReportFile writes the report next to path and renames it into place,
+// so an existing file is only replaced by a complete report.
+func WriteReportFile(path, format string, result *Result) error {
+	if !slices.Contains(ReportFormats, strings.ToLower(format)) {
+		return unknownFormatError(format)
+	}
+
+	tempPath := path + ".tmp"
+	file, err := os.Create(tempPath)
+	if err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	if err := WriteReport(file, format, result); err != nil {
+		file.Close()
+		os.Remove(tempPath)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("failed to rename report: %w", err)
+	}
+	return nil
+}
+
+func (r RequestResult) suiteName(iterations int) string {
+	name := r.Target.Name()
+	if iterations > 1 {
+		name = fmt.Sprintf("%s (iteration %d)", name, r.Iteration+1)
+	}
+	return name
+}
@@ -0,0 +1,162 @@
+package runner
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"postOffice/internal/postman"
+	"strings"
+	"testing"
+
+	httpclient "postOffice/internal/http"
+)
+
+func createReportResult(t *testing.T) *Result {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/greet" {
+			w.Write([]byte("hello " + r.URL.Query().Get("user")))
+			return
+		}
+		w.Write([]byte("pong"))
+	}))
+	t.Cleanup(server.Close)
+
+	collection := createRunCollection(server.URL)
+	data := []map[string]string{
+		{"user": "alice", "status": "200"},
+		{"user": "<bob>", "status": "201"},
+	}
+	run := New(httpclient.NewExecutor(), postman.NewParser(), collection, nil, CollectTargets(collection.Items, nil), Options{Data: data})
+	return run.RunAll(nil)
+}
+
+func TestWriteReport_JUnit(t *testing.T) {
+	result := createReportResult(t)
+
+	var buf bytes.Buffer
+	if err := WriteReport(&buf, "junit", result); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var suites junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+		t.Fatalf("Expected valid XML, got %v\n%s", err, buf.String())
+	}
+	if suites.Name != "Run Test" || suites.Tests != 4 || suites.Failures != 1 || suites.Errors != 0 {
+		t.Errorf("Unexpected totals: %+v", suites)
+	}
+	if len(suites.Suites) != 4 {
+		t.Fatalf("Expected one suite per request per iteration, got %d", len(suites.Suites))
+	}
+
+	failing := suites.Suites[2]
+	if failing.Name != "Greet (iteration 2)" || failing.Failures != 1 || len(failing.Cases) != 2 {
+		t.Fatalf("Unexpected failing suite: %+v", failing)
+	}
+	if failing.Cases[1].Name != "status is ok" || failing.Cases[1].ClassName != "Greet" || failing.Cases[1].Failure == nil {
+		t.Fatalf("Expected the status test to fail, got %+v", failing.Cases[1])
+	}
+	if !strings.Contains(failing.Cases[1].Failure.Message, "201") {
+		t.Errorf("Expected the assertion message, got %q", failing.Cases[1].Failure.Message)
+	}
+	if len(failing.Properties) != 1 || !strings.Contains(failing.Properties[0].Value, "user=<bob>") {
+		t.Errorf("Expected iteration data as a property, got %+v", failing.Properties)
+	}
+	if suites.Suites[1].Name != "Folder / Ping (iteration 1)" || suites.Suites[3].Name != "Folder / Ping (iteration 2)" {
+		t.Errorf("Unexpected suite names: %q, %q", suites.Suites[1].Name, suites.Suites[3].Name)
+	}
+}
+
+func TestWriteReport_JSON(t *testing.T) {
+	result := createReportResult(t)
+
+	var buf bytes.Buffer
+	if err := WriteReport(&buf, "json", result); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var report newmanReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+	stats := report.Run.Stats
+	if report.Collection.Info.Name != "Run Test" || stats.Iterations.Total != 2 || stats.Requests.Total != 4 {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+	if stats.Assertions.Total != 4 || stats.Assertions.Failed != 1 {
+		t.Errorf("Unexpected assertion stats: %+v", stats.Assertions)
+	}
+	if len(report.Run.Executions) != 4 || report.Run.Executions[2].IterationData["user"] != "<bob>" {
+		t.Fatalf("Expected executions with iteration data, got %+v", report.Run.Executions)
+	}
+	if response := report.Run.Executions[1].Response; response == nil || response.Code != 200 {
+		t.Errorf("Expected the response to be recorded, got %+v", response)
+	}
+
+	if len(report.Run.Failures) != 1 {
+		t.Fatalf("Expected one failure, got %+v", report.Run.Failures)
+	}
+	failure := report.Run.Failures[0]
+	if failure.Source.Name != "Greet" || failure.Cursor.Iteration != 1 || failure.Error.Test != "status is ok" {
+		t.Errorf("Unexpected failure: %+v", failure)
+	}
+}
+
+func TestWriteReport_HTML(t *testing.T) {
+	result := createReportResult(t)
+
+	var buf bytes.Buffer
+	if err := WriteReport(&buf, "HTML", result); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	content := buf.String()
+	for _, expected := range []string{"<title>Run Test", "Iteration 2", "user=&lt;bob&gt;", "status is ok", "Folder / Ping"} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected %q in the HTML report", expected)
+		}
+	}
+	if strings.Contains(content, "<bob>") {
+		t.Error("Expected iteration data to be escaped")
+	}
+}
+
+func TestWriteReportFile(t *testing.T) {
+	result := createReportResult(t)
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "results.xml")
+	if err := WriteReportFile(path, "junit", result); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if data, err := os.ReadFile(path); err != nil || !bytes.HasPrefix(data, []byte(xml.Header)) {
+		t.Errorf("Expected an XML file, got %q (%v)", data, err)
+	}
+
+	missing := filepath.Join(dir, "results.txt")
+	if err := WriteReportFile(missing, "text", result); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+	if _, err := os.Stat(missing); !os.IsNotExist(err) {
+		t.Error("Expected no file to be left behind for an unknown format")
+	}
+
+	existing := filepath.Join(dir, "existing.xml")
+	if err := os.WriteFile(existing, []byte("keep"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteReportFile(existing, "pdf", result); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+	if data, err := os.ReadFile(existing); err != nil || string(data) != "keep" {
+		t.Errorf("Expected an existing file to be left untouched, got %q (%v)", data, err)
+	}
+	if _, err := os.Stat(existing + ".tmp"); !os.IsNotExist(err) {
+		t.Error("Expected no temp file to be left behind")
+	}
+}
@@ -0,0 +1,263 @@
+package runner
+
+import (
+	"fmt"
+	"postOffice/internal/http"
+	"postOffice/internal/postman"
+	"postOffice/internal/script"
+	"strings"
+	"time"
+)
+
+type Target struct {
+	Item postman.Item
+	Path []string
+}
+
+func (t Target) Name() string {
+	return strings.Join(append(append([]string{}, t.Path...), t.Item.Name), " / ")
+}
+
+type Options struct {
+	Iterations    int
+	Data          []map[string]string
+	Delay         time.Duration
+	StopOnFailure bool
+}
+
+type RequestResult struct {
+	Iteration       int
+	Data            map[string]string
+	Target          Target
+	Response        *http.Response
+	TestResult      *script.TestResult
+	VariableChanges postman.VariableChanges
+}
+
+func (r RequestResult) Failed() bool {
+	if r.Response != nil && r.Response.Error != nil {
+		return true
+	}
+	return r.TestResult != nil && r.TestResult.HasFailures()
+}
+
+type IterationResult struct {
+	Index    int
+	Data     map[string]string
+	Requests []RequestResult
+}
+
+func (it IterationResult) Failed() bool {
+	for _, request := range it.Requests {
+		if request.Failed() {
+			return true
+		}
+	}
+	return false
+}
+
+type Summary struct {
+	Iterations     int
+	Requests       int
+	FailedRequests int
+	Tests          int
+	FailedTests    int
+}
+
+type Result struct {
+	Collection string
+	StartedAt  time.Time
+	Duration   time.Duration
+	Iterations []IterationResult
+}
+
+func (r *Result) Summary() Summary {
+	summary := Summary{Iterations: len(r.Iterations)}
+	for _, iteration := range r.Iterations {
+		for _, request := range iteration.Requests {
+			summary.Requests++
+			if request.Failed() {
+				summary.FailedRequests++
+			}
+			if request.TestResult == nil {
+				continue
+			}
+			for _, test := range request.TestResult.Tests {
+				summary.Tests++
+				if !test.Passed {
+					summary.FailedTests++
+				}
+			}
+		}
+	}
+	return summary
+}
+
+type Run struct {
+	executor    *http.Executor
+	parser      *postman.Parser
+	collection  *postman.Collection
+	environment *postman.Environment
+	targets     []Target
+	options     Options
+
+	iteration int
+	next      int
+	Result    *Result
+}
+
+func CollectTargets(items []postman.Item, path []string) []Target {
+	var targets []Target
+	for _, item := range items {
+		if item.IsRequest() && item.Request != nil {
+			targets = append(targets, Target{Item: item, Path: append([]string{}, path...)})
+		} else if item.IsFolder() {
+			targets = append(targets, CollectTargets(item.Items, append(append([]string{}, path...), item.Name))...)
+		}
+	}
+	return targets
+}
+
+func FindFolder(collection *postman.Collection, path []string) ([]postman.Item, error) {
+	items := collection.Items
+	for _, name := range path {
+		found := false
+		for _, item := range items {
+			if item.Name == name && item.IsFolder() {
+				items = item.Items
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("folder not found: %s", strings.Join(path, "/"))
+		}
+	}
+	return items, nil
+}
+
+func FindTargets(collection *postman.Collection, path []string) ([]Target, error) {
+	if len(path) == 0 {
+		return CollectTargets(collection.Items, nil), nil
+	}
+
+	parent := path[:len(path)-1]
+	items, err := FindFolder(collection, parent)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if item.Name != path[len(path)-1] {
+			continue
+		}
+		if item.IsFolder() {
+			return CollectTargets(item.Items, path), nil
+		}
+		if item.IsRequest() && item.Request != nil {
+			return []Target{{Item: item, Path: append([]string{}, parent...)}}, nil
+		}
+	}
+	return nil, fmt.Errorf("folder or request not found: %s", strings.Join(path, "/"))
+}
+
+func New(
+	executor *http.Executor,
+	parser *postman.Parser,
+	collection *postman.Collection,
+	environment *postman.Environment,
+	targets []Target,
+	options Options,
+) *Run {
+	if options.Iterations <= 0 {
+		options.Iterations = max(len(options.Data), 1)
+	}
+
+	scopes := postman.SnapshotVariableScopes(collection, environment)
+	return &Run{
+		executor:    executor,
+		parser:      parser,
+		collection:  scopes.Collection,
+		environment: scopes.Environment,
+		targets:     targets,
+		options:     options,
+		Result: &Result{
+			Collection: collection.Info.Name,
+			StartedAt:  time.Now(),
+		},
+	}
+}
+
+func (r *Run) Total() int {
+	return r.options.Iterations * len(r.targets)
+}
+
+func (r *Run) Completed() int {
+	return r.iteration*len(r.targets) + r.next
+}
+
+func (r *Run) Done() bool {
+	return len(r.targets) == 0 || r.iteration >= r.options.Iterations
+}
+
+func (r *Run) iterationData(index int) map[string]string {
+	if len(r.options.Data) == 0 {
+		return nil
+	}
+	return r.options.Data[min(index, len(r.options.Data)-1)]
+}
+
+func (r *Run) Step() RequestResult {
+	if r.next == 0 {
+		r.Result.Iterations = append(r.Result.Iterations, IterationResult{
+			Index: r.iteration,
+			Data:  r.iterationData(r.iteration),
+		})
+	}
+
+	target := r.targets[r.next]
+	current := &r.Result.Iterations[len(r.Result.Iterations)-1]
+	item := target.Item
+
+	variables := r.parser.GetAllVariables(r.collection, target.Path, r.environment)
+	scopes := postman.SnapshotVariableScopes(r.collection, r.environment)
+	response, testResult, changes := r.executor.ExecuteInScopes(item.Request, &item, scopes, variables, current.Data)
+	changes.ApplyTo(r.collection, r.environment)
+
+	result := RequestResult{
+		Iteration:       r.iteration,
+		Data:            current.Data,
+		Target:          target,
+		Response:        response,
+		TestResult:      testResult,
+		VariableChanges: changes,
+	}
+	current.Requests = append(current.Requests, result)
+	r.Result.Duration = time.Since(r.Result.StartedAt)
+
+	r.next++
+	if r.next >= len(r.targets) {
+		r.next = 0
+		r.iteration++
+	}
+	return result
+}
+
+func (r *Run) Options() Options {
+	return r.options
+}
+
+func (r *Run) RunAll(onResult func(RequestResult)) *Result {
+	for !r.Done() {
+		result := r.Step()
+		if onResult != nil {
+			onResult(result)
+		}
+		if r.options.StopOnFailure && result.Failed() {
+			break
+		}
+		if r.options.Delay > 0 && !r.Done() {
+			time.Sleep(r.options.Delay)
+		}
+	}
+	return r.Result
+}
@@ -0,0 +1,212 @@
+package runner
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"postOffice/internal/postman"
+	"testing"
+
+	httpclient "postOffice/internal/http"
+)
+
+func createRunCollection(serverURL string) *postman.Collection {
+	testScript := []postman.Event{{
+		Listen: "test",
+		Script: postman.Script{
+			Type: "text/javascript",
+			Exec: []string{
+				"pm.test('greets the data user', () => {",
+				"    if (pm.response.text() !== 'hello ' + pm.iterationData.get('user')) {",
+				"        throw new Error('unexpected body ' + pm.response.text());",
+				"    }",
+				"});",
+				"pm.test('status is ok', () => { pm.response.to.have.status(Number(pm.iterationData.get('status'))); });",
+			},
+		},
+	}}
+
+	return &postman.Collection{
+		Info: postman.Info{Name: "Run Test"},
+		Items: []postman.Item{
+			{
+				Name:    "Greet",
+				Request: &postman.Request{Method: "GET", URL: postman.URL{Raw: serverURL + "/greet?user={{user}}"}},
+				Events:  testScript,
+			},
+			{
+				Name: "Folder",
+				Items: []postman.Item{
+					{Name: "Ping", Request: &postman.Request{Method: "GET", URL: postman.URL{Raw: serverURL + "/ping"}}},
+				},
+			},
+		},
+	}
+}
+
+func TestRun_DataDrivenIterations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/greet" {
+			w.Write([]byte("hello " + r.URL.Query().Get("user")))
+			return
+		}
+		w.Write([]byte("pong"))
+	}))
+	defer server.Close()
+
+	collection := createRunCollection(server.URL)
+	environment := &postman.Environment{
+		Name:   "Dev",
+		Values: []postman.EnvVariable{{Key: "user", Value: "env-user", Enabled: true}},
+	}
+	data := []map[string]string{
+		{"user": "alice", "status": "200"},
+		{"user": "bob", "status": "201"},
+	}
+
+	targets := CollectTargets(collection.Items, nil)
+	if len(targets) != 2 || targets[1].Name() != "Folder / Ping" {
+		t.Fatalf("Expected requests from nested folders, got %+v", targets)
+	}
+
+	run := New(httpclient.NewExecutor(), postman.NewParser(), collection, environment, targets, Options{Data: data})
+	if run.Total() != 4 {
+		t.Fatalf("Expected 2 iterations of 2 requests, got %d", run.Total())
+	}
+
+	var seen []RequestResult
+	result := run.RunAll(func(r RequestResult) { seen = append(seen, r) })
+	if len(seen) != 4 || !run.Done() || run.Completed() != 4 {
+		t.Fatalf("Expected 4 results, got %d", len(seen))
+	}
+
+	if len(result.Iterations) != 2 {
+		t.Fatalf("Expected 2 iterations, got %d", len(result.Iterations))
+	}
+	first, second := result.Iterations[0], result.Iterations[1]
+	if first.Data["user"] != "alice" || first.Failed() {
+		t.Errorf("Expected first iteration to pass with alice, got %+v", first)
+	}
+	if second.Data["user"] != "bob" || !second.Failed() {
+		t.Errorf("Expected second iteration to fail with bob, got %+v", second)
+	}
+	if second.Requests[0].Response.Body != "hello bob" {
+		t.Errorf("Expected iteration data to win over the environment, got %q", second.Requests[0].Response.Body)
+	}
+
+	summary := result.Summary()
+	if summary.Iterations != 2 || summary.Requests != 4 || summary.FailedRequests != 1 || summary.Tests != 4 || summary.FailedTests != 1 {
+		t.Errorf("Unexpected summary: %+v", summary)
+	}
+}
+
+func TestRun_IterationsWithoutData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	collection := &postman.Collection{
+		Info:  postman.Info{Name: "Run Test"},
+		Items: []postman.Item{{Name: "Ping", Request: &postman.Request{Method: "GET", URL: postman.URL{Raw: server.URL}}}},
+	}
+
+	run := New(httpclient.NewExecutor(), postman.NewParser(), collection, nil, CollectTargets(collection.Items, nil), Options{Iterations: 3})
+	result := run.RunAll(nil)
+	if len(result.Iterations) != 3 || result.Iterations[2].Data != nil {
+		t.Errorf("Expected 3 iterations without data, got %+v", result.Iterations)
+	}
+}
+
+func TestFindFolder(t *testing.T) {
+	collection := createRunCollection("http://localhost")
+
+	items, err := FindFolder(collection, []string{"Folder"})
+	if err != nil || len(items) != 1 || items[0].Name != "Ping" {
+		t.Errorf("Expected folder items, got %+v, %v", items, err)
+	}
+	if _, err := FindFolder(collection, []string{"Missing"}); err == nil {
+		t.Error("Expected an error for a missing folder")
+	}
+}
+
+func TestRun_StopOnFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello nobody"))
+	}))
+	defer server.Close()
+
+	collection := createRunCollection(server.URL)
+	run := New(httpclient.NewExecutor(), postman.NewParser(), collection, nil, CollectTargets(collection.Items, nil), Options{
+		Iterations:    2,
+		Data:          []map[string]string{{"user": "alice", "status": "200"}},
+		StopOnFailure: true,
+	})
+
+	result := run.RunAll(nil)
+	if summary := result.Summary(); summary.Requests != 1 || summary.FailedRequests != 1 || run.Done() {
+		t.Errorf("Expected the run to stop after the first failed request, got %+v", summary)
+	}
+}
+
+func TestFindTargets(t *testing.T) {
+	collection := createRunCollection("http://localhost")
+
+	for _, test := range []struct {
+		path     []string
+		expected []string
+	}{
+		{nil, []string{"Greet", "Folder / Ping"}},
+		{[]string{"Folder"}, []string{"Folder / Ping"}},
+		{[]string{"Folder", "Ping"}, []string{"Folder / Ping"}},
+		{[]string{"Greet"}, []string{"Greet"}},
+	} {
+		targets, err := FindTargets(collection, test.path)
+		if err != nil || len(targets) != len(test.expected) {
+			t.Errorf("%v: expected %v, got %+v (%v)", test.path, test.expected, targets, err)
+			continue
+		}
+		for i, target := range targets {
+			if target.Name() != test.expected[i] {
+				t.Errorf("%v: expected %q, got %q", test.path, test.expected[i], target.Name())
+			}
+		}
+	}
+
+	if _, err := FindTargets(collection, []string{"Folder", "Missing"}); err == nil {
+		t.Error("Expected an error for a missing request")
+	}
+}
+
+func TestRun_VariableChangesChainBetweenRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.Query().Get("token")))
+	}))
+	defer server.Close()
+
+	collection := &postman.Collection{
+		Info:      postman.Info{Name: "Chain"},
+		Variables: []postman.Variable{{Key: "token", Value: "none"}},
+		Items: []postman.Item{
+			{
+				Name:    "Login",
+				Request: &postman.Request{Method: "GET", URL: postman.URL{Raw: server.URL + "/login"}},
+				Events: []postman.Event{{
+					Listen: "test",
+					Script: postman.Script{Exec: []string{"pm.collectionVariables.set('token', 'secret');"}},
+				}},
+			},
+			{Name: "Use", Request: &postman.Request{Method: "GET", URL: postman.URL{Raw: server.URL + "/use?token={{token}}"}}},
+		},
+	}
+
+	run := New(httpclient.NewExecutor(), postman.NewParser(), collection, nil, CollectTargets(collection.Items, nil), Options{})
+	login, use := run.Step(), run.Step()
+
+	if len(login.VariableChanges.Collection) != 1 || login.VariableChanges.Collection[0].Value != "secret" {
+		t.Errorf("Expected the login script write to be reported, got %+v", login.VariableChanges)
+	}
+	if use.Response.Body != "secret" || !use.VariableChanges.Empty() {
+		t.Errorf("Expected the next request to see the write, got %q", use.Response.Body)
+	}
+	if collection.Variables[0].Value != "none" {
+		t.Errorf("Expected the run to leave the caller's collection untouched, got %s", collection.Variables[0].Value)
+	}
+}
@@ -0,0 +1,145 @@
+package runner
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+func FormatData(data map[string]string) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%s", key, data[key]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func FormatResult(result RequestResult, data map[string]string) []string {
+	icon := "✓"
+	if result.Failed() {
+		icon = "✗"
+	}
+
+	line := fmt.Sprintf("  %s %s %s", icon, result.Target.Item.Request.Method, result.Target.Name())
+	if result.Response != nil && result.Response.Error != nil {
+		line += fmt.Sprintf("  error: %v", result.Response.Error)
+	} else if result.Response != nil {
+		line += fmt.Sprintf("  %s  %v", result.Response.Status, result.Response.Duration.Round(time.Millisecond))
+	}
+	lines := []string{line}
+
+	if result.TestResult != nil {
+		for _, test := range result.TestResult.Tests {
+			if test.Passed {
+				lines = append(lines, fmt.Sprintf("      ✓ %s", test.Name))
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("      ✗ %s: %s", test.Name, test.Error))
+		}
+		for _, err := range result.TestResult.Errors {
+			lines = append(lines, fmt.Sprintf("      ! %s", err))
+		}
+	}
+
+	if result.Failed() && len(data) > 0 {
+		lines = append(lines, fmt.Sprintf("      data: %s", FormatData(data)))
+	}
+	return lines
+}
+
+func WriteSummary(w io.Writer, result *Result) {
+	summary := result.Summary()
+
+	var failed []IterationResult
+	for _, iteration := range result.Iterations {
+		if iteration.Failed() {
+			failed = append(failed, iteration)
+		}
+	}
+
+	if len(failed) > 0 {
+		fmt.Fprintln(w, "\nFailures:")
+		for _, iteration := range failed {
+			fmt.Fprintf(w, "Iteration %d", iteration.Index+1)
+			if len(iteration.Data) > 0 {
+				fmt.Fprintf(w, " (%s)", FormatData(iteration.Data))
+			}
+			fmt.Fprintln(w)
+			for _, request := range iteration.Requests {
+				if request.Failed() {
+					for _, line := range FormatResult(request, nil) {
+						fmt.Fprintln(w, line)
+					}
+				}
+			}
+		}
+	}
+
+	fmt.Fprintf(w, "\nIterations: %d | Requests: %d (%d failed) | Tests: %d (%d failed) | Duration: %v\n",
+		summary.Iterations, summary.Requests, summary.FailedRequests, summary.Tests, summary.FailedTests, result.Duration.Round(time.Millisecond))
+}
+
+func formatLatency(d time.Duration) string {
+	if d < time.Millisecond {
+		return d.Round(time.Microsecond).String()
+	}
+	return d.Round(100 * time.Microsecond).String()
+}
+
+func FormatLoadProgress(snapshot LoadSnapshot) string {
+	return fmt.Sprintf("%v | %d/%d VUs | %d requests | %.1f req/s | %.1f%% errors | p50 %s p90 %s p99 %s",
+		snapshot.Elapsed.Round(time.Second), snapshot.ActiveVUs, snapshot.Options.VUs, snapshot.Requests, snapshot.RPS,
+		snapshot.ErrorRate()*100, formatLatency(snapshot.Latency.P50), formatLatency(snapshot.Latency.P90), formatLatency(snapshot.Latency.P99))
+}
+
+func FormatLatencySummary(latency LatencySummary) string {
+	return fmt.Sprintf("min %s | mean %s | p50 %s | p90 %s | p99 %s | max %s",
+		formatLatency(latency.Min), formatLatency(latency.Mean), formatLatency(latency.P50),
+		formatLatency(latency.P90), formatLatency(latency.P99), formatLatency(latency.Max))
+}
+
+func WriteLoadSummary(w io.Writer, snapshot LoadSnapshot) {
+	outcome := "Finished"
+	if snapshot.Stopped {
+		outcome = "Stopped"
+	}
+
+	fmt.Fprintf(w, "\n%s load test of %s (%s) after %v\n", outcome, snapshot.Name, snapshot.Options, snapshot.Elapsed.Round(time.Millisecond))
+	fmt.Fprintf(w, "Iterations: %d | Requests: %d | Throughput: %.1f req/s\n", snapshot.Iterations, snapshot.Requests, snapshot.RPS)
+	fmt.Fprintf(w, "Errors: %d (%.2f%%) | Request errors: %d | HTTP 4xx/5xx: %d | Requests with failed tests: %d\n",
+		snapshot.Failed(), snapshot.ErrorRate()*100, snapshot.Errors, snapshot.HTTPErrors, snapshot.FailedTests)
+	fmt.Fprintf(w, "Latency: %s\n", FormatLatencySummary(snapshot.Latency))
+
+	if len(snapshot.Statuses) > 0 || snapshot.Errors > 0 {
+		fmt.Fprintln(w, "\nStatus codes:")
+		for _, status := range snapshot.Statuses {
+			fmt.Fprintf(w, "  %d  %d\n", status.Code, status.Count)
+		}
+		if snapshot.Errors > 0 {
+			fmt.Fprintf(w, "  err  %d\n", snapshot.Errors)
+		}
+	}
+
+	if len(snapshot.TopErrors) > 0 {
+		fmt.Fprintln(w, "\nErrors:")
+		for _, err := range snapshot.TopErrors {
+			fmt.Fprintf(w, "  %dx %s\n", err.Count, err.Message)
+		}
+	}
+
+	if len(snapshot.Targets) > 1 {
+		fmt.Fprintln(w, "\nRequests:")
+		for _, target := range snapshot.Targets {
+			fmt.Fprintf(w, "  %s %s: %d requests, %d failed | p50 %s p90 %s p99 %s\n", target.Method, target.Name,
+				target.Requests, target.Failures, formatLatency(target.Latency.P50), formatLatency(target.Latency.P90), formatLatency(target.Latency.P99))
+		}
+	}
+}
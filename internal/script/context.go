@@ -10,12 +10,16 @@ type ResponseData struct {
 	Body         string
 	Headers      map[string][]string
 	ResponseTime int64
+	Timings      map[string]float64
+	BodySize     int64
+	HeaderSize   int64
 }
 
 type ExecutionContext struct {
 	Response        *ResponseData
 	CollectionVars  []postman.Variable
 	EnvironmentVars []postman.EnvVariable
+	IterationData   map[string]string
 }
 
 type TestResult struct {
@@ -109,6 +109,9 @@ func setupPmAPI(vm *goja.Runtime, ctx *ExecutionContext, result *TestResult) err
 			return goja.Undefined()
 		}
 		key := call.Arguments[0].String()
+		if value, ok := ctx.IterationData[key]; ok {
+			return vm.ToValue(value)
+		}
 		if value, ok := envVarGetter(key); ok {
 			return vm.ToValue(value)
 		}
@@ -136,6 +139,43 @@ func setupPmAPI(vm *goja.Runtime, ctx *ExecutionContext, result *TestResult) err
 		return fmt.Errorf("failed to set pm.variables: %w", err)
 	}
 
+	iterationDataObj := vm.NewObject()
+	if err := iterationDataObj.Set("get", func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) < 1 {
+			return goja.Undefined()
+		}
+		if value, ok := ctx.IterationData[call.Arguments[0].String()]; ok {
+			return vm.ToValue(value)
+		}
+		return goja.Undefined()
+	}); err != nil {
+		return fmt.Errorf("failed to set pm.iterationData.get: %w", err)
+	}
+
+	if err := iterationDataObj.Set("has", func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) < 1 {
+			return vm.ToValue(false)
+		}
+		_, ok := ctx.IterationData[call.Arguments[0].String()]
+		return vm.ToValue(ok)
+	}); err != nil {
+		return fmt.Errorf("failed to set pm.iterationData.has: %w", err)
+	}
+
+	if err := iterationDataObj.Set("toObject", func(call goja.FunctionCall) goja.Value {
+		data := make(map[string]interface{}, len(ctx.IterationData))
+		for key, value := range ctx.IterationData {
+			data[key] = value
+		}
+		return vm.ToValue(data)
+	}); err != nil {
+		return fmt.Errorf("failed to set pm.iterationData.toObject: %w", err)
+	}
+
+	if err := pmObj.Set("iterationData", iterationDataObj); err != nil {
+		return fmt.Errorf("failed to set pm.iterationData: %w", err)
+	}
+
 	if ctx.Response != nil {
 		responseObj := vm.NewObject()
 
@@ -157,6 +197,21 @@ func setupPmAPI(vm *goja.Runtime, ctx *ExecutionContext, result *TestResult) err
 			return fmt.Errorf("failed to set pm.response.json: %w", err)
 		}
 
+		sizeFunc := func(call goja.FunctionCall) goja.Value {
+			bodySize := ctx.Response.BodySize
+			if bodySize == 0 {
+				bodySize = int64(len(ctx.Response.Body))
+			}
+			return vm.ToValue(map[string]int64{
+				"body":   bodySize,
+				"header": ctx.Response.HeaderSize,
+				"total":  bodySize + ctx.Response.HeaderSize,
+			})
+		}
+		if err := responseObj.Set("size", sizeFunc); err != nil {
+			return fmt.Errorf("failed to set pm.response.size: %w", err)
+		}
+
 		if err := responseObj.Set("code", ctx.Response.StatusCode); err != nil {
 			return fmt.Errorf("failed to set pm.response.code: %w", err)
 		}
@@ -169,6 +224,14 @@ func setupPmAPI(vm *goja.Runtime, ctx *ExecutionContext, result *TestResult) err
 			return fmt.Errorf("failed to set pm.response.responseTime: %w", err)
 		}
 
+		timings := ctx.Response.Timings
+		if timings == nil {
+			timings = map[string]float64{}
+		}
+		if err := responseObj.Set("timings", timings); err != nil {
+			return fmt.Errorf("failed to set pm.response.timings: %w", err)
+		}
+
 		if err := responseObj.Set("headers", ctx.Response.Headers); err != nil {
 			return fmt.Errorf("failed to set pm.response.headers: %w", err)
 		}
@@ -363,3 +363,105 @@ func TestExecuteTestScript_ResponseToHaveJsonBody_NotFound(t *testing.T) {
 		t.Errorf("Expected 'not found' in error, got: %s", result.Tests[0].Error)
 	}
 }
+
+func TestExecuteTestScript_ResponseTimings(t *testing.T) {
+	runtime := NewRuntime()
+	script := postman.Script{
+		Type: "text/javascript",
+		Exec: []string{
+			"pm.test('timings are accessible', () => {",
+			"    const timings = pm.response.timings;",
+			"    if (timings.ttfb !== 42.5 || timings.dns !== 3) {",
+			"        throw new Error('Unexpected timings ' + JSON.stringify(timings));",
+			"    }",
+			"});",
+		},
+	}
+
+	ctx := &ExecutionContext{
+		Response: &ResponseData{
+			StatusCode: 200,
+			Timings:    map[string]float64{"dns": 3, "ttfb": 42.5, "total": 50},
+		},
+	}
+
+	result := runtime.ExecuteTestScript(script, ctx)
+
+	if len(result.Errors) > 0 {
+		t.Errorf("Expected no errors, got: %v", result.Errors)
+	}
+	if len(result.Tests) != 1 || !result.Tests[0].Passed {
+		t.Fatalf("Expected timings test to pass, got: %+v", result.Tests)
+	}
+}
+
+func TestExecuteTestScript_ResponseSize(t *testing.T) {
+	runtime := NewRuntime()
+	script := postman.Script{
+		Type: "text/javascript",
+		Exec: []string{
+			"pm.test('size() reports body and header bytes', () => {",
+			"    const size = pm.response.size();",
+			"    if (size.body !== 2048 || size.header !== 120 || size.total !== 2168) {",
+			"        throw new Error('Unexpected size ' + JSON.stringify(size));",
+			"    }",
+			"});",
+		},
+	}
+
+	ctx := &ExecutionContext{
+		Response: &ResponseData{
+			StatusCode: 200,
+			Body:       "",
+			BodySize:   2048,
+			HeaderSize: 120,
+		},
+	}
+
+	result := runtime.ExecuteTestScript(script, ctx)
+
+	if len(result.Errors) > 0 {
+		t.Errorf("Expected no errors, got: %v", result.Errors)
+	}
+	if len(result.Tests) != 1 || !result.Tests[0].Passed {
+		t.Fatalf("Expected size test to pass, got: %+v", result.Tests)
+	}
+}
+
+func TestExecuteTestScript_IterationData(t *testing.T) {
+	runtime := NewRuntime()
+	script := postman.Script{
+		Type: "text/javascript",
+		Exec: []string{
+			"pm.test('iteration data is accessible', () => {",
+			"    if (pm.iterationData.get('user') !== 'alice') {",
+			"        throw new Error('Expected user alice');",
+			"    }",
+			"    if (!pm.iterationData.has('id') || pm.iterationData.has('missing')) {",
+			"        throw new Error('Unexpected has() result');",
+			"    }",
+			"    if (pm.iterationData.toObject().id !== '7') {",
+			"        throw new Error('Expected toObject() to include id');",
+			"    }",
+			"    if (pm.variables.get('user') !== 'alice') {",
+			"        throw new Error('Expected iteration data to win over the environment');",
+			"    }",
+			"});",
+		},
+	}
+
+	ctx := &ExecutionContext{
+		Response:        &ResponseData{StatusCode: 200},
+		EnvironmentVars: []postman.EnvVariable{{Key: "user", Value: "env-user", Enabled: true}},
+		IterationData:   map[string]string{"user": "alice", "id": "7"},
+	}
+
+	result := runtime.ExecuteTestScript(script, ctx)
+
+	if len(result.Errors) > 0 {
+		t.Errorf("Expected no errors, got: %v", result.Errors)
+	}
+	if len(result.Tests) != 1 || !result.Tests[0].Passed {
+		t.Fatalf("Expected iteration data test to pass, got: %+v", result.Tests)
+	}
+}
@@ -0,0 +1,343 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type BodyView int
+
+const (
+	BodyViewPretty BodyView = iota
+	BodyViewRaw
+	BodyViewHex
+)
+
+const (
+	maxPrettyBodyBytes = 512 * 1024
+	maxHexBodyBytes    = 64 * 1024
+	maxBodyLines       = 5000
+	maxBodyLineWidth   = 1000
+)
+
+var (
+	jsonKeyStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("4"))
+	jsonStringStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	jsonNumberStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
+	jsonLiteralStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("5"))
+	markupTagStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("4"))
+	markupAttrStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+	markupNoteStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	hexOffsetStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+
+	markupTokenPattern = regexp.MustCompile(`(?s)<!--.*?-->|<![^>]*>|<\?.*?\?>|<[^>]+>|[^<]+`)
+	markupTagPattern   = regexp.MustCompile(`^<(/?)([^\s/>]+)(.*?)(/?)>$`)
+	markupAttrPattern  = regexp.MustCompile(`([^\s=]+)(\s*=\s*)("[^"]*"|'[^']*'|[^\s"']+)`)
+	jsonNumberPattern  = regexp.MustCompile(`^-?\d+(\.\d+)?([eE][+-]?\d+)?`)
+)
+
+var htmlVoidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+func (v BodyView) String() string {
+	switch v {
+	case BodyViewRaw:
+		return "raw"
+	case BodyViewHex:
+		return "hex"
+	}
+	return "pretty"
+}
+
+func headerValue(headers map[string][]string, name string) string {
+	for key, values := range headers {
+		if strings.EqualFold(key, name) && len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+func detectBodyFormat(contentType string, body string) string {
+	if language := previewLanguage(strings.ToLower(contentType)); language != "" && language != "text" {
+		return language
+	}
+
+	trimmed := strings.TrimSpace(body)
+	switch {
+	case trimmed == "":
+		return "text"
+	case (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid([]byte(trimmed)):
+		return "json"
+	case len(trimmed) >= 14 && strings.EqualFold(trimmed[:14], "<!doctype html"),
+		len(trimmed) >= 5 && strings.EqualFold(trimmed[:5], "<html"):
+		return "html"
+	case strings.HasPrefix(trimmed, "<"):
+		return "xml"
+	}
+	return "text"
+}
+
+func (m Model) buildBodyLines(body string, contentType string) []string {
+	format := detectBodyFormat(contentType, body)
+	view := m.bodyView
+
+	var lines []string
+	switch {
+	case view == BodyViewHex:
+		lines = hexBodyLines(body)
+	case view == BodyViewPretty && len(body) <= maxPrettyBodyBytes:
+		lines = prettyBodyLines(body, format)
+	default:
+		lines = rawBodyLines(body)
+	}
+
+	label := fmt.Sprintf("Response Body (%s, %s, %s):", view, format, formatByteSize(len(body)))
+	if view == BodyViewPretty && len(body) > maxPrettyBodyBytes {
+		label = fmt.Sprintf("Response Body (raw, %s, %s - too large to pretty-print):", format, formatByteSize(len(body)))
+	}
+
+	result := []string{requestStyle.Render(label) + markupNoteStyle.Render("  v to switch view")}
+	if len(lines) > maxBodyLines {
+		hidden := len(lines) - maxBodyLines
+		lines = append(lines[:maxBodyLines:maxBodyLines], markupNoteStyle.Render(fmt.Sprintf("... %d more lines not shown", hidden)))
+	}
+	return append(result, lines...)
+}
+
+func formatByteSize(size int) string {
+	switch {
+	case size >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+	case size >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	}
+	return fmt.Sprintf("%d B", size)
+}
+
+func rawBodyLines(body string) []string {
+	var lines []string
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		for len(line) > maxBodyLineWidth {
+			cut := maxBodyLineWidth
+			for cut > 0 && !isRuneStart(line[cut]) {
+				cut--
+			}
+			lines = append(lines, line[:cut])
+			line = line[cut:]
+			if len(lines) > maxBodyLines {
+				return lines
+			}
+		}
+		lines = append(lines, line)
+		if len(lines) > maxBodyLines {
+			return lines
+		}
+	}
+	return lines
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+func hexBodyLines(body string) []string {
+	data := []byte(body)
+	truncated := len(data) > maxHexBodyBytes
+	if truncated {
+		data = data[:maxHexBodyBytes]
+	}
+
+	var lines []string
+	for offset := 0; offset < len(data); offset += 16 {
+		chunk := data[offset:min(offset+16, len(data))]
+
+		hexPart := hex.EncodeToString(chunk)
+		var grouped strings.Builder
+		for i := 0; i < 16; i++ {
+			if i == 8 {
+				grouped.WriteString(" ")
+			}
+			if i < len(chunk) {
+				grouped.WriteString(hexPart[i*2:i*2+2] + " ")
+			} else {
+				grouped.WriteString("   ")
+			}
+		}
+
+		printable := make([]byte, len(chunk))
+		for i, b := range chunk {
+			if b >= 32 && b < 127 {
+				printable[i] = b
+			} else {
+				printable[i] = '.'
+			}
+		}
+
+		lines = append(lines, hexOffsetStyle.Render(fmt.Sprintf("%08x", offset))+"  "+grouped.String()+" |"+string(printable)+"|")
+	}
+
+	if truncated {
+		lines = append(lines, markupNoteStyle.Render(fmt.Sprintf("... showing first %s of %s", formatByteSize(maxHexBodyBytes), formatByteSize(len(body)))))
+	}
+	return lines
+}
+
+func prettyBodyLines(body string, format string) []string {
+	switch format {
+	case "json":
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, []byte(strings.TrimSpace(body)), "", "  "); err != nil {
+			return rawBodyLines(body)
+		}
+		lines := strings.Split(buf.String(), "\n")
+		for i, line := range lines {
+			lines[i] = highlightJSONLine(line)
+		}
+		return lines
+	case "xml", "html":
+		lines := indentMarkup(body, format == "html")
+		for i, line := range lines {
+			lines[i] = highlightMarkupLine(line)
+		}
+		return lines
+	}
+	return rawBodyLines(body)
+}
+
+func highlightJSONLine(line string) string {
+	var out strings.Builder
+	for i := 0; i < len(line); {
+		c := line[i]
+		switch {
+		case c == '"':
+			end := i + 1
+			for end < len(line) && line[end] != '"' {
+				if line[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(line))
+			token := line[i:end]
+			rest := strings.TrimLeft(line[end:], " ")
+			if strings.HasPrefix(rest, ":") {
+				out.WriteString(jsonKeyStyle.Render(token))
+			} else {
+				out.WriteString(jsonStringStyle.Render(token))
+			}
+			i = end
+		case c == '-' || (c >= '0' && c <= '9'):
+			token := jsonNumberPattern.FindString(line[i:])
+			if token == "" {
+				token = line[i : i+1]
+			}
+			out.WriteString(jsonNumberStyle.Render(token))
+			i += len(token)
+		case strings.HasPrefix(line[i:], "true"):
+			out.WriteString(jsonLiteralStyle.Render("true"))
+			i += 4
+		case strings.HasPrefix(line[i:], "false"):
+			out.WriteString(jsonLiteralStyle.Render("false"))
+			i += 5
+		case strings.HasPrefix(line[i:], "null"):
+			out.WriteString(jsonLiteralStyle.Render("null"))
+			i += 4
+		default:
+			out.WriteByte(c)
+			i++
+		}
+	}
+	return out.String()
+}
+
+func indentMarkup(body string, isHTML bool) []string {
+	var tokens []string
+	for _, token := range markupTokenPattern.FindAllString(body, -1) {
+		if trimmed := strings.TrimSpace(token); trimmed != "" {
+			tokens = append(tokens, trimmed)
+		}
+	}
+
+	var lines []string
+	depth := 0
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		indent := strings.Repeat("  ", depth)
+
+		match := markupTagPattern.FindStringSubmatch(token)
+		switch {
+		case match == nil || strings.HasPrefix(token, "<!") || strings.HasPrefix(token, "<?"):
+			lines = append(lines, indent+token)
+		case match[1] == "/":
+			depth = max(depth-1, 0)
+			lines = append(lines, strings.Repeat("  ", depth)+token)
+		case match[4] == "/" || (isHTML && htmlVoidElements[strings.ToLower(match[2])]):
+			lines = append(lines, indent+token)
+		case i+2 < len(tokens) && !strings.HasPrefix(tokens[i+1], "<") && tokens[i+2] == "</"+match[2]+">":
+			lines = append(lines, indent+token+tokens[i+1]+tokens[i+2])
+			i += 2
+		case i+1 < len(tokens) && tokens[i+1] == "</"+match[2]+">":
+			lines = append(lines, indent+token+tokens[i+1])
+			i++
+		default:
+			lines = append(lines, indent+token)
+			depth++
+		}
+	}
+	return lines
+}
+
+func highlightMarkupLine(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	indent := line[:len(line)-len(trimmed)]
+
+	var out strings.Builder
+	out.WriteString(indent)
+	for _, token := range markupTokenPattern.FindAllString(trimmed, -1) {
+		match := markupTagPattern.FindStringSubmatch(token)
+		switch {
+		case strings.HasPrefix(token, "<!") || strings.HasPrefix(token, "<?"):
+			out.WriteString(markupNoteStyle.Render(token))
+		case match != nil:
+			out.WriteString(markupTagStyle.Render("<" + match[1] + match[2]))
+			out.WriteString(markupAttrPattern.ReplaceAllStringFunc(match[3], func(attr string) string {
+				parts := markupAttrPattern.FindStringSubmatch(attr)
+				return markupAttrStyle.Render(parts[1]) + parts[2] + jsonStringStyle.Render(parts[3])
+			}))
+			out.WriteString(markupTagStyle.Render(match[4] + ">"))
+		default:
+			out.WriteString(token)
+		}
+	}
+	return out.String()
+}
+
+func (m Model) refreshResponseViewport() Model {
+	m.responseViewport.Width = m.width - 8
+	m.responseViewport.Height = m.height - 8
+	m = m.setViewportContent(ModeResponse, strings.Join(m.buildResponseLines(), "\n"))
+	return m
+}
+
+func handleBodyViewKey(m Model) (Model, tea.Cmd) {
+	if m.lastResponse == nil {
+		return m, nil
+	}
+
+	m.bodyView = (m.bodyView + 1) % 3
+	m = m.refreshResponseViewport()
+	m.responseViewport.GotoTop()
+	m.statusMessage = fmt.Sprintf("Body view: %s", m.bodyView)
+	return m, nil
+}
@@ -0,0 +1,133 @@
+package tui
+
+import (
+	"postOffice/internal/http"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func stripLines(lines []string) []string {
+	stripped := make([]string, len(lines))
+	for i, line := range lines {
+		stripped[i] = ansi.Strip(line)
+	}
+	return stripped
+}
+
+func TestDetectBodyFormat(t *testing.T) {
+	tests := []struct {
+		contentType string
+		body        string
+		expected    string
+	}{
+		{"application/json; charset=utf-8", "not json", "json"},
+		{"", `{"a":1}`, "json"},
+		{"text/plain", `[1,2]`, "json"},
+		{"", "<!DOCTYPE html><html></html>", "html"},
+		{"", `<?xml version="1.0"?><a/>`, "xml"},
+		{"application/xml", "", "xml"},
+		{"", "{broken", "text"},
+		{"", "", "text"},
+	}
+
+	for _, tt := range tests {
+		if got := detectBodyFormat(tt.contentType, tt.body); got != tt.expected {
+			t.Errorf("detectBodyFormat(%q, %q) = %s, expected %s", tt.contentType, tt.body, got, tt.expected)
+		}
+	}
+}
+
+func TestPrettyBodyLines_JSON(t *testing.T) {
+	lines := stripLines(prettyBodyLines(`{"id":1,"tags":["a"],"ok":true,"none":null}`, "json"))
+	expected := []string{"{", `  "id": 1,`, `  "tags": [`, `    "a"`, "  ],", `  "ok": true,`, `  "none": null`, "}"}
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected pretty JSON:\n%s", strings.Join(lines, "\n"))
+	}
+}
+
+func TestPrettyBodyLines_Markup(t *testing.T) {
+	body := `<?xml version="1.0"?><users><user id="1"><name>Ada</name><admin/></user><!-- end --></users>`
+	lines := stripLines(prettyBodyLines(body, "xml"))
+	expected := []string{
+		`<?xml version="1.0"?>`,
+		"<users>",
+		`  <user id="1">`,
+		"    <name>Ada</name>",
+		"    <admin/>",
+		"  </user>",
+		"  <!-- end -->",
+		"</users>",
+	}
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected pretty XML:\n%s", strings.Join(lines, "\n"))
+	}
+
+	lines = stripLines(prettyBodyLines("<html><body><p>Hi<br>there</p><img src=x></body></html>", "html"))
+	if lines[len(lines)-1] != "</html>" || !contains(strings.Join(lines, "\n"), "    <br>") {
+		t.Errorf("Expected void elements not to increase depth:\n%s", strings.Join(lines, "\n"))
+	}
+}
+
+func TestHexBodyLines(t *testing.T) {
+	lines := stripLines(hexBodyLines("Hello, hex!\x00\x01"))
+	if len(lines) != 1 {
+		t.Fatalf("Expected one hex line, got %d", len(lines))
+	}
+	if !strings.HasPrefix(lines[0], "00000000  48 65 6c 6c 6f 2c 20 68  65 78 21 00 01") || !strings.HasSuffix(lines[0], "|Hello, hex!..|") {
+		t.Errorf("Unexpected hex line: %q", lines[0])
+	}
+}
+
+func TestBuildBodyLines_CapsHugeBodies(t *testing.T) {
+	m := createTestModel()
+
+	minified := strings.Repeat("x", maxPrettyBodyBytes+1)
+	lines := stripLines(m.buildBodyLines(minified, "text/plain"))
+	if !contains(lines[0], "too large to pretty-print") {
+		t.Errorf("Expected label to mention the size cap, got %q", lines[0])
+	}
+	for _, line := range lines {
+		if len(line) > maxBodyLineWidth {
+			t.Fatalf("Expected long lines to be split, got a line of %d bytes", len(line))
+		}
+	}
+
+	lines = stripLines(m.buildBodyLines(strings.Repeat("line\n", maxBodyLines+10), "text/plain"))
+	if len(lines) != maxBodyLines+2 || !contains(lines[len(lines)-1], "more lines not shown") {
+		t.Errorf("Expected line count to be capped, got %d lines", len(lines))
+	}
+
+	m.bodyView = BodyViewHex
+	lines = stripLines(m.buildBodyLines(minified, "text/plain"))
+	if !contains(lines[len(lines)-1], "showing first 64.0 KB") {
+		t.Errorf("Expected hex view to be capped, got %q", lines[len(lines)-1])
+	}
+}
+
+func TestBodyViewKey_CyclesViews(t *testing.T) {
+	m := createTestModel()
+	m.width, m.height = 120, 40
+	m.mode = ModeResponse
+	m.lastResponse = &http.Response{
+		StatusCode: 200,
+		Status:     "200 OK",
+		Headers:    map[string][]string{"Content-Type": {"application/json"}},
+		Body:       `{"a":1}`,
+	}
+
+	expected := []BodyView{BodyViewRaw, BodyViewHex, BodyViewPretty}
+	for _, view := range expected {
+		m, _ = handleBodyViewKey(m)
+		if m.bodyView != view {
+			t.Fatalf("Expected %s view, got %s", view, m.bodyView)
+		}
+	}
+
+	m, _ = handleBodyViewKey(m)
+	content := ansi.Strip(m.responseViewport.View())
+	if !contains(content, "Response Body (raw, json, 7 B)") || !contains(content, `{"a":1}`) {
+		t.Errorf("Expected viewport to show the raw body, got:\n%s", content)
+	}
+}
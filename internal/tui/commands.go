@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"postOffice/internal/postman"
+	"sort"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -161,6 +162,169 @@ func (cr *CommandRegistry) registerCommands() {
 			Handler:     handleDeleteCommand,
 			AvailableIn: []ViewMode{ModeRequests},
 		},
+		{
+			Name:        "new",
+			Description: "Create a request in the current folder",
+			ShortHelp:   ":new [METHOD] <name>",
+			Handler:     handleNewCommand,
+			AvailableIn: []ViewMode{ModeRequests},
+		},
+		{
+			Name:        "mkdir",
+			Description: "Create a folder in the current folder",
+			ShortHelp:   ":mkdir <name>",
+			Handler:     handleMkdirCommand,
+			AvailableIn: []ViewMode{ModeRequests},
+		},
+		{
+			Name:        "mv",
+			Aliases:     []string{"move"},
+			Description: "Move selected item to a folder (/ for the collection root)",
+			ShortHelp:   ":mv <folder/path>",
+			Handler:     handleMoveCommand,
+			AvailableIn: []ViewMode{ModeRequests},
+		},
+		{
+			Name:        "rename",
+			Description: "Rename selected request or folder",
+			ShortHelp:   ":rename <name>",
+			Handler:     handleRenameCommand,
+			AvailableIn: []ViewMode{ModeRequests},
+		},
+		{
+			Name:        "resync",
+			Aliases:     []string{"sync"},
+			Description: "Re-sync collection with its OpenAPI spec",
+			ShortHelp:   ":resync",
+			Handler:     handleResyncCommand,
+			AvailableIn: []ViewMode{ModeCollections, ModeRequests},
+		},
+		{
+			Name:        "newcollection",
+			Aliases:     []string{"nc"},
+			Description: "Create an empty collection file",
+			ShortHelp:   ":newcollection <name> <path>",
+			Handler:     handleNewCollectionCommand,
+			AvailableIn: []ViewMode{ModeCollections, ModeRequests},
+		},
+		{
+			Name:        "newenv",
+			Aliases:     []string{"ne"},
+			Description: "Create an empty environment file",
+			ShortHelp:   ":newenv <name> <path>",
+			Handler:     handleNewEnvironmentCommand,
+			AvailableIn: []ViewMode{ModeCollections, ModeRequests, ModeEnvironments, ModeVariables},
+		},
+		{
+			Name:        "saveas",
+			Description: "Save the current collection or selected environment to a new file",
+			ShortHelp:   ":saveas <path>",
+			Handler:     handleSaveAsCommand,
+			AvailableIn: []ViewMode{ModeRequests, ModeEnvironments},
+		},
+		{
+			Name:        "curl",
+			Description: "Import a curl command as a request",
+			ShortHelp:   ":curl",
+			Handler:     handleCurlCommand,
+			AvailableIn: []ViewMode{ModeRequests},
+		},
+		{
+			Name:        "snippet",
+			Aliases:     []string{"yank"},
+			Description: "Copy request as code (curl, httpie, go, python, fetch) or write it to a file",
+			ShortHelp:   ":snippet",
+			Handler:     handleSnippetCommand,
+			AvailableIn: []ViewMode{ModeRequests, ModeResponse},
+		},
+		{
+			Name:        "examples",
+			Aliases:     []string{"ex"},
+			Description: "Browse saved response examples of a request",
+			ShortHelp:   ":examples",
+			Handler:     handleExamplesCommand,
+			AvailableIn: []ViewMode{ModeRequests, ModeResponse},
+		},
+		{
+			Name:        "saveexample",
+			Description: "Save the current response as a named example on the request",
+			ShortHelp:   ":saveexample <name>",
+			Handler:     handleSaveExampleCommand,
+			AvailableIn: []ViewMode{ModeResponse},
+		},
+		{
+			Name:        "filter",
+			Aliases:     []string{"jq"},
+			Description: "Show only the part of a JSON response matching a gjson path or jq expression (no argument clears)",
+			ShortHelp:   ":filter <expr>",
+			Handler:     handleFilterCommand,
+			AvailableIn: []ViewMode{ModeResponse},
+		},
+		{
+			Name:        "setvar",
+			Description: "Store a value extracted from the response (current filter or given expression) in the active environment",
+			ShortHelp:   ":setvar <name> [expr]",
+			Handler:     handleSetVarCommand,
+			AvailableIn: []ViewMode{ModeResponse},
+		},
+		{
+			Name:        "saveresponse",
+			Aliases:     []string{"sr"},
+			Description: "Write the current response body to a file",
+			ShortHelp:   ":saveresponse <path>",
+			Handler:     handleSaveResponseCommand,
+			AvailableIn: []ViewMode{ModeResponse},
+		},
+		{
+			Name:        "run",
+			Description: "Run every request in the selected folder or collection",
+			ShortHelp:   ":run [-n N] [-delay 500ms] [-bail] [-d data.csv]",
+			Handler:     handleRunCommand,
+			AvailableIn: []ViewMode{ModeCollections, ModeRequests},
+		},
+		{
+			Name:        "report",
+			Description: "Write a JUnit XML, JSON or HTML report of the last run",
+			ShortHelp:   ":report <format> <path>",
+			Handler:     handleReportCommand,
+			AvailableIn: []ViewMode{ModeCollections, ModeRequests, ModeRunner},
+		},
+		{
+			Name:        "loadtest",
+			Aliases:     []string{"lt"},
+			Description: "Load test the selected request, folder or collection with concurrent virtual users",
+			ShortHelp:   ":loadtest [-u users] [-duration 30s] [-n N] [-ramp-up 10s] [-rps 50]",
+			Handler:     handleLoadTestCommand,
+			AvailableIn: []ViewMode{ModeCollections, ModeRequests, ModeLoadTest},
+		},
+		{
+			Name:        "replay",
+			Description: "Resend the current request starting from a hop in its redirect chain",
+			ShortHelp:   ":replay <n>",
+			Handler:     handleReplayCommand,
+			AvailableIn: []ViewMode{ModeResponse},
+		},
+		{
+			Name:        "export",
+			Description: "Export collection (openapi <path>) or executed requests (har <path>)",
+			ShortHelp:   ":export",
+			Handler:     handleExportCommand,
+			AvailableIn: []ViewMode{ModeCollections, ModeRequests},
+		},
+		{
+			Name:        "undo",
+			Description: "Undo the last collection or environment change",
+			ShortHelp:   ":undo",
+			Handler:     handleUndoCommand,
+			AvailableIn: []ViewMode{ModeCollections, ModeRequests, ModeEnvironments, ModeVariables},
+		},
+		{
+			Name:        "redo",
+			Description: "Redo the last undone change",
+			ShortHelp:   ":redo",
+			Handler:     handleRedoCommand,
+			AvailableIn: []ViewMode{ModeCollections, ModeRequests, ModeEnvironments, ModeVariables},
+		},
 		{
 			Name:        "quit",
 			Aliases:     []string{"q", "exit"},
@@ -217,7 +381,7 @@ func (cr *CommandRegistry) registerKeyBindings() {
 			Description: "Select",
 			ShortHelp:   "enter",
 			Handler:     handleEnterKey,
-			AvailableIn: []ViewMode{ModeCollections, ModeRequests, ModeResponse, ModeEnvironments, ModeChanges},
+			AvailableIn: []ViewMode{ModeCollections, ModeRequests, ModeResponse, ModeEnvironments, ModeChanges, ModeRunner},
 		},
 		{
 			Keys:        []string{"ctrl+e"},
@@ -241,9 +405,9 @@ func (cr *CommandRegistry) registerKeyBindings() {
 			AvailableIn: []ViewMode{ModeRequests, ModeEnvironments, ModeChanges},
 		},
 		{
-			Keys:        []string{"J"},
+			Keys:        []string{"o"},
 			Description: "View JSON",
-			ShortHelp:   "J",
+			ShortHelp:   "o",
 			Handler:     handleJSONKey,
 			AvailableIn: []ViewMode{ModeRequests, ModeEnvironments},
 		},
@@ -252,7 +416,21 @@ func (cr *CommandRegistry) registerKeyBindings() {
 			Description: "Search",
 			ShortHelp:   "/",
 			Handler:     handleSearchKey,
-			AvailableIn: []ViewMode{ModeCollections, ModeRequests, ModeEnvironments},
+			AvailableIn: []ViewMode{ModeCollections, ModeRequests, ModeEnvironments, ModeResponse, ModeInfo, ModeJSON, ModeLog},
+		},
+		{
+			Keys:        []string{"n"},
+			Description: "Next search match",
+			ShortHelp:   "n/N",
+			Handler:     handleNextMatchKey,
+			AvailableIn: []ViewMode{ModeResponse, ModeInfo, ModeJSON, ModeLog},
+		},
+		{
+			Keys:        []string{"N"},
+			Description: "Previous search match",
+			ShortHelp:   "n/N",
+			Handler:     handlePrevMatchKey,
+			AvailableIn: []ViewMode{ModeResponse, ModeInfo, ModeJSON, ModeLog},
 		},
 		{
 			Keys:        []string{"s"},
@@ -261,26 +439,33 @@ func (cr *CommandRegistry) registerKeyBindings() {
 			Handler:     handleRestoreSessionKey,
 			AvailableIn: []ViewMode{ModeCollections},
 		},
+		{
+			Keys:        []string{"s"},
+			Description: "Diff layout",
+			ShortHelp:   "s",
+			Handler:     handleDiffLayoutKey,
+			AvailableIn: []ViewMode{ModeInfo},
+		},
 		{
 			Keys:        []string{"esc", "left", "backspace", "h"},
 			Description: "Close/Back",
 			ShortHelp:   "esc",
 			Handler:     handleBackKey,
-			AvailableIn: []ViewMode{ModeResponse, ModeInfo, ModeJSON, ModeLog, ModeCollections, ModeRequests, ModeEnvironments, ModeVariables, ModeChanges},
+			AvailableIn: []ViewMode{ModeResponse, ModeInfo, ModeJSON, ModeLog, ModeCollections, ModeRequests, ModeEnvironments, ModeVariables, ModeChanges, ModeRunner, ModeLoadTest},
 		},
 		{
 			Keys:        []string{"up", "k"},
 			Description: "Navigate up",
 			ShortHelp:   "j/k",
 			Handler:     handleUpKey,
-			AvailableIn: []ViewMode{ModeCollections, ModeRequests, ModeInfo, ModeJSON, ModeLog, ModeResponse, ModeEnvironments, ModeVariables, ModeChanges},
+			AvailableIn: []ViewMode{ModeCollections, ModeRequests, ModeInfo, ModeJSON, ModeLog, ModeResponse, ModeEnvironments, ModeVariables, ModeChanges, ModeRunner},
 		},
 		{
 			Keys:        []string{"down", "j"},
 			Description: "Scroll/Navigate",
 			ShortHelp:   "j/k",
 			Handler:     handleDownKey,
-			AvailableIn: []ViewMode{ModeCollections, ModeRequests, ModeInfo, ModeJSON, ModeLog, ModeResponse, ModeEnvironments, ModeVariables, ModeChanges},
+			AvailableIn: []ViewMode{ModeCollections, ModeRequests, ModeInfo, ModeJSON, ModeLog, ModeResponse, ModeEnvironments, ModeVariables, ModeChanges, ModeRunner},
 		},
 		{
 			Keys:        []string{"d"},
@@ -289,6 +474,118 @@ func (cr *CommandRegistry) registerKeyBindings() {
 			Handler:     handleDuplicateKey,
 			AvailableIn: []ViewMode{ModeRequests},
 		},
+		{
+			Keys:        []string{"x"},
+			Description: "Cut",
+			ShortHelp:   "x",
+			Handler:     handleCutKey,
+			AvailableIn: []ViewMode{ModeRequests},
+		},
+		{
+			Keys:        []string{"p"},
+			Description: "Paste",
+			ShortHelp:   "p",
+			Handler:     handlePasteKey,
+			AvailableIn: []ViewMode{ModeRequests},
+		},
+		{
+			Keys:        []string{"J"},
+			Description: "Move down",
+			ShortHelp:   "J/K",
+			Handler:     handleMoveDownKey,
+			AvailableIn: []ViewMode{ModeRequests},
+		},
+		{
+			Keys:        []string{"K"},
+			Description: "Move up",
+			ShortHelp:   "J/K",
+			Handler:     handleMoveUpKey,
+			AvailableIn: []ViewMode{ModeRequests},
+		},
+		{
+			Keys:        []string{"u"},
+			Description: "Undo",
+			ShortHelp:   "u",
+			Handler:     handleUndoKey,
+			AvailableIn: []ViewMode{ModeCollections, ModeRequests, ModeEnvironments, ModeVariables},
+		},
+		{
+			Keys:        []string{"U", "ctrl+r"},
+			Description: "Redo",
+			ShortHelp:   "U",
+			Handler:     handleRedoKey,
+			AvailableIn: []ViewMode{ModeCollections, ModeEnvironments, ModeVariables},
+		},
+		{
+			Keys:        []string{"U"},
+			Description: "Redo",
+			ShortHelp:   "U",
+			Handler:     handleRedoKey,
+			AvailableIn: []ViewMode{ModeRequests},
+		},
+		{
+			Keys:        []string{"y"},
+			Description: "Copy as snippet",
+			ShortHelp:   "y",
+			Handler:     handleSnippetKey,
+			AvailableIn: []ViewMode{ModeRequests, ModeResponse},
+		},
+		{
+			Keys:        []string{"S"},
+			Description: "Save as example",
+			ShortHelp:   "S",
+			Handler:     handleSaveExampleKey,
+			AvailableIn: []ViewMode{ModeResponse},
+		},
+		{
+			Keys:        []string{"v"},
+			Description: "Switch body view (pretty, raw, hex)",
+			ShortHelp:   "v",
+			Handler:     handleBodyViewKey,
+			AvailableIn: []ViewMode{ModeResponse},
+		},
+		{
+			Keys:        []string{"c"},
+			Description: "Show or hide connection details",
+			ShortHelp:   "c",
+			Handler:     handleConnectionKey,
+			AvailableIn: []ViewMode{ModeResponse},
+		},
+		{
+			Keys:        []string{"p", " "},
+			Description: "Pause or resume run",
+			ShortHelp:   "p",
+			Handler:     handleRunnerPauseKey,
+			AvailableIn: []ViewMode{ModeRunner},
+		},
+		{
+			Keys:        []string{"x"},
+			Description: "Stop run",
+			ShortHelp:   "x",
+			Handler:     handleRunnerStopKey,
+			AvailableIn: []ViewMode{ModeRunner},
+		},
+		{
+			Keys:        []string{"x"},
+			Description: "Stop load test",
+			ShortHelp:   "x",
+			Handler:     handleLoadTestStopKey,
+			AvailableIn: []ViewMode{ModeLoadTest},
+		},
+		{
+			Keys:        []string{"F"},
+			Description: "Filter response body",
+			ShortHelp:   "F",
+			Handler:     handleFilterKey,
+			AvailableIn: []ViewMode{ModeResponse},
+		},
+		{
+			Keys:        []string{"V"},
+			Description: "Save filtered value as variable",
+			ShortHelp:   "V",
+			Handler:     handleSetVarKey,
+			AvailableIn: []ViewMode{ModeResponse},
+		},
 		{
 			Keys:        []string{"E"},
 			Description: "Edit scripts",
@@ -303,6 +600,13 @@ func (cr *CommandRegistry) registerKeyBindings() {
 			Handler:     handleDiscardSelectedKey,
 			AvailableIn: []ViewMode{ModeChanges},
 		},
+		{
+			Keys:        []string{"a"},
+			Description: "Apply spec sync",
+			ShortHelp:   "a",
+			Handler:     handleApplySyncKey,
+			AvailableIn: []ViewMode{ModeChanges},
+		},
 		{
 			Keys:        []string{"ctrl+d"},
 			Description: "Discard all",
@@ -353,6 +657,20 @@ func (cr *CommandRegistry) HandleKey(m Model, key string) (Model, tea.Cmd, bool)
 	return m, nil, false
 }
 
+func (cr *CommandRegistry) HasKeyBinding(mode ViewMode, key string) bool {
+	for _, kb := range cr.keyBindings {
+		if !isInModes(mode, kb.AvailableIn) {
+			continue
+		}
+		for _, k := range kb.Keys {
+			if k == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (cr *CommandRegistry) GenerateHelpText() string {
 	var parts []string
 	seen := make(map[string]bool)
@@ -505,7 +823,7 @@ func handleInfoCommand(m Model, args []string) (Model, tea.Cmd) {
 		m.infoViewport.Height = m.height - 8
 		lines := m.buildItemInfoLines()
 		content := strings.Join(lines, "\n")
-		m.infoViewport.SetContent(content)
+		m = m.setViewportContent(ModeInfo, content)
 
 		m.statusMessage = "Showing item info (q to close)"
 	} else if m.mode == ModeCollections {
@@ -559,9 +877,16 @@ func handleChangesCommand(m Model, args []string) (Model, tea.Cmd) {
 	m.mode = ModeChanges
 	m.cursor = 0
 	m.items = []string{}
+	m.changeIDs = []string{}
 
 	for itemID := range m.modifiedRequests {
-		m.items = append(m.items, itemID)
+		m.changeIDs = append(m.changeIDs, itemID)
+	}
+	sort.Slice(m.changeIDs, func(i, j int) bool {
+		return m.changeLabel(m.changeIDs[i]) < m.changeLabel(m.changeIDs[j])
+	})
+	for _, itemID := range m.changeIDs {
+		m.items = append(m.items, m.changeLabel(itemID))
 	}
 
 	if len(m.items) == 0 {
@@ -602,6 +927,138 @@ func handleDeleteCommand(m Model, args []string) (Model, tea.Cmd) {
 	return m, nil
 }
 
+func handleUndoCommand(m Model, args []string) (Model, tea.Cmd) {
+	return m.undo(), nil
+}
+
+func handleRedoCommand(m Model, args []string) (Model, tea.Cmd) {
+	return m.redo(), nil
+}
+
+func handleNewCommand(m Model, args []string) (Model, tea.Cmd) {
+	return m.createRequest(strings.Join(args, " ")), nil
+}
+
+func handleMkdirCommand(m Model, args []string) (Model, tea.Cmd) {
+	return m.createFolder(strings.Join(args, " ")), nil
+}
+
+func handleMoveCommand(m Model, args []string) (Model, tea.Cmd) {
+	return m.moveItemToFolder(strings.Join(args, " ")), nil
+}
+
+func handleRenameCommand(m Model, args []string) (Model, tea.Cmd) {
+	return m.renameItem(strings.Join(args, " ")), nil
+}
+
+func handleResyncCommand(m Model, args []string) (Model, tea.Cmd) {
+	specPath := ""
+	if len(args) > 0 {
+		specPath = strings.Join(args, " ")
+	}
+	m = m.startResync(specPath)
+	return m, nil
+}
+
+func handleNewCollectionCommand(m Model, args []string) (Model, tea.Cmd) {
+	name, path, ok := splitNameAndPath(args)
+	if !ok {
+		m.statusMessage = "Usage: :newcollection <name> <path>"
+		return m, nil
+	}
+	return m.createCollection(name, path), nil
+}
+
+func handleNewEnvironmentCommand(m Model, args []string) (Model, tea.Cmd) {
+	name, path, ok := splitNameAndPath(args)
+	if !ok {
+		m.statusMessage = "Usage: :newenv <name> <path>"
+		return m, nil
+	}
+	return m.createEnvironment(name, path), nil
+}
+
+func handleSaveAsCommand(m Model, args []string) (Model, tea.Cmd) {
+	path := strings.TrimSpace(strings.Join(args, " "))
+	if path == "" {
+		m.statusMessage = "Usage: :saveas <path>"
+		return m, nil
+	}
+	return m.saveAs(path), nil
+}
+
+func splitNameAndPath(args []string) (string, string, bool) {
+	fields := strings.Fields(strings.Join(args, " "))
+	if len(fields) < 2 {
+		return "", "", false
+	}
+	return strings.Join(fields[:len(fields)-1], " "), fields[len(fields)-1], true
+}
+
+func handleCurlCommand(m Model, args []string) (Model, tea.Cmd) {
+	if len(args) == 0 || strings.TrimSpace(args[0]) == "" {
+		m.statusMessage = "Usage: :curl <curl command>"
+		return m, nil
+	}
+	// args[0] is the raw remainder of the command line; keep it intact so
+	// whitespace inside quoted arguments reaches the curl tokenizer.
+	command := strings.TrimSpace(args[0])
+	if fields := strings.Fields(command); !strings.EqualFold(fields[0], "curl") {
+		command = "curl " + command
+	}
+	m = m.importCurl(command)
+	return m, nil
+}
+
+func handleSnippetCommand(m Model, args []string) (Model, tea.Cmd) {
+	fields := []string{}
+	if len(args) > 0 {
+		fields = strings.Fields(strings.Join(args, " "))
+	}
+	if len(fields) == 0 {
+		return m.openSnippetPicker(), nil
+	}
+
+	target := strings.ToLower(fields[0])
+	if len(fields) == 1 {
+		return m.copySnippet(target)
+	}
+
+	return m.writeSnippet(target, strings.Join(fields[1:], " ")), nil
+}
+
+func handleExamplesCommand(m Model, args []string) (Model, tea.Cmd) {
+	return m.openExamplePicker(), nil
+}
+
+func handleSaveExampleCommand(m Model, args []string) (Model, tea.Cmd) {
+	return m.saveResponseAsExample(strings.Join(args, " ")), nil
+}
+
+func handleExportCommand(m Model, args []string) (Model, tea.Cmd) {
+	fields := []string{}
+	if len(args) > 0 {
+		fields = strings.Fields(strings.Join(args, " "))
+	}
+	if len(fields) < 2 {
+		m.statusMessage = "Usage: :export openapi|har <path>"
+		return m, nil
+	}
+
+	format := strings.ToLower(fields[0])
+	path := strings.Join(fields[1:], " ")
+
+	switch format {
+	case "openapi":
+		m = m.exportOpenAPI(path)
+	case "har":
+		m = m.exportHAR(path)
+	default:
+		m.statusMessage = fmt.Sprintf("Unknown export format: %s", format)
+	}
+	return m, nil
+}
+
 func handleQuitCommand(m Model, args []string) (Model, tea.Cmd) {
 	m.saveSession()
 	return m, tea.Quit
@@ -626,6 +1083,8 @@ func handleLogsCommand(m Model, args []string) (Model, tea.Cmd) {
 	m.previousMode = m.mode
 	m.mode = ModeLog
 	m.scrollOffset = 0
+	m = m.refreshLogsViewport()
+	m.logsViewport.GotoBottom()
 	m.statusMessage = "Showing session logs (j/k to scroll, esc to close)"
 	return m, nil
 }
@@ -635,7 +1094,14 @@ func handleQuitKey(m Model) (Model, tea.Cmd) {
 	return m, tea.Quit
 }
 
+func handleSnippetKey(m Model) (Model, tea.Cmd) {
+	return m.openSnippetPicker(), nil
+}
+
 func handleEnterKey(m Model) (Model, tea.Cmd) {
+	if m.mode == ModeRunner {
+		return m.openRunnerResult(), nil
+	}
 	if m.mode == ModeRequests {
 		if len(m.currentItems) > 0 && m.cursor < len(m.currentItems) {
 			item := m.currentItems[m.cursor]
@@ -646,9 +1112,11 @@ func handleEnterKey(m Model) (Model, tea.Cmd) {
 		return m, nil
 	}
 	if m.mode == ModeChanges {
-		if m.cursor < len(m.items) {
-			itemID := m.items[m.cursor]
-			m = m.navigateToChangedRequest(itemID)
+		if m.pendingSync != nil {
+			return m.showSyncChangeDetails(m.cursor), nil
+		}
+		if m.cursor < len(m.changeIDs) {
+			m = m.navigateToChangedRequest(m.changeIDs[m.cursor])
 		}
 		return m, nil
 	}
@@ -682,6 +1150,7 @@ func handleResponseViewKey(m Model) (Model, tea.Cmd) {
 					m.lastResponse = exec.Response
 					m.lastTestResult = exec.TestResult
 					m.lastExecutedItemID = itemID
+					m.responseExample = ""
 
 					m.scrollOffset = 0
 					m.mode = ModeResponse
@@ -690,7 +1159,7 @@ func handleResponseViewKey(m Model) (Model, tea.Cmd) {
 					m.responseViewport.Height = m.height - 8
 					lines := m.buildResponseLines()
 					content := strings.Join(lines, "\n")
-					m.responseViewport.SetContent(content)
+					m = m.setViewportContent(ModeResponse, content)
 
 					m.statusMessage = "Showing response (ctrl+r to resend, q to close)"
 				} else {
@@ -715,7 +1184,7 @@ func handleInfoKey(m Model) (Model, tea.Cmd) {
 		m.infoViewport.Height = m.height - 8
 		lines := m.buildItemInfoLines()
 		content := strings.Join(lines, "\n")
-		m.infoViewport.SetContent(content)
+		m = m.setViewportContent(ModeInfo, content)
 
 		m.statusMessage = "Showing item info (q to close)"
 	} else if m.mode == ModeEnvironments && m.environment != nil {
@@ -728,12 +1197,13 @@ func handleInfoKey(m Model) (Model, tea.Cmd) {
 		m.infoViewport.Height = m.height - 8
 		lines := m.buildEnvironmentInfoLines()
 		content := strings.Join(lines, "\n")
-		m.infoViewport.SetContent(content)
+		m = m.setViewportContent(ModeInfo, content)
 
 		m.statusMessage = "Showing environment info (q to close)"
-	} else if m.mode == ModeChanges && m.cursor < len(m.items) {
-		itemID := m.items[m.cursor]
-		m = m.showChangeDiff(itemID)
+	} else if m.mode == ModeChanges && m.pendingSync != nil {
+		m = m.showSyncChangeDetails(m.cursor)
+	} else if m.mode == ModeChanges && m.cursor < len(m.changeIDs) {
+		m = m.showChangeDiff(m.changeIDs[m.cursor])
 	}
 	return m, nil
 }
@@ -755,7 +1225,7 @@ func handleJSONKey(m Model) (Model, tea.Cmd) {
 		m.jsonViewport.Height = m.height - 8
 		title := lipgloss.NewStyle().Bold(true).Render("JSON View (q: close)")
 		fullContent := title + "\n\n" + m.jsonContent
-		m.jsonViewport.SetContent(fullContent)
+		m = m.setViewportContent(ModeJSON, fullContent)
 
 		m.statusMessage = "Showing JSON view (q to close)"
 	} else if m.mode == ModeEnvironments && m.cursor < len(m.items) {
@@ -775,7 +1245,7 @@ func handleJSONKey(m Model) (Model, tea.Cmd) {
 			m.jsonViewport.Height = m.height - 8
 			title := lipgloss.NewStyle().Bold(true).Render("JSON View (q: close)")
 			fullContent := title + "\n\n" + m.jsonContent
-			m.jsonViewport.SetContent(fullContent)
+			m = m.setViewportContent(ModeJSON, fullContent)
 
 			m.statusMessage = "Showing JSON view (q to close)"
 		}
@@ -793,6 +1263,16 @@ func handleSearchKey(m Model) (Model, tea.Cmd) {
 		m.statusMessage = "Enter search query (Esc to cancel, Enter to confirm)"
 		return m, m.searchInput.Focus()
 	}
+	if isSearchableViewport(m.mode) {
+		m.searchMode = true
+		m.searchInput.SetValue("")
+		if m.viewportSearch != nil && m.viewportSearch.mode == m.mode {
+			m.searchInput.SetValue(m.viewportSearch.pattern)
+			m.searchInput.CursorEnd()
+		}
+		m.statusMessage = "Search (regex, case-insensitive unless the pattern has capitals; Enter to confirm, Esc to cancel)"
+		return m, m.searchInput.Focus()
+	}
 	return m, nil
 }
 
@@ -803,9 +1283,72 @@ func handleRestoreSessionKey(m Model) (Model, tea.Cmd) {
 	return m, nil
 }
 
+func handleUndoKey(m Model) (Model, tea.Cmd) {
+	return m.undo(), nil
+}
+
+func handleRedoKey(m Model) (Model, tea.Cmd) {
+	return m.redo(), nil
+}
+
+func handleCutKey(m Model) (Model, tea.Cmd) {
+	return m.cutSelectedItem(), nil
+}
+
+func handlePasteKey(m Model) (Model, tea.Cmd) {
+	return m.pasteCutItem(), nil
+}
+
+func handleMoveDownKey(m Model) (Model, tea.Cmd) {
+	return m.reorderSelectedItem(1), nil
+}
+
+func handleMoveUpKey(m Model) (Model, tea.Cmd) {
+	return m.reorderSelectedItem(-1), nil
+}
+
+func handleSaveExampleKey(m Model) (Model, tea.Cmd) {
+	if m.lastResponse == nil || m.lastResponse.Error != nil || m.responseExample != "" {
+		m.statusMessage = "Only a successful live response can be saved as an example"
+		return m, nil
+	}
+	m.commandMode = true
+	m.commandInput.SetValue("saveexample " + m.lastResponse.Status)
+	m.commandInput.CursorEnd()
+	return m, m.commandInput.Focus()
+}
+
 func handleBackKey(m Model) (Model, tea.Cmd) {
+	if m.viewportSearch != nil && m.viewportSearch.mode == m.mode {
+		m = m.clearViewportSearch()
+		m.statusMessage = "Search cleared"
+		return m, nil
+	}
+	if m.mode == ModeResponse && m.runner != nil && m.runner.drilled {
+		m.runner.drilled = false
+		m.mode = ModeRunner
+		m.statusMessage = "Back to run"
+		return m, nil
+	}
+	if m.mode == ModeRunner {
+		m.mode = m.previousMode
+		if m.mode == ModeRunner || m.mode == 0 {
+			m.mode = ModeRequests
+		}
+		m.statusMessage = "Closed runner view"
+		return m, nil
+	}
+	if m.mode == ModeLoadTest {
+		m.mode = m.previousMode
+		if m.collection == nil {
+			m.mode = ModeCollections
+		}
+		m.statusMessage = "Closed load test view"
+		return m, nil
+	}
 	if m.mode == ModeResponse {
 		m.mode = ModeRequests
+		m.responseExample = ""
 		m.scrollOffset = 0
 		m.statusMessage = "Closed response view"
 		return m, nil
@@ -817,6 +1360,7 @@ func handleBackKey(m Model) (Model, tea.Cmd) {
 			m.mode = ModeRequests
 		}
 		m.currentInfoItem = nil
+		m.diffItemID = ""
 		m.scrollOffset = 0
 		m.statusMessage = "Closed info view"
 		return m, nil
@@ -842,6 +1386,9 @@ func handleBackKey(m Model) (Model, tea.Cmd) {
 		m.statusMessage = "Closed logs view"
 		return m, nil
 	}
+	if m.mode == ModeChanges && m.pendingSync != nil {
+		return m.cancelResync(), nil
+	}
 	if m.mode == ModeChanges {
 		m.mode = m.previousMode
 		m.statusMessage = "Closed changes view"
@@ -862,8 +1409,31 @@ func handleBackKey(m Model) (Model, tea.Cmd) {
 	return m, nil
 }
 
+func handleDiffLayoutKey(m Model) (Model, tea.Cmd) {
+	if m.diffItemID == "" {
+		return m, nil
+	}
+
+	m.diffSideBySide = !m.diffSideBySide
+	offset := m.infoViewport.YOffset
+	m = m.showChangeDiff(m.diffItemID)
+	m.infoViewport.SetYOffset(offset)
+	if m.diffSideBySide && m.width < sideBySideMinWidth {
+		m.statusMessage = fmt.Sprintf("Side-by-side diff needs a terminal at least %d columns wide", sideBySideMinWidth)
+	} else if m.diffSideBySide {
+		m.statusMessage = "Showing side-by-side diff"
+	} else {
+		m.statusMessage = "Showing unified diff"
+	}
+	return m, nil
+}
+
 func handleUpKey(m Model) (Model, tea.Cmd) {
-	if m.mode == ModeInfo && m.previousMode == ModeEnvironments && m.environment != nil {
+	if m.mode == ModeRunner {
+		if m.runner != nil && m.runner.cursor > 0 {
+			m.runner.cursor--
+		}
+	} else if m.mode == ModeInfo && m.previousMode == ModeEnvironments && m.environment != nil {
 		if m.envVarCursor > 0 {
 			m.envVarCursor--
 		}
@@ -880,7 +1450,11 @@ func handleUpKey(m Model) (Model, tea.Cmd) {
 }
 
 func handleDownKey(m Model) (Model, tea.Cmd) {
-	if m.mode == ModeInfo && m.previousMode == ModeEnvironments && m.environment != nil {
+	if m.mode == ModeRunner {
+		if m.runner != nil && m.runner.cursor < len(m.runner.results)-1 {
+			m.runner.cursor++
+		}
+	} else if m.mode == ModeInfo && m.previousMode == ModeEnvironments && m.environment != nil {
 		if m.envVarCursor < len(m.environment.Values)-1 {
 			m.envVarCursor++
 		}
@@ -923,43 +1497,52 @@ func handleEditScriptKey(m Model) (Model, tea.Cmd) {
 }
 
 func handleDiscardSelectedKey(m Model) (Model, tea.Cmd) {
-	if m.mode == ModeChanges && m.cursor < len(m.items) {
-		itemID := m.items[m.cursor]
-		parts := strings.Split(itemID, "/")
-		if len(parts) > 0 {
-			collectionName := parts[0]
+	if m.mode == ModeChanges && m.pendingSync != nil {
+		return m.skipSyncChange(m.cursor), nil
+	}
+	if m.mode == ModeChanges && m.cursor < len(m.changeIDs) {
+		itemID := m.changeIDs[m.cursor]
+		label := m.items[m.cursor]
 
-			delete(m.modifiedRequests, itemID)
-			delete(m.modifiedItems, itemID)
+		collectionName := ""
+		if location, err := m.locateItem(itemID); err == nil {
+			collectionName = location.collection.Info.Name
+		}
 
-			if path, exists := m.parser.GetCollectionPath(collectionName); exists {
-				m.parser.LoadCollection(path)
-				if m.collection != nil && m.collection.Info.Name == collectionName {
-					if newCollection, exists := m.parser.GetCollection(collectionName); exists {
-						m.collection = newCollection
-					}
+		delete(m.modifiedRequests, itemID)
+		delete(m.modifiedItems, itemID)
+
+		if path, exists := m.parser.GetCollectionPath(collectionName); exists {
+			m.parser.LoadCollection(path)
+			if m.collection != nil && m.collection.Info.Name == collectionName {
+				if newCollection, exists := m.parser.GetCollection(collectionName); exists {
+					m.collection = newCollection
 				}
 			}
+		}
 
-			m.items = append(m.items[:m.cursor], m.items[m.cursor+1:]...)
+		m.items = append(m.items[:m.cursor], m.items[m.cursor+1:]...)
+		m.changeIDs = append(m.changeIDs[:m.cursor], m.changeIDs[m.cursor+1:]...)
 
-			if len(m.items) == 0 {
-				m.mode = m.previousMode
-				m.modifiedCollections = make(map[string]bool)
-				m = m.refreshCurrentView()
-				m.statusMessage = "All changes discarded and reloaded from file"
-			} else {
-				if m.cursor >= len(m.items) {
-					m.cursor = len(m.items) - 1
-				}
-				m.statusMessage = fmt.Sprintf("Discarded changes to %s and reloaded from file", itemID)
+		if len(m.items) == 0 {
+			m.mode = m.previousMode
+			m.modifiedCollections = make(map[string]bool)
+			m = m.refreshCurrentView()
+			m.statusMessage = "All changes discarded and reloaded from file"
+		} else {
+			if m.cursor >= len(m.items) {
+				m.cursor = len(m.items) - 1
 			}
+			m.statusMessage = fmt.Sprintf("Discarded changes to %s and reloaded from file", label)
 		}
 	}
 	return m, nil
 }
 
 func handleDiscardAllKey(m Model) (Model, tea.Cmd) {
+	if m.mode == ModeChanges && m.pendingSync != nil {
+		return m.cancelResync(), nil
+	}
 	if m.mode == ModeChanges {
 		collectionsToReload := make(map[string]bool)
 		for collectionName := range m.modifiedCollections {
@@ -989,6 +1572,13 @@ func handleDiscardAllKey(m Model) (Model, tea.Cmd) {
 	return m, nil
 }
 
+func handleApplySyncKey(m Model) (Model, tea.Cmd) {
+	if m.mode == ModeChanges && m.pendingSync != nil {
+		m = m.applyResync()
+	}
+	return m, nil
+}
+
 func handleEditScriptCommand(m Model, args []string) (Model, tea.Cmd) {
 	if m.mode == ModeRequests && m.cursor < len(m.currentItems) {
 		item := m.currentItems[m.cursor]
@@ -0,0 +1,141 @@
+package tui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"postOffice/internal/postman"
+)
+
+func createSavedTestModel(t *testing.T) Model {
+	t.Helper()
+	collectionPath := filepath.Join(t.TempDir(), "collection.json")
+	data, _ := json.Marshal(createMockCollection())
+	if err := os.WriteFile(collectionPath, data, 0644); err != nil {
+		t.Fatalf("Failed to write collection: %v", err)
+	}
+
+	parser := postman.NewParser()
+	collection, err := parser.LoadCollection(collectionPath)
+	if err != nil {
+		t.Fatalf("Failed to load collection: %v", err)
+	}
+
+	m := NewModel(parser)
+	m.collection = collection
+	m.mode = ModeRequests
+	m = m.loadRequestsList()
+	return m
+}
+
+func TestCurlCommand_InsertsIntoCurrentFolder(t *testing.T) {
+	m := createSavedTestModel(t)
+	m.breadcrumb = []string{"Test Folder"}
+	m = m.refreshCurrentView()
+
+	m, _ = handleCurlCommand(m, []string{`-X POST https://example.com/api/items -H 'Content-Type: application/json' -d '{"a":1}'`})
+
+	if !contains(m.statusMessage, "Imported request") {
+		t.Fatalf("Expected import to succeed, got: %s", m.statusMessage)
+	}
+	folder := m.collection.Items[0]
+	if len(folder.Items) != 2 {
+		t.Fatalf("Expected request inserted into folder, got %d items", len(folder.Items))
+	}
+	imported := folder.Items[1]
+	if imported.Request.Method != "POST" || imported.Request.Body.Raw != `{"a":1}` {
+		t.Errorf("Unexpected imported request: %+v", imported.Request)
+	}
+	if m.cursor != len(m.currentItems)-1 {
+		t.Errorf("Expected cursor on imported request, got %d", m.cursor)
+	}
+	if !m.modifiedCollections[m.collection.Info.Name] {
+		t.Error("Expected collection to be marked modified")
+	}
+}
+
+func TestCurlCommand_AcceptsPastedCurlPrefix(t *testing.T) {
+	m := createSavedTestModel(t)
+
+	m, _ = handleCurlCommand(m, []string{"curl https://example.com/health"})
+
+	last := m.collection.Items[len(m.collection.Items)-1]
+	if last.Name != "GET /health" {
+		t.Errorf("Expected imported 'GET /health', got '%s'", last.Name)
+	}
+}
+
+func TestCurlCommand_InvalidInput(t *testing.T) {
+	m := createSavedTestModel(t)
+	count := len(m.collection.Items)
+
+	m, _ = handleCurlCommand(m, []string{"-H 'a: b'"})
+
+	if !contains(m.statusMessage, "Failed to parse curl command") {
+		t.Errorf("Expected parse error, got: %s", m.statusMessage)
+	}
+	if len(m.collection.Items) != count {
+		t.Error("Expected collection to be unchanged")
+	}
+
+	m, _ = handleCurlCommand(m, []string{})
+	if !contains(m.statusMessage, "Usage") {
+		t.Errorf("Expected usage message, got: %s", m.statusMessage)
+	}
+}
+
+func TestCurlCommand_KeepsHARCaptureUntouched(t *testing.T) {
+	harPath := filepath.Join(t.TempDir(), "capture.har")
+	capture := `{"log":{"version":"1.2","creator":{"name":"test","version":"1"},"entries":[` +
+		`{"request":{"method":"GET","url":"https://example.com/a","headers":[]},"response":{"status":200,"headers":[],"content":{}}}]}}`
+	if err := os.WriteFile(harPath, []byte(capture), 0644); err != nil {
+		t.Fatalf("Failed to write HAR: %v", err)
+	}
+
+	parser := postman.NewParser()
+	collection, err := parser.LoadCollection(harPath)
+	if err != nil {
+		t.Fatalf("Failed to load HAR: %v", err)
+	}
+	m := NewModel(parser)
+	m.collection = collection
+	m.mode = ModeRequests
+	m = m.loadRequestsList()
+
+	m, _ = handleCurlCommand(m, []string{`https://example.com/b`})
+	m = m.saveAllModifiedRequests()
+
+	if data, _ := os.ReadFile(harPath); string(data) != capture {
+		t.Fatal("Expected HAR capture not to be overwritten")
+	}
+	if !m.modifiedCollections["capture"] {
+		t.Error("Expected HAR collection to stay modified until :saveas")
+	}
+}
+
+func TestCurlCommand_KeepsWhitespaceInQuotedArguments(t *testing.T) {
+	m := createSavedTestModel(t)
+	body := "{\"a\":  \"b\\n  c\"}"
+	m.commandInput.SetValue(`curl -X POST https://example.com/api/items -H 'X-Padded:  a   b' -d '` + body + `'`)
+
+	m, _ = m.executeCommand()
+
+	if !contains(m.statusMessage, "Imported request") {
+		t.Fatalf("Expected import to succeed, got: %s", m.statusMessage)
+	}
+	imported := m.collection.Items[len(m.collection.Items)-1].Request
+	if imported.Body == nil || imported.Body.Raw != body {
+		t.Errorf("Expected body %q, got %+v", body, imported.Body)
+	}
+	var padded string
+	for _, header := range imported.Header {
+		if header.Key == "X-Padded" {
+			padded = header.Value
+		}
+	}
+	if padded != "a   b" {
+		t.Errorf("Expected header value %q, got %q", "a   b", padded)
+	}
+}
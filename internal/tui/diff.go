@@ -0,0 +1,399 @@
+package tui
+
+import (
+	"fmt"
+	"postOffice/internal/postman"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+type diffOp int
+
+const (
+	diffEqual diffOp = iota
+	diffDelete
+	diffInsert
+)
+
+const (
+	diffContextLines   = 3
+	diffMaxCells       = 4000000
+	sideBySideMinWidth = 120
+)
+
+var (
+	diffAddedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	diffRemovedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	diffChangedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
+	diffHunkStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+	diffContextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+)
+
+type diffLine struct {
+	op   diffOp
+	text string
+}
+
+func diffLines(a, b []string) []diffLine {
+	if len(a)*len(b) > diffMaxCells {
+		var result []diffLine
+		for _, line := range a {
+			result = append(result, diffLine{op: diffDelete, text: line})
+		}
+		for _, line := range b {
+			result = append(result, diffLine{op: diffInsert, text: line})
+		}
+		return result
+	}
+
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var result []diffLine
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			result = append(result, diffLine{op: diffEqual, text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			result = append(result, diffLine{op: diffDelete, text: a[i]})
+			i++
+		default:
+			result = append(result, diffLine{op: diffInsert, text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		result = append(result, diffLine{op: diffDelete, text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		result = append(result, diffLine{op: diffInsert, text: b[j]})
+	}
+	return result
+}
+
+func hasDiffChanges(lines []diffLine) bool {
+	for _, line := range lines {
+		if line.op != diffEqual {
+			return true
+		}
+	}
+	return false
+}
+
+func renderUnifiedDiff(lines []diffLine) []string {
+	var output []string
+
+	start := 0
+	for start < len(lines) {
+		for start < len(lines) && lines[start].op == diffEqual {
+			start++
+		}
+		if start >= len(lines) {
+			break
+		}
+
+		hunkStart := max(start-diffContextLines, 0)
+		hunkEnd := start
+		for hunkEnd < len(lines) {
+			if lines[hunkEnd].op != diffEqual {
+				hunkEnd++
+				continue
+			}
+			run := hunkEnd
+			for run < len(lines) && lines[run].op == diffEqual {
+				run++
+			}
+			if run == len(lines) || run-hunkEnd > 2*diffContextLines {
+				hunkEnd = min(hunkEnd+diffContextLines, len(lines))
+				break
+			}
+			hunkEnd = run
+		}
+
+		oldLine, newLine := 1, 1
+		for _, line := range lines[:hunkStart] {
+			if line.op != diffInsert {
+				oldLine++
+			}
+			if line.op != diffDelete {
+				newLine++
+			}
+		}
+		oldCount, newCount := 0, 0
+		for _, line := range lines[hunkStart:hunkEnd] {
+			if line.op != diffInsert {
+				oldCount++
+			}
+			if line.op != diffDelete {
+				newCount++
+			}
+		}
+
+		output = append(output, diffHunkStyle.Render(fmt.Sprintf("@@ -%d,%d +%d,%d @@", oldLine, oldCount, newLine, newCount)))
+		for _, line := range lines[hunkStart:hunkEnd] {
+			output = append(output, renderDiffLine(line))
+		}
+
+		start = hunkEnd
+	}
+
+	return output
+}
+
+func renderDiffLine(line diffLine) string {
+	text := expandTabs(line.text)
+	switch line.op {
+	case diffDelete:
+		return diffRemovedStyle.Render("-" + text)
+	case diffInsert:
+		return diffAddedStyle.Render("+" + text)
+	default:
+		return diffContextStyle.Render(" " + text)
+	}
+}
+
+func renderSideBySideDiff(lines []diffLine, width int) []string {
+	columnWidth := (width - 3) / 2
+	if columnWidth < 10 {
+		return renderUnifiedDiff(lines)
+	}
+
+	var output []string
+	row := func(left, right string, leftStyle, rightStyle lipgloss.Style) {
+		output = append(output, leftStyle.Render(fitColumn(left, columnWidth))+" │ "+rightStyle.Render(fitColumn(right, columnWidth)))
+	}
+
+	row("Original", "Modified", lipgloss.NewStyle().Bold(true), lipgloss.NewStyle().Bold(true))
+	output = append(output, strings.Repeat("─", columnWidth)+"─┼─"+strings.Repeat("─", columnWidth))
+
+	for i := 0; i < len(lines); {
+		if lines[i].op == diffEqual {
+			row(" "+lines[i].text, " "+lines[i].text, diffContextStyle, diffContextStyle)
+			i++
+			continue
+		}
+
+		var deleted, inserted []string
+		for i < len(lines) && lines[i].op != diffEqual {
+			if lines[i].op == diffDelete {
+				deleted = append(deleted, lines[i].text)
+			} else {
+				inserted = append(inserted, lines[i].text)
+			}
+			i++
+		}
+
+		for j := 0; j < max(len(deleted), len(inserted)); j++ {
+			left, right := "", ""
+			leftStyle, rightStyle := diffContextStyle, diffContextStyle
+			if j < len(deleted) {
+				left = "-" + deleted[j]
+				leftStyle = diffRemovedStyle
+			}
+			if j < len(inserted) {
+				right = "+" + inserted[j]
+				rightStyle = diffAddedStyle
+			}
+			row(left, right, leftStyle, rightStyle)
+		}
+	}
+
+	return output
+}
+
+func fitColumn(text string, width int) string {
+	runes := []rune(expandTabs(text))
+	if len(runes) > width {
+		if width > 1 {
+			return string(runes[:width-1]) + "…"
+		}
+		return string(runes[:width])
+	}
+	return string(runes) + strings.Repeat(" ", width-len(runes))
+}
+
+func expandTabs(text string) string {
+	return strings.ReplaceAll(text, "\t", "    ")
+}
+
+func (m Model) buildRequestDiffLines(name string, original *postman.Item, modified *postman.Item) []string {
+	var lines []string
+	lines = append(lines, lipgloss.NewStyle().Bold(true).Render("Diff: "+name+" (original → modified) (s: toggle layout, q: close)"))
+	lines = append(lines, "")
+
+	sideBySide := m.diffSideBySide && m.width >= sideBySideMinWidth
+	changed := false
+
+	if original.Name != modified.Name {
+		changed = true
+		lines = append(lines, requestStyle.Render("Name:"))
+		lines = append(lines, diffChangedStyle.Render("~ "+original.Name+" → "+modified.Name))
+		lines = append(lines, "")
+	}
+
+	originalReq := original.Request
+	if originalReq == nil {
+		originalReq = &postman.Request{}
+	}
+	modifiedReq := modified.Request
+	if modifiedReq == nil {
+		modifiedReq = &postman.Request{}
+	}
+
+	lines = append(lines, requestStyle.Render("Request:"))
+	for _, field := range []struct{ label, old, new string }{
+		{"Method", originalReq.Method, modifiedReq.Method},
+		{"URL", originalReq.URL.Raw, modifiedReq.URL.Raw},
+	} {
+		if field.old == field.new {
+			lines = append(lines, diffContextStyle.Render("  "+field.label+": "+field.new))
+		} else {
+			changed = true
+			lines = append(lines, diffChangedStyle.Render("~ "+field.label+": "+field.old+" → "+field.new))
+		}
+	}
+	lines = append(lines, "")
+
+	headerLines, headersChanged := diffHeaders(originalReq.Header, modifiedReq.Header)
+	if len(headerLines) > 0 {
+		lines = append(lines, requestStyle.Render("Headers:"))
+		lines = append(lines, headerLines...)
+		lines = append(lines, "")
+	}
+	changed = changed || headersChanged
+
+	originalMode, modifiedMode := "", ""
+	if originalReq.Body != nil {
+		originalMode = originalReq.Body.Mode
+	}
+	if modifiedReq.Body != nil {
+		modifiedMode = modifiedReq.Body.Mode
+	}
+	bodyDiff := diffLines(bodyDiffLines(originalReq.Body), bodyDiffLines(modifiedReq.Body))
+	if len(bodyDiff) > 0 || originalMode != modifiedMode {
+		lines = append(lines, requestStyle.Render("Body:"))
+		if originalMode != modifiedMode {
+			changed = true
+			lines = append(lines, diffChangedStyle.Render("~ Mode: "+originalMode+" → "+modifiedMode))
+		}
+		if hasDiffChanges(bodyDiff) {
+			changed = true
+		}
+		lines = append(lines, m.renderDiffBlock(bodyDiff, sideBySide)...)
+		lines = append(lines, "")
+	}
+
+	for _, script := range []struct{ label, listen string }{
+		{"Pre-request Script:", "prerequest"},
+		{"Tests:", "test"},
+	} {
+		scriptDiff := diffLines(scriptExec(original.Events, script.listen), scriptExec(modified.Events, script.listen))
+		if len(scriptDiff) == 0 {
+			continue
+		}
+		if hasDiffChanges(scriptDiff) {
+			changed = true
+		}
+		lines = append(lines, requestStyle.Render(script.label))
+		lines = append(lines, m.renderDiffBlock(scriptDiff, sideBySide)...)
+		lines = append(lines, "")
+	}
+
+	if !changed {
+		lines = append(lines, diffContextStyle.Render("No differences from the saved collection file"))
+	}
+
+	return lines
+}
+
+func (m Model) renderDiffBlock(lines []diffLine, sideBySide bool) []string {
+	if !hasDiffChanges(lines) {
+		return []string{diffContextStyle.Render("  (unchanged)")}
+	}
+	if sideBySide {
+		return renderSideBySideDiff(lines, m.width-12)
+	}
+	return renderUnifiedDiff(lines)
+}
+
+func diffHeaders(original, modified []postman.Header) ([]string, bool) {
+	var lines []string
+	changed := false
+
+	modifiedByKey := make(map[string]postman.Header)
+	for _, header := range modified {
+		modifiedByKey[strings.ToLower(header.Key)] = header
+	}
+	originalKeys := make(map[string]bool)
+
+	for _, header := range original {
+		key := strings.ToLower(header.Key)
+		originalKeys[key] = true
+		updated, exists := modifiedByKey[key]
+		switch {
+		case !exists:
+			changed = true
+			lines = append(lines, diffRemovedStyle.Render("- "+header.Key+": "+header.Value))
+		case updated.Value != header.Value:
+			changed = true
+			lines = append(lines, diffChangedStyle.Render("~ "+header.Key+": "+header.Value+" → "+updated.Value))
+		default:
+			lines = append(lines, diffContextStyle.Render("  "+header.Key+": "+header.Value))
+		}
+	}
+
+	for _, header := range modified {
+		if !originalKeys[strings.ToLower(header.Key)] {
+			changed = true
+			lines = append(lines, diffAddedStyle.Render("+ "+header.Key+": "+header.Value))
+		}
+	}
+
+	return lines, changed
+}
+
+func bodyDiffLines(body *postman.Body) []string {
+	if body == nil {
+		return nil
+	}
+	if body.Mode == "formdata" {
+		var lines []string
+		for _, param := range body.FormData {
+			if param.Type == "file" {
+				lines = append(lines, param.Key+"=@"+param.Src)
+			} else {
+				lines = append(lines, param.Key+"="+param.Value)
+			}
+		}
+		return lines
+	}
+	if body.Raw == "" {
+		return nil
+	}
+	return splitLines(body.Raw)
+}
+
+func scriptExec(events []postman.Event, listen string) []string {
+	var lines []string
+	for _, event := range events {
+		if event.Listen == listen {
+			lines = append(lines, event.Script.Exec...)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,152 @@
+package tui
+
+import (
+	"fmt"
+	"postOffice/internal/postman"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestDiffLines(t *testing.T) {
+	diff := diffLines([]string{"a", "b", "c"}, []string{"a", "x", "c", "d"})
+
+	var ops []string
+	for _, line := range diff {
+		switch line.op {
+		case diffEqual:
+			ops = append(ops, " "+line.text)
+		case diffDelete:
+			ops = append(ops, "-"+line.text)
+		case diffInsert:
+			ops = append(ops, "+"+line.text)
+		}
+	}
+
+	expected := []string{" a", "-b", "+x", " c", "+d"}
+	if strings.Join(ops, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected %v, got %v", expected, ops)
+	}
+}
+
+func TestRenderUnifiedDiff_HunkHeaders(t *testing.T) {
+	var original, modified []string
+	for i := 0; i < 20; i++ {
+		line := fmt.Sprintf("line %d", i)
+		original = append(original, line)
+		modified = append(modified, line)
+	}
+	modified[1] = "changed"
+	modified[18] = "changed"
+
+	output := renderUnifiedDiff(diffLines(original, modified))
+
+	var hunks []string
+	for _, line := range output {
+		if plain := ansi.Strip(line); strings.HasPrefix(plain, "@@") {
+			hunks = append(hunks, plain)
+		}
+	}
+
+	expected := []string{"@@ -1,5 +1,5 @@", "@@ -16,5 +16,5 @@"}
+	if strings.Join(hunks, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected hunks %v, got %v", expected, hunks)
+	}
+}
+
+func TestDiffHeaders(t *testing.T) {
+	original := []postman.Header{
+		{Key: "Accept", Value: "text/plain"},
+		{Key: "X-Removed", Value: "1"},
+		{Key: "X-Same", Value: "same"},
+	}
+	modified := []postman.Header{
+		{Key: "accept", Value: "application/json"},
+		{Key: "X-Same", Value: "same"},
+		{Key: "X-Added", Value: "2"},
+	}
+
+	lines, changed := diffHeaders(original, modified)
+	if !changed {
+		t.Fatal("Expected headers to be reported as changed")
+	}
+
+	plain := ansi.Strip(strings.Join(lines, "\n"))
+	for _, want := range []string{
+		"~ Accept: text/plain → application/json",
+		"- X-Removed: 1",
+		"  X-Same: same",
+		"+ X-Added: 2",
+	} {
+		if !strings.Contains(plain, want) {
+			t.Errorf("Expected header diff to contain %q, got:\n%s", want, plain)
+		}
+	}
+}
+
+func TestShowChangeDiff_ComparesAgainstSavedFile(t *testing.T) {
+	m := createSavedTestModel(t)
+	m.width = 80
+	m.height = 40
+
+	edited := m.deepCopyRequest(m.collection.Items[1].Request)
+	edited.Method = "PUT"
+	edited.Header = append(edited.Header, postman.Header{Key: "Authorization", Value: "Bearer token"})
+	edited.Body.Raw = "{\"test\": \"changed\"}"
+	if !m.updateRequestInCollection(nil, "POST Request", "POST Request", edited) {
+		t.Fatal("Failed to update request in collection")
+	}
+	itemID := m.getRequestIdentifier(m.collection.Items[1])
+	m.modifiedRequests[itemID] = edited
+	m.modifiedItems[itemID] = true
+
+	m = m.showChangeDiff(itemID)
+
+	if m.mode != ModeInfo {
+		t.Fatalf("Expected ModeInfo, got %v (%s)", m.mode, m.statusMessage)
+	}
+
+	content := ansi.Strip(m.infoViewport.View())
+	for _, want := range []string{
+		"~ Method: POST → PUT",
+		"+ Authorization: Bearer token",
+		"-{\"test\": \"data\"}",
+		"+{\"test\": \"changed\"}",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected diff to contain %q, got:\n%s", want, content)
+		}
+	}
+}
+
+func TestShowChangeDiff_SideBySideToggle(t *testing.T) {
+	m := createSavedTestModel(t)
+	m.width = 160
+	m.height = 40
+
+	edited := m.deepCopyRequest(m.collection.Items[1].Request)
+	edited.Body.Raw = "{\"test\": \"changed\"}"
+	m.updateRequestInCollection(nil, "POST Request", "POST Request", edited)
+	itemID := m.getRequestIdentifier(m.collection.Items[1])
+	m.modifiedRequests[itemID] = edited
+
+	m = m.showChangeDiff(itemID)
+	if !strings.Contains(ansi.Strip(m.infoViewport.View()), "Original") {
+		t.Error("Expected side-by-side layout on a wide terminal")
+	}
+
+	m, _ = handleDiffLayoutKey(m)
+	if m.diffSideBySide {
+		t.Error("Expected side-by-side to be toggled off")
+	}
+	content := ansi.Strip(m.infoViewport.View())
+	if strings.Contains(content, "│") || !strings.Contains(content, "@@ -1,1 +1,1 @@") {
+		t.Errorf("Expected unified layout after toggle, got:\n%s", content)
+	}
+
+	m, _ = handleBackKey(m)
+	if m.diffItemID != "" {
+		t.Error("Expected diff state to be cleared when closing the view")
+	}
+}
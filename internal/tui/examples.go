@@ -0,0 +1,341 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"postOffice/internal/postman"
+	"sort"
+	"strings"
+
+	httpclient "postOffice/internal/http"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type examplePicker struct {
+	itemID   string
+	itemName string
+	examples []postman.Response
+	cursor   int
+}
+
+func (m Model) exampleTargetID() string {
+	if m.mode == ModeResponse && m.lastExecutedItemID != "" {
+		return m.lastExecutedItemID
+	}
+	if m.collection == nil || m.cursor >= len(m.currentItems) || !m.currentItems[m.cursor].IsRequest() {
+		return ""
+	}
+	return m.getRequestIdentifier(m.currentItems[m.cursor])
+}
+
+func (m Model) openExamplePicker() Model {
+	itemID := m.exampleTargetID()
+	if itemID == "" {
+		m.statusMessage = "No request selected"
+		return m
+	}
+
+	location, err := m.locateItem(itemID)
+	if err != nil {
+		m.statusMessage = err.Error()
+		return m
+	}
+	if len(location.item.Responses) == 0 {
+		m.statusMessage = fmt.Sprintf("No saved examples for %s", location.item.Name)
+		return m
+	}
+
+	m.examplePicker = &examplePicker{
+		itemID:   itemID,
+		itemName: location.item.Name,
+		examples: location.item.Responses,
+	}
+	m.statusMessage = "Examples: <enter> open, <c> compare with live response, <esc> cancel"
+	return m
+}
+
+func (m Model) handleExamplePickerKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	picker := m.examplePicker
+
+	switch msg.String() {
+	case "esc", "q":
+		m.examplePicker = nil
+		m.statusMessage = "Closed examples"
+	case "up", "k":
+		if picker.cursor > 0 {
+			picker.cursor--
+		}
+	case "down", "j":
+		if picker.cursor < len(picker.examples)-1 {
+			picker.cursor++
+		}
+	case "enter":
+		m.examplePicker = nil
+		return m.openExample(picker.itemID, picker.examples[picker.cursor]), nil
+	case "c":
+		m.examplePicker = nil
+		return m.compareExample(picker.itemID, picker.examples[picker.cursor]), nil
+	}
+
+	return m, nil
+}
+
+func (m Model) openExample(itemID string, example postman.Response) Model {
+	m.lastResponse = exampleToResponse(example)
+	m.lastTestResult = nil
+	m.lastExecutedItemID = itemID
+	m.responseExample = example.Name
+
+	m.scrollOffset = 0
+	m.mode = ModeResponse
+	m.responseViewport.Width = m.width - 8
+	m.responseViewport.Height = m.height - 8
+	m = m.setViewportContent(ModeResponse, strings.Join(m.buildResponseLines(), "\n"))
+	m.responseViewport.GotoTop()
+
+	m.statusMessage = fmt.Sprintf("Showing saved example '%s' (ctrl+r for a live call, q to close)", example.Name)
+	return m
+}
+
+func (m Model) compareExample(itemID string, example postman.Response) Model {
+	exec, exists := m.requestExecutions[itemID]
+	if !exists || exec.Response == nil {
+		m.statusMessage = "No live response to compare with. Execute the request first with ctrl+e"
+		return m
+	}
+	if exec.Response.Error != nil {
+		m.statusMessage = fmt.Sprintf("Live request failed: %v", exec.Response.Error)
+		return m
+	}
+
+	if exec.Response.Binary {
+		m.statusMessage = "Cannot compare a binary response with an example"
+		return m
+	}
+	liveBody, err := exec.Response.LoadBody()
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Cannot compare with the live response: %v", err)
+		return m
+	}
+
+	live := exec.Response
+	saved := exampleToResponse(example)
+	sideBySide := m.diffSideBySide && m.width >= sideBySideMinWidth
+
+	var lines []string
+	lines = append(lines, lipgloss.NewStyle().Bold(true).Render("Example '"+example.Name+"' → live response (q: close)"))
+	lines = append(lines, "")
+
+	lines = append(lines, requestStyle.Render("Status:"))
+	if saved.Status == live.Status {
+		lines = append(lines, diffContextStyle.Render("  "+live.Status))
+	} else {
+		lines = append(lines, diffChangedStyle.Render("~ "+saved.Status+" → "+live.Status))
+	}
+	lines = append(lines, "")
+
+	headerLines, _ := diffHeaders(sortedHeaders(saved.Headers), sortedHeaders(live.Headers))
+	if len(headerLines) > 0 {
+		lines = append(lines, requestStyle.Render("Headers:"))
+		lines = append(lines, headerLines...)
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, requestStyle.Render("Body:"))
+	bodyDiff := diffLines(splitLines(prettyBody(saved.Body)), splitLines(prettyBody(liveBody)))
+	lines = append(lines, m.renderDiffBlock(bodyDiff, sideBySide)...)
+
+	m.previousMode = m.mode
+	m.mode = ModeInfo
+	m.scrollOffset = 0
+	m.infoViewport.Width = m.width - 8
+	m.infoViewport.Height = m.height - 8
+	m = m.setViewportContent(ModeInfo, strings.Join(lines, "\n"))
+	m.infoViewport.GotoTop()
+
+	m.statusMessage = fmt.Sprintf("Comparing example '%s' with the last live response (q to close)", example.Name)
+	return m
+}
+
+func (m Model) saveResponseAsExample(name string) Model {
+	if m.lastResponse == nil || m.lastExecutedItemID == "" {
+		m.statusMessage = "No response to save"
+		return m
+	}
+	if m.responseExample != "" {
+		m.statusMessage = "This is already a saved example. Run the request with ctrl+r to save a live response"
+		return m
+	}
+	if m.lastResponse.Error != nil {
+		m.statusMessage = "Cannot save a failed request as an example"
+		return m
+	}
+	if m.lastResponse.Binary {
+		m.statusMessage = "Cannot save a binary response as an example"
+		return m
+	}
+	body, err := m.lastResponse.LoadBody()
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Cannot save example: %v", err)
+		return m
+	}
+
+	location, err := m.locateItem(m.lastExecutedItemID)
+	if err != nil {
+		m.statusMessage = err.Error()
+		return m
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = m.lastResponse.Status
+	}
+
+	before := m.captureCollection(location.collection.Info.Name)
+	example := responseToExample(name, m.lastResponse, body)
+	example.OriginalRequest = m.deepCopyRequest(location.item.Request)
+	location.item.Responses = append(location.item.Responses, example)
+	m = m.recordChange(fmt.Sprintf("Save example %s", name), before)
+
+	if _, err := m.autoSaveCollection(location.collection.Info.Name); err != nil {
+		m.statusMessage = fmt.Sprintf("Failed to save collection: %v", err)
+		return m
+	}
+
+	m.statusMessage = fmt.Sprintf("Saved example '%s' on %s", name, location.item.Name)
+	return m
+}
+
+func responseToExample(name string, resp *httpclient.Response, body string) postman.Response {
+	_, statusText, _ := strings.Cut(resp.Status, " ")
+	if statusText == "" {
+		statusText = http.StatusText(resp.StatusCode)
+	}
+
+	return postman.Response{
+		ID:              postman.NewID(),
+		Name:            name,
+		Status:          statusText,
+		Code:            resp.StatusCode,
+		PreviewLanguage: previewLanguage(http.Header(resp.Headers).Get("Content-Type")),
+		Header:          sortedHeaders(resp.Headers),
+		Body:            body,
+	}
+}
+
+func exampleToResponse(example postman.Response) *httpclient.Response {
+	resp := &httpclient.Response{
+		StatusCode: example.Code,
+		Status:     strings.TrimSpace(fmt.Sprintf("%d %s", example.Code, example.Status)),
+		Headers:    make(map[string][]string),
+		Body:       example.Body,
+	}
+	for _, header := range example.Header {
+		resp.Headers[header.Key] = append(resp.Headers[header.Key], header.Value)
+	}
+
+	if req := example.OriginalRequest; req != nil {
+		resp.RequestMethod = req.Method
+		resp.RequestURL = req.URL.Raw
+		resp.RequestHeaders = make(map[string]string)
+		for _, header := range req.Header {
+			resp.RequestHeaders[header.Key] = header.Value
+		}
+		if req.Body != nil {
+			resp.RequestBody = req.Body.Raw
+		}
+	}
+
+	return resp
+}
+
+func sortedHeaders(headers map[string][]string) []postman.Header {
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := []postman.Header{}
+	for _, key := range keys {
+		for _, value := range headers[key] {
+			result = append(result, postman.Header{Key: key, Value: value})
+		}
+	}
+	return result
+}
+
+func previewLanguage(contentType string) string {
+	switch {
+	case strings.Contains(contentType, "json"):
+		return "json"
+	case strings.Contains(contentType, "html"):
+		return "html"
+	case strings.Contains(contentType, "xml"):
+		return "xml"
+	case contentType != "":
+		return "text"
+	}
+	return ""
+}
+
+func prettyBody(body string) string {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(body), "", "  "); err == nil {
+		return buf.String()
+	}
+	return body
+}
+
+func (m Model) buildExamplesSection() []string {
+	var lines []string
+
+	if m.currentInfoItem == nil || len(m.currentInfoItem.Responses) == 0 {
+		return lines
+	}
+
+	lines = append(lines, requestStyle.Render("Examples:"))
+	for i, example := range m.currentInfoItem.Responses {
+		line := fmt.Sprintf("  %d. %s", i+1, example.Name)
+		if example.Code != 0 {
+			line += fmt.Sprintf(" (%d %s)", example.Code, example.Status)
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, "")
+
+	return lines
+}
+
+func (m Model) renderExamplePicker() string {
+	metrics := m.calculateLayout()
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("214"))
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("Examples for "+m.examplePicker.itemName)+" "+
+		helpStyle.Render("(j/k select, enter open, c compare with live response, esc cancel)"))
+	lines = append(lines, "")
+
+	for i, example := range m.examplePicker.examples {
+		line := example.Name
+		if example.Code != 0 {
+			line += fmt.Sprintf("  %d %s", example.Code, example.Status)
+		}
+		if i == m.examplePicker.cursor {
+			lines = append(lines, selectedItemStyle.Render("> "+line))
+		} else {
+			lines = append(lines, normalItemStyle.Render("  "+line))
+		}
+	}
+
+	return mainWindowStyle.
+		Height(metrics.contentHeight).
+		Width(m.width - 4).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,185 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"postOffice/internal/postman"
+	"strings"
+	"testing"
+
+	httpclient "postOffice/internal/http"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func createResponseTestModel(t *testing.T) (Model, string) {
+	t.Helper()
+	m := createSavedTestModel(t)
+	m.width, m.height = 100, 40
+	m = selectRequest(t, m, "POST Request")
+
+	itemID := m.getRequestIdentifier(m.currentItems[m.cursor])
+	response := &httpclient.Response{
+		StatusCode: 201,
+		Status:     "201 Created",
+		Headers:    map[string][]string{"Content-Type": {"application/json"}},
+		Body:       `{"id":1,"name":"first"}`,
+	}
+	m.requestExecutions[itemID] = &RequestExecution{Response: response}
+	m.lastResponse = response
+	m.lastExecutedItemID = itemID
+	m.mode = ModeResponse
+	return m, itemID
+}
+
+func TestSaveExample_PersistsToCollectionFile(t *testing.T) {
+	m, _ := createResponseTestModel(t)
+
+	m, _ = handleSaveExampleCommand(m, []string{"Created", "item"})
+	if !contains(m.statusMessage, "Saved example 'Created item'") {
+		t.Fatalf("Unexpected status: %s", m.statusMessage)
+	}
+
+	reloaded, err := m.parser.LoadCollectionSnapshot(m.collection.Info.Name)
+	if err != nil {
+		t.Fatalf("Failed to reload collection: %v", err)
+	}
+	examples := reloaded.Items[1].Responses
+	if len(examples) != 1 {
+		t.Fatalf("Expected 1 saved example, got %d", len(examples))
+	}
+
+	example := examples[0]
+	if example.Name != "Created item" || example.Code != 201 || example.Status != "Created" {
+		t.Errorf("Unexpected example: %+v", example)
+	}
+	if example.PreviewLanguage != "json" || example.Body != `{"id":1,"name":"first"}` {
+		t.Errorf("Unexpected example body: %+v", example)
+	}
+	if example.OriginalRequest == nil || example.OriginalRequest.Method != "POST" {
+		t.Errorf("Expected original request to be stored, got %+v", example.OriginalRequest)
+	}
+}
+
+func TestSaveExample_KeyPrefillsCommand(t *testing.T) {
+	m, _ := createResponseTestModel(t)
+
+	m, _ = handleSaveExampleKey(m)
+	if !m.commandMode || m.commandInput.Value() != "saveexample 201 Created" {
+		t.Errorf("Expected prefilled command, got %q", m.commandInput.Value())
+	}
+}
+
+func TestExamplePicker_OpensExampleInResponseView(t *testing.T) {
+	m, itemID := createResponseTestModel(t)
+	m.collection.Items[1].Responses = []postman.Response{
+		{Name: "Not found", Code: 404, Status: "Not Found", Body: "missing"},
+	}
+	m.mode = ModeRequests
+
+	m, _ = handleExamplesCommand(m, nil)
+	if m.examplePicker == nil {
+		t.Fatalf("Expected example picker to open, status: %s", m.statusMessage)
+	}
+	if !strings.Contains(m.View(), "Not found") {
+		t.Error("Expected picker to list the example")
+	}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+
+	if m.mode != ModeResponse || m.responseExample != "Not found" {
+		t.Fatalf("Expected example in response view, got mode %v example %q", m.mode, m.responseExample)
+	}
+	if m.lastResponse.StatusCode != 404 || m.lastResponse.Body != "missing" {
+		t.Errorf("Unexpected response: %+v", m.lastResponse)
+	}
+	if m.requestExecutions[itemID].Response.StatusCode != 201 {
+		t.Error("Expected live response to be left untouched")
+	}
+
+	m, _ = handleSaveExampleCommand(m, []string{"again"})
+	if len(m.collection.Items[1].Responses) != 1 {
+		t.Error("Expected a displayed example not to be saved again")
+	}
+}
+
+func TestExamplePicker_ComparesWithLiveResponse(t *testing.T) {
+	m, _ := createResponseTestModel(t)
+	m.collection.Items[1].Responses = []postman.Response{
+		{
+			Name:   "Created",
+			Code:   201,
+			Status: "Created",
+			Header: []postman.Header{{Key: "Content-Type", Value: "application/json"}},
+			Body:   `{"id":1,"name":"old"}`,
+		},
+	}
+
+	m, _ = handleExamplesCommand(m, nil)
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	m = newModel.(Model)
+
+	if m.mode != ModeInfo {
+		t.Fatalf("Expected ModeInfo, got %v (%s)", m.mode, m.statusMessage)
+	}
+
+	content := ansi.Strip(m.infoViewport.View())
+	for _, want := range []string{
+		"  201 Created",
+		"  Content-Type: application/json",
+		`-  "name": "old"`,
+		`+  "name": "first"`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected comparison to contain %q, got:\n%s", want, content)
+		}
+	}
+
+	m, _ = handleBackKey(m)
+	if m.mode != ModeResponse {
+		t.Errorf("Expected to return to the response view, got %v", m.mode)
+	}
+}
+
+func TestSaveExample_LoadsStreamedBody(t *testing.T) {
+	m, _ := createResponseTestModel(t)
+	bodyFile := filepath.Join(t.TempDir(), "body")
+	if err := os.WriteFile(bodyFile, []byte(`{"id":2}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m.lastResponse.Body = ""
+	m.lastResponse.BodyFile = bodyFile
+	m.lastResponse.BodySize = 8
+
+	m, _ = handleSaveExampleCommand(m, []string{"Large"})
+	examples := m.collection.Items[1].Responses
+	if len(examples) != 1 || examples[0].Body != `{"id":2}` {
+		t.Fatalf("Expected example body to be read from the body file, got %+v (%s)", examples, m.statusMessage)
+	}
+}
+
+func TestSaveExample_RefusesUnloadableBody(t *testing.T) {
+	m, itemID := createResponseTestModel(t)
+	m.lastResponse.Body = ""
+	m.lastResponse.BodyFile = filepath.Join(t.TempDir(), "gone")
+	m.lastResponse.BodySize = 8
+
+	m, _ = handleSaveExampleCommand(m, []string{"Gone"})
+	if len(m.collection.Items[1].Responses) != 0 || !contains(m.statusMessage, "Cannot save example") {
+		t.Errorf("Expected no example to be saved, got status %q", m.statusMessage)
+	}
+
+	m.lastResponse.Binary = true
+	m, _ = handleSaveExampleCommand(m, []string{"Binary"})
+	if len(m.collection.Items[1].Responses) != 0 || !contains(m.statusMessage, "binary") {
+		t.Errorf("Expected binary response to be refused, got status %q", m.statusMessage)
+	}
+
+	m.collection.Items[1].Responses = []postman.Response{{Name: "Saved", Code: 201, Body: "{}"}}
+	m = m.compareExample(itemID, m.collection.Items[1].Responses[0])
+	if m.mode == ModeInfo || !contains(m.statusMessage, "binary") {
+		t.Errorf("Expected comparison with a binary response to be refused, got %q", m.statusMessage)
+	}
+}
@@ -0,0 +1,66 @@
+package tui
+
+import (
+	"fmt"
+	"postOffice/internal/http"
+	"postOffice/internal/postman"
+	"strings"
+)
+
+func (m Model) exportOpenAPI(path string) Model {
+	if m.collection == nil {
+		m.statusMessage = "No collection loaded"
+		return m
+	}
+
+	collectionName := m.collection.Info.Name
+	lookup := func(folder []string, item *postman.Item) *postman.RecordedResponse {
+		itemID := m.getItemIdentifier(collectionName, folder, *item)
+		exec, exists := m.requestExecutions[itemID]
+		if !exists || exec.Response == nil || exec.Response.Error != nil {
+			return nil
+		}
+
+		contentType := ""
+		for key, values := range exec.Response.Headers {
+			if strings.EqualFold(key, "Content-Type") && len(values) > 0 {
+				contentType = values[0]
+			}
+		}
+
+		return &postman.RecordedResponse{
+			StatusCode:  exec.Response.StatusCode,
+			ContentType: contentType,
+			Body:        exec.Response.Body,
+		}
+	}
+
+	skipped, err := m.parser.ExportOpenAPI(collectionName, path, lookup)
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Failed to export OpenAPI spec: %v", err)
+		return m
+	}
+
+	m.statusMessage = fmt.Sprintf("Exported %s to OpenAPI spec: %s", collectionName, path)
+	if len(skipped) > 0 {
+		m.statusMessage += fmt.Sprintf(" (skipped %d request(s): %s)", len(skipped), strings.Join(skipped, "; "))
+	}
+	return m
+}
+
+func (m Model) exportHAR(path string) Model {
+	responses := m.sessionLog.responses
+	if len(responses) == 0 {
+		m.statusMessage = "No executed requests to export"
+		return m
+	}
+
+	har := http.BuildHAR(responses)
+	if err := postman.WriteHAR(har, path); err != nil {
+		m.statusMessage = fmt.Sprintf("Failed to export HAR: %v", err)
+		return m
+	}
+
+	m.statusMessage = fmt.Sprintf("Exported %d request(s) to HAR: %s", len(har.Log.Entries), path)
+	return m
+}
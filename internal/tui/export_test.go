@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"postOffice/internal/http"
+	"postOffice/internal/postman"
+)
+
+func TestExportOpenAPI_IncludesRecordedResponses(t *testing.T) {
+	dir := t.TempDir()
+	collectionPath := filepath.Join(dir, "collection.json")
+	data, _ := json.Marshal(createMockCollection())
+	if err := os.WriteFile(collectionPath, data, 0644); err != nil {
+		t.Fatalf("Failed to write collection: %v", err)
+	}
+
+	parser := postman.NewParser()
+	collection, err := parser.LoadCollection(collectionPath)
+	if err != nil {
+		t.Fatalf("Failed to load collection: %v", err)
+	}
+
+	m := NewModel(parser)
+	m.collection = collection
+	m.mode = ModeRequests
+	m = m.loadRequestsList()
+
+	itemID := m.getRequestIdentifier(collection.Items[1])
+	m.requestExecutions[itemID] = &RequestExecution{
+		Status: "201 Created",
+		Response: &http.Response{
+			StatusCode: 201,
+			Headers:    map[string][]string{"Content-Type": {"application/json"}},
+			Body:       `{"id": 1}`,
+		},
+	}
+
+	path := filepath.Join(dir, "openapi.json")
+	m, _ = handleExportCommand(m, []string{"openapi " + path})
+
+	if !contains(m.statusMessage, "Exported") {
+		t.Fatalf("Expected export to succeed, got: %s", m.statusMessage)
+	}
+
+	spec, err := m.parser.LoadOpenAPISpec(path)
+	if err != nil {
+		t.Fatalf("Failed to load exported spec: %v", err)
+	}
+
+	found := false
+	for _, pathItem := range spec.Paths {
+		for _, op := range []*postman.OpenAPIOperation{pathItem.Get, pathItem.Post, pathItem.Put, pathItem.Delete} {
+			if op != nil {
+				if _, ok := op.Responses["201"]; ok {
+					found = true
+				}
+			}
+		}
+	}
+	if !found {
+		t.Error("Expected recorded 201 response in exported spec")
+	}
+}
+
+func TestExportCommand_Usage(t *testing.T) {
+	m := createTestModel()
+
+	m, _ = handleExportCommand(m, []string{"openapi"})
+	if !contains(m.statusMessage, "Usage") {
+		t.Errorf("Expected usage message, got: %s", m.statusMessage)
+	}
+
+	m, _ = handleExportCommand(m, []string{"yaml out.yaml"})
+	if !contains(m.statusMessage, "Unknown export format") {
+		t.Errorf("Expected unknown format message, got: %s", m.statusMessage)
+	}
+}
+
+func TestExportHAR(t *testing.T) {
+	m := createTestModel()
+
+	path := filepath.Join(t.TempDir(), "session.har")
+	m, _ = handleExportCommand(m, []string{"har " + path})
+	if !contains(m.statusMessage, "No executed requests") {
+		t.Errorf("Expected nothing to export, got: %s", m.statusMessage)
+	}
+
+	started := time.Now()
+	for i, status := range []string{"500 Internal Server Error", "200 OK"} {
+		m.setRequestExecution("Test Collection/POST Request", &RequestExecution{
+			Response: &http.Response{
+				StatusCode:    500 - 300*i,
+				Status:        status,
+				RequestMethod: "POST",
+				RequestURL:    "https://example.com/api/create",
+				StartedAt:     started.Add(time.Duration(i) * time.Second),
+				Body:          "ok",
+			},
+		})
+	}
+
+	m, _ = handleExportCommand(m, []string{"har " + path})
+	if !contains(m.statusMessage, "Exported 2 request(s)") {
+		t.Fatalf("Expected export to succeed, got: %s", m.statusMessage)
+	}
+
+	collection, err := m.parser.LoadCollection(path)
+	if err != nil {
+		t.Fatalf("Failed to re-import exported HAR: %v", err)
+	}
+	if len(collection.Items) != 1 || collection.Items[0].Name != "example.com" {
+		t.Fatalf("Unexpected re-imported collection: %+v", collection.Items)
+	}
+	if entries := collection.Items[0].Items; len(entries) != 2 {
+		t.Errorf("Expected both executions of the re-sent request, got %d", len(entries))
+	}
+}
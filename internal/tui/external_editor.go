@@ -0,0 +1,189 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"postOffice/internal/postman"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type editorTarget int
+
+const (
+	editorTargetBody editorTarget = iota
+	editorTargetScript
+)
+
+type EditorFinishedMsg struct {
+	Path string
+	Err  error
+
+	target editorTarget
+}
+
+func editorCommand(path string) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	fields := strings.Fields(editor)
+	return exec.Command(fields[0], append(fields[1:], path)...)
+}
+
+func bodyFileExtension(req *postman.Request) string {
+	contentType := ""
+	for _, header := range req.Header {
+		if strings.EqualFold(header.Key, "Content-Type") {
+			contentType = strings.ToLower(header.Value)
+		}
+	}
+
+	raw := ""
+	if req.Body != nil {
+		if req.Body.Mode == "graphql" {
+			return ".graphql"
+		}
+		raw = strings.TrimSpace(req.Body.Raw)
+	}
+
+	switch {
+	case strings.Contains(contentType, "graphql"):
+		return ".graphql"
+	case strings.Contains(contentType, "json"), raw != "" && json.Valid([]byte(raw)):
+		return ".json"
+	case strings.Contains(contentType, "xml"):
+		return ".xml"
+	}
+	return ".txt"
+}
+
+func (m Model) openExternalEditor(target editorTarget, content string, extension string) (Model, tea.Cmd) {
+	file, err := os.CreateTemp("", "postoffice-*"+extension)
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Failed to create temp file: %v", err)
+		return m, nil
+	}
+	path := file.Name()
+
+	_, err = file.WriteString(content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		m.statusMessage = fmt.Sprintf("Failed to write temp file: %v", err)
+		return m, nil
+	}
+
+	m.statusMessage = "Waiting for external editor..."
+	return m, tea.ExecProcess(editorCommand(path), func(err error) tea.Msg {
+		return EditorFinishedMsg{Path: path, Err: err, target: target}
+	})
+}
+
+func (m Model) openBodyInEditor() (Model, tea.Cmd) {
+	if m.editType != EditTypeRequest || m.editRequest == nil {
+		return m, nil
+	}
+
+	content := ""
+	if m.editFieldMode && m.editFieldCursor == 4 {
+		content = m.editFieldTextArea.Value()
+	} else if m.editRequest.Body != nil {
+		content = m.editRequest.Body.Raw
+	}
+	return m.openExternalEditor(editorTargetBody, content, bodyFileExtension(m.editRequest))
+}
+
+func (m Model) openScriptInEditor() (Model, tea.Cmd) {
+	if m.editType != EditTypeScript || m.editScript == nil {
+		return m, nil
+	}
+	return m.openExternalEditor(editorTargetScript, m.editFieldTextArea.Value(), ".js")
+}
+
+// textAreaMaxLines is the textarea's fixed line capacity; SetValue drops any
+// lines beyond it.
+const textAreaMaxLines = 10000
+
+// setTextAreaValue loads content into the edit textarea without truncating it.
+// The character limit only guards typing, so it is lifted when content is
+// longer. Content with more lines than the textarea holds is refused and the
+// textarea is left untouched.
+func (m Model) setTextAreaValue(content string) (Model, error) {
+	if lines := strings.Count(content, "\n") + 1; lines > textAreaMaxLines {
+		return m, fmt.Errorf("%d lines is more than the editor holds (%d)", lines, textAreaMaxLines)
+	}
+
+	limit := m.editFieldTextArea.CharLimit
+	m.editFieldTextArea.CharLimit = 0
+	m.editFieldTextArea.SetValue(content)
+	if m.editFieldTextArea.Length() <= limit {
+		m.editFieldTextArea.CharLimit = limit
+	}
+	return m, nil
+}
+
+func (m Model) applyExternalEdit(msg EditorFinishedMsg) Model {
+	data, readErr := os.ReadFile(msg.Path)
+	keepFile := false
+	defer func() {
+		if !keepFile {
+			os.Remove(msg.Path)
+		}
+	}()
+
+	if msg.Err != nil {
+		m.statusMessage = fmt.Sprintf("Editor exited with error: %v", msg.Err)
+		return m
+	}
+	if readErr != nil {
+		m.statusMessage = fmt.Sprintf("Failed to read edited file: %v", readErr)
+		return m
+	}
+	if m.mode != ModeEdit {
+		m.statusMessage = "Edit was closed before the editor finished; changes discarded"
+		return m
+	}
+
+	content := strings.TrimSuffix(string(data), "\n")
+
+	switch msg.target {
+	case editorTargetBody:
+		if m.editType != EditTypeRequest || m.editRequest == nil {
+			return m
+		}
+		m = m.pushEditHistory()
+		if m.editRequest.Body == nil {
+			m.editRequest.Body = &postman.Body{Mode: "raw"}
+		}
+		m.editRequest.Body.Raw = content
+		m.editFieldMode = false
+		m.editFieldTextArea.Blur()
+		m.editFieldCursor = 4
+		m.statusMessage = "Body updated from editor (use :w to save to file)"
+	case editorTargetScript:
+		if m.editType != EditTypeScript {
+			return m
+		}
+		updated, err := m.setTextAreaValue(content)
+		if err != nil {
+			keepFile = true
+			m.statusMessage = fmt.Sprintf("Script not updated: %v; your edit is kept in %s", err, msg.Path)
+			return m
+		}
+		m = updated
+		m.editFieldTextArea.Focus()
+		m.statusMessage = "Script updated from editor (use :w to save to file)"
+	}
+
+	return m
+}
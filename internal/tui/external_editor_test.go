@@ -0,0 +1,160 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"postOffice/internal/postman"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func writeEditedFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "edited")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write edited file: %v", err)
+	}
+	return path
+}
+
+func TestBodyFileExtension(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  *postman.Request
+		expected string
+	}{
+		{"json body", &postman.Request{Body: &postman.Body{Mode: "raw", Raw: `{"a":1}`}}, ".json"},
+		{"json content type", &postman.Request{Header: []postman.Header{{Key: "content-type", Value: "application/json"}}}, ".json"},
+		{"graphql mode", &postman.Request{Body: &postman.Body{Mode: "graphql"}}, ".graphql"},
+		{"graphql content type", &postman.Request{Header: []postman.Header{{Key: "Content-Type", Value: "application/graphql"}}}, ".graphql"},
+		{"xml", &postman.Request{Header: []postman.Header{{Key: "Content-Type", Value: "text/xml"}}, Body: &postman.Body{Raw: "<a/>"}}, ".xml"},
+		{"plain text", &postman.Request{Body: &postman.Body{Mode: "raw", Raw: "hello"}}, ".txt"},
+		{"no body", &postman.Request{}, ".txt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bodyFileExtension(tt.request); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestExternalEditor_CtrlOStartsEditor(t *testing.T) {
+	m := createSavedTestModel(t)
+	m = selectRequest(t, m, "POST Request")
+	m = m.enterEditMode(m.currentItems[m.cursor])
+
+	newModel, cmd := m.handleEditModeKeys(tea.KeyMsg{Type: tea.KeyCtrlO})
+	m = newModel.(Model)
+	if cmd == nil {
+		t.Fatal("Expected ctrl+o to return an exec command")
+	}
+	if m.statusMessage != "Waiting for external editor..." {
+		t.Errorf("Unexpected status: %s", m.statusMessage)
+	}
+}
+
+func TestExternalEditor_AppliesBody(t *testing.T) {
+	m := createSavedTestModel(t)
+	m = selectRequest(t, m, "POST Request")
+	m = m.enterEditMode(m.currentItems[m.cursor])
+	original := m.collection.Items[1].Request.Body.Raw
+
+	path := writeEditedFile(t, "{\"edited\": true}\n")
+	newModel, _ := m.Update(EditorFinishedMsg{Path: path, target: editorTargetBody})
+	m = newModel.(Model)
+
+	if m.editRequest.Body.Raw != `{"edited": true}` {
+		t.Errorf("Expected body from editor, got %q", m.editRequest.Body.Raw)
+	}
+	if m.collection.Items[1].Request.Body.Raw != original {
+		t.Error("Expected the collection to stay unchanged until the edit is applied")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Expected the temp file to be removed")
+	}
+
+	newModel, _ = m.handleEditModeKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	m = newModel.(Model)
+	if m.editRequest.Body.Raw != original {
+		t.Errorf("Expected the editor change to be undoable, got %q", m.editRequest.Body.Raw)
+	}
+}
+
+func TestExternalEditor_AppliesScript(t *testing.T) {
+	m := createSavedTestModel(t)
+	m = selectRequest(t, m, "POST Request")
+	m = m.enterScriptSelectionMode(m.currentItems[m.cursor])
+	m = m.selectScriptType(m.currentItems[m.cursor], ScriptTypeTest)
+
+	path := writeEditedFile(t, "pm.test('ok', function () {});\n")
+	newModel, _ := m.Update(EditorFinishedMsg{Path: path, target: editorTargetScript})
+	m = newModel.(Model)
+
+	if m.editFieldTextArea.Value() != "pm.test('ok', function () {});" {
+		t.Errorf("Expected script from editor, got %q", m.editFieldTextArea.Value())
+	}
+}
+
+func TestExternalEditor_ErrorKeepsContent(t *testing.T) {
+	m := createSavedTestModel(t)
+	m = selectRequest(t, m, "POST Request")
+	m = m.enterEditMode(m.currentItems[m.cursor])
+	original := m.editRequest.Body.Raw
+
+	path := writeEditedFile(t, "ignored")
+	newModel, _ := m.Update(EditorFinishedMsg{Path: path, Err: os.ErrNotExist, target: editorTargetBody})
+	m = newModel.(Model)
+
+	if m.editRequest.Body.Raw != original {
+		t.Error("Expected body to be unchanged when the editor fails")
+	}
+	if !contains(m.statusMessage, "Editor exited with error") {
+		t.Errorf("Unexpected status: %s", m.statusMessage)
+	}
+}
+
+func TestExternalEditor_DoesNotTruncateLongScripts(t *testing.T) {
+	m := createSavedTestModel(t)
+	m = selectRequest(t, m, "POST Request")
+	m = m.enterScriptSelectionMode(m.currentItems[m.cursor])
+	m = m.selectScriptType(m.currentItems[m.cursor], ScriptTypeTest)
+
+	line := "pm.test('ok', function () { /* padding */ });"
+	script := strings.TrimSuffix(strings.Repeat(line+"\n", 60000/len(line)+100), "\n")
+	if len(script) <= m.editFieldTextArea.CharLimit {
+		t.Fatalf("Expected the script to exceed the %d character limit", m.editFieldTextArea.CharLimit)
+	}
+
+	newModel, _ := m.Update(EditorFinishedMsg{Path: writeEditedFile(t, script+"\n"), target: editorTargetScript})
+	m = newModel.(Model)
+	if m.editFieldTextArea.Value() != script {
+		t.Errorf("Expected the full %d character script, got %d characters", len(script), len(m.editFieldTextArea.Value()))
+	}
+}
+
+func TestExternalEditor_RefusesScriptOverLineCapacity(t *testing.T) {
+	m := createSavedTestModel(t)
+	m = selectRequest(t, m, "POST Request")
+	m = m.enterScriptSelectionMode(m.currentItems[m.cursor])
+	m = m.selectScriptType(m.currentItems[m.cursor], ScriptTypeTest)
+	m.editFieldTextArea.SetValue("original")
+
+	path := writeEditedFile(t, strings.Repeat("x\n", textAreaMaxLines+1))
+	newModel, _ := m.Update(EditorFinishedMsg{Path: path, target: editorTargetScript})
+	m = newModel.(Model)
+
+	if m.editFieldTextArea.Value() != "original" {
+		t.Error("Expected the script to be left unchanged")
+	}
+	if !contains(m.statusMessage, "Script not updated") || !contains(m.statusMessage, path) {
+		t.Errorf("Unexpected status: %s", m.statusMessage)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("Expected the edited file to be kept, got %v", err)
+	}
+}
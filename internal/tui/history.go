@@ -0,0 +1,187 @@
+package tui
+
+import (
+	"fmt"
+	"maps"
+	"postOffice/internal/postman"
+	"reflect"
+	"slices"
+)
+
+const maxHistoryEntries = 100
+
+type historySnapshot struct {
+	collections      map[string]*postman.Collection
+	environments     map[string]*postman.Environment
+	modifiedRequests map[string]*postman.Request
+	modifiedItems    map[string]bool
+}
+
+type historyEntry struct {
+	description string
+	before      historySnapshot
+	after       historySnapshot
+}
+
+type editHistoryState struct {
+	name    string
+	request *postman.Request
+}
+
+func (m Model) captureState(collections []string, environments []string) historySnapshot {
+	snapshot := historySnapshot{
+		collections:      make(map[string]*postman.Collection),
+		environments:     make(map[string]*postman.Environment),
+		modifiedRequests: maps.Clone(m.modifiedRequests),
+		modifiedItems:    maps.Clone(m.modifiedItems),
+	}
+	for _, name := range collections {
+		if collection, exists := m.parser.GetCollection(name); exists {
+			snapshot.collections[name] = collection.Clone()
+		}
+	}
+	for _, name := range environments {
+		if environment, exists := m.parser.GetEnvironment(name); exists {
+			snapshot.environments[name] = environment.Clone()
+		}
+	}
+	return snapshot
+}
+
+func (m Model) captureCollection(name string) historySnapshot {
+	return m.captureState([]string{name}, nil)
+}
+
+func (m Model) recordChange(description string, before historySnapshot) Model {
+	after := m.captureState(slices.Collect(maps.Keys(before.collections)), slices.Collect(maps.Keys(before.environments)))
+
+	m.undoStack = append(m.undoStack, historyEntry{
+		description: description,
+		before:      before,
+		after:       after,
+	})
+	if len(m.undoStack) > maxHistoryEntries {
+		m.undoStack = m.undoStack[len(m.undoStack)-maxHistoryEntries:]
+	}
+	m.redoStack = nil
+	return m
+}
+
+func (m Model) undo() Model {
+	if len(m.undoStack) == 0 {
+		m.statusMessage = "Nothing to undo"
+		return m
+	}
+
+	entry := m.undoStack[len(m.undoStack)-1]
+	m.undoStack = m.undoStack[:len(m.undoStack)-1]
+	m.redoStack = append(m.redoStack, entry)
+
+	m = m.restoreSnapshot(entry.before)
+	m.statusMessage = fmt.Sprintf("Undid: %s (use :w to write to file)", entry.description)
+	return m
+}
+
+func (m Model) redo() Model {
+	if len(m.redoStack) == 0 {
+		m.statusMessage = "Nothing to redo"
+		return m
+	}
+
+	entry := m.redoStack[len(m.redoStack)-1]
+	m.redoStack = m.redoStack[:len(m.redoStack)-1]
+	m.undoStack = append(m.undoStack, entry)
+
+	m = m.restoreSnapshot(entry.after)
+	m.statusMessage = fmt.Sprintf("Redid: %s (use :w to write to file)", entry.description)
+	return m
+}
+
+func (m Model) restoreSnapshot(snapshot historySnapshot) Model {
+	for name, saved := range snapshot.collections {
+		if collection, exists := m.parser.GetCollection(name); exists {
+			*collection = *saved.Clone()
+			m.modifiedCollections[name] = true
+		}
+	}
+	for name, saved := range snapshot.environments {
+		if environment, exists := m.parser.GetEnvironment(name); exists {
+			*environment = *saved.Clone()
+			m.modifiedEnvironments[name] = true
+		}
+	}
+	m.modifiedRequests = maps.Clone(snapshot.modifiedRequests)
+	m.modifiedItems = maps.Clone(snapshot.modifiedItems)
+
+	if m.collection != nil {
+		if _, err := postman.FindFolder(&m.collection.Items, m.breadcrumb); err != nil {
+			m.breadcrumb = []string{}
+		}
+	}
+	if m.mode == ModeRequests {
+		m = m.refreshCurrentView()
+		if m.cursor >= len(m.items) {
+			m.cursor = max(len(m.items)-1, 0)
+		}
+	}
+	return m
+}
+
+func (m Model) recordVariableChanges(itemName string, before historySnapshot) Model {
+	after := m.captureState(slices.Collect(maps.Keys(before.collections)), slices.Collect(maps.Keys(before.environments)))
+
+	changed := false
+	for name, collection := range before.collections {
+		if updated, exists := after.collections[name]; exists && !reflect.DeepEqual(collection.Variables, updated.Variables) {
+			changed = true
+		}
+	}
+	for name, environment := range before.environments {
+		if updated, exists := after.environments[name]; exists && !reflect.DeepEqual(environment.Values, updated.Values) {
+			changed = true
+		}
+	}
+	if !changed {
+		return m
+	}
+
+	return m.recordChange(fmt.Sprintf("Script variables: %s", itemName), before)
+}
+
+func (m Model) pushEditHistory() Model {
+	m.editUndoStack = append(m.editUndoStack, editHistoryState{name: m.editItemName, request: m.deepCopyRequest(m.editRequest)})
+	m.editRedoStack = nil
+	return m
+}
+
+func (m Model) undoEditField() Model {
+	if len(m.editUndoStack) == 0 {
+		m.statusMessage = "Nothing to undo in this edit"
+		return m
+	}
+
+	state := m.editUndoStack[len(m.editUndoStack)-1]
+	m.editUndoStack = m.editUndoStack[:len(m.editUndoStack)-1]
+	m.editRedoStack = append(m.editRedoStack, editHistoryState{name: m.editItemName, request: m.deepCopyRequest(m.editRequest)})
+
+	m.editItemName = state.name
+	m.editRequest = state.request
+	m.statusMessage = "Undid field change"
+	return m
+}
+
+func (m Model) redoEditField() Model {
+	if len(m.editRedoStack) == 0 {
+		m.statusMessage = "Nothing to redo in this edit"
+		return m
+	}
+
+	state := m.editRedoStack[len(m.editRedoStack)-1]
+	m.editRedoStack = m.editRedoStack[:len(m.editRedoStack)-1]
+	m.editUndoStack = append(m.editUndoStack, editHistoryState{name: m.editItemName, request: m.deepCopyRequest(m.editRequest)})
+
+	m.editItemName = state.name
+	m.editRequest = state.request
+	m.statusMessage = "Redid field change"
+	return m
+}
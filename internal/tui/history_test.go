@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"postOffice/internal/postman"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestUndoRedo_DeleteRequest(t *testing.T) {
+	m := createSavedTestModel(t)
+	m = selectRequest(t, m, "POST Request")
+
+	m = m.deleteRequest(m.currentItems[m.cursor])
+	if len(m.collection.Items) != 1 {
+		t.Fatalf("Expected request to be deleted, status: %s", m.statusMessage)
+	}
+
+	m, _ = handleUndoKey(m)
+	if len(m.collection.Items) != 2 || m.collection.Items[1].Name != "POST Request" {
+		t.Fatalf("Expected delete to be undone, got %v", currentNames(m))
+	}
+	if !contains(m.statusMessage, "Undid: Delete request POST Request") {
+		t.Errorf("Expected change description in status, got: %s", m.statusMessage)
+	}
+	if !m.modifiedCollections["Test Collection"] {
+		t.Error("Expected undo to mark the collection as modified")
+	}
+	if len(m.currentItems) != 2 {
+		t.Error("Expected the requests view to be refreshed")
+	}
+
+	m, _ = handleRedoKey(m)
+	if len(m.collection.Items) != 1 || !contains(m.statusMessage, "Redid: Delete request") {
+		t.Errorf("Expected delete to be redone, got %v (%s)", currentNames(m), m.statusMessage)
+	}
+
+	m, _ = handleRedoKey(m)
+	if m.statusMessage != "Nothing to redo" {
+		t.Errorf("Unexpected status: %s", m.statusMessage)
+	}
+}
+
+func TestUndoRedo_EditRestoresModificationState(t *testing.T) {
+	m := createSavedTestModel(t)
+	m = selectRequest(t, m, "POST Request")
+	itemID := m.getRequestIdentifier(m.currentItems[m.cursor])
+
+	m = m.enterEditMode(m.currentItems[m.cursor])
+	m.editRequest.Method = "PUT"
+	newModel, _ := m.handleEditModeKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	if m.collection.Items[1].Request.Method != "PUT" || !m.modifiedItems[itemID] {
+		t.Fatal("Expected edit to be applied in memory")
+	}
+
+	m, _ = handleUndoKey(m)
+	if m.collection.Items[1].Request.Method != "POST" {
+		t.Errorf("Expected method to be restored, got %s", m.collection.Items[1].Request.Method)
+	}
+	if m.modifiedItems[itemID] || m.modifiedRequests[itemID] != nil {
+		t.Error("Expected the request to no longer be marked as modified")
+	}
+
+	m, _ = handleRedoKey(m)
+	if m.collection.Items[1].Request.Method != "PUT" || !m.modifiedItems[itemID] {
+		t.Error("Expected redo to re-apply the edit and its modification state")
+	}
+}
+
+func TestUndoRedo_TreeChangesAndNewChangeClearsRedo(t *testing.T) {
+	m := createSavedTestModel(t)
+
+	m, _ = handleMkdirCommand(m, []string{"A"})
+	m, _ = handleMkdirCommand(m, []string{"B"})
+	m, _ = handleUndoKey(m)
+	if postman.HasFolder(m.collection.Items, "B") || !postman.HasFolder(m.collection.Items, "A") {
+		t.Fatalf("Expected only the last folder to be undone, got %v", currentNames(m))
+	}
+
+	m, _ = handleMkdirCommand(m, []string{"C"})
+	m, _ = handleRedoKey(m)
+	if postman.HasFolder(m.collection.Items, "B") {
+		t.Error("Expected a new change to clear the redo history")
+	}
+}
+
+func TestEditMode_UndoFieldChange(t *testing.T) {
+	m := createSavedTestModel(t)
+	m = selectRequest(t, m, "POST Request")
+	m = m.enterEditMode(m.currentItems[m.cursor])
+
+	m.editFieldCursor = 1
+	m.editFieldMode = true
+	m.editFieldInput.SetValue("PATCH")
+	newModel, _ := m.handleFieldEdit(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+	if m.editRequest.Method != "PATCH" {
+		t.Fatalf("Expected field to be updated, got %s", m.editRequest.Method)
+	}
+
+	newModel, _ = m.handleEditModeKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	m = newModel.(Model)
+	if m.editRequest.Method != "POST" {
+		t.Errorf("Expected u to undo the field change, got %s", m.editRequest.Method)
+	}
+
+	newModel, _ = m.handleEditModeKeys(tea.KeyMsg{Type: tea.KeyCtrlR})
+	m = newModel.(Model)
+	if m.editRequest.Method != "PATCH" {
+		t.Errorf("Expected ctrl+r to redo the field change, got %s", m.editRequest.Method)
+	}
+}
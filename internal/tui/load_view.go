@@ -0,0 +1,305 @@
+package tui
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"postOffice/internal/http"
+	"postOffice/internal/postman"
+	"postOffice/internal/runner"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const loadTestRefreshInterval = 500 * time.Millisecond
+
+var sparklineLevels = []rune("▁▂▃▄▅▆▇█")
+
+type loadTestState struct {
+	id       int
+	name     string
+	load     *runner.Load
+	snapshot runner.LoadSnapshot
+	stopping bool
+	finished bool
+}
+
+type loadTestTickMsg struct {
+	loadID int
+}
+
+func parseLoadOptions(args []string) (runner.LoadOptions, error) {
+	var options runner.LoadOptions
+
+	flags := flag.NewFlagSet("loadtest", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.IntVar(&options.VUs, "u", 1, "")
+	flags.DurationVar(&options.Duration, "duration", 0, "")
+	flags.IntVar(&options.Iterations, "n", 0, "")
+	flags.DurationVar(&options.RampUp, "ramp-up", 0, "")
+	flags.Float64Var(&options.RPS, "rps", 0, "")
+	if err := flags.Parse(strings.Fields(strings.Join(args, " "))); err != nil {
+		return options, err
+	}
+	if flags.NArg() > 0 {
+		return options, fmt.Errorf("unexpected argument: %s", flags.Arg(0))
+	}
+	if options.VUs < 1 || options.Duration < 0 || options.Iterations < 0 || options.RampUp < 0 || options.RPS < 0 {
+		return options, fmt.Errorf("values must not be negative and -u must be at least 1")
+	}
+	return options, nil
+}
+
+func (m Model) loadTestTarget() (*postman.Collection, []string, error) {
+	if m.mode == ModeRequests {
+		if m.collection == nil {
+			return nil, nil, fmt.Errorf("no collection loaded")
+		}
+		path := append([]string{}, m.breadcrumb...)
+		if m.cursor < len(m.currentItems) {
+			path = append(path, m.currentItems[m.cursor].Name)
+		}
+		return m.collection, path, nil
+	}
+	return m.runTarget()
+}
+
+func handleLoadTestCommand(m Model, args []string) (Model, tea.Cmd) {
+	if m.loadTest != nil && !m.loadTest.finished {
+		if m.mode != ModeLoadTest {
+			m.previousMode = m.mode
+		}
+		m.mode = ModeLoadTest
+		m.statusMessage = "A load test is already in progress"
+		return m, nil
+	}
+
+	options, err := parseLoadOptions(args)
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Usage: :loadtest [-u users] [-duration 30s] [-n iterations] [-ramp-up 10s] [-rps 50] (%v)", err)
+		return m, nil
+	}
+
+	collection, path, err := m.loadTestTarget()
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Cannot load test: %v", err)
+		return m, nil
+	}
+	targets, err := runner.FindTargets(collection, path)
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Cannot load test: %v", err)
+		return m, nil
+	}
+	if len(targets) == 0 {
+		m.statusMessage = "Cannot load test: no requests found"
+		return m, nil
+	}
+	targets = m.applyModifiedRequests(collection, targets)
+
+	name := collection.Info.Name
+	if len(path) > 0 {
+		name += " / " + strings.Join(path, " / ")
+	}
+
+	executor := m.executor.WithTransport(http.NewTunedTransport(options.VUs))
+	load := runner.NewLoad(executor, m.parser, collection, m.environment, name, targets, options)
+	load.Start()
+
+	id := 1
+	if m.loadTest != nil {
+		id = m.loadTest.id + 1
+	}
+	m.loadTest = &loadTestState{id: id, name: name, load: load, snapshot: load.Snapshot()}
+
+	if m.mode != ModeLoadTest {
+		m.previousMode = m.mode
+	}
+	m.mode = ModeLoadTest
+	m.statusMessage = fmt.Sprintf("Load testing %s (%s)", name, load.Options())
+	return m, loadTestTickCmd(id)
+}
+
+func loadTestTickCmd(id int) tea.Cmd {
+	return tea.Tick(loadTestRefreshInterval, func(time.Time) tea.Msg {
+		return loadTestTickMsg{loadID: id}
+	})
+}
+
+func (m Model) handleLoadTestTick(msg loadTestTickMsg) (Model, tea.Cmd) {
+	state := m.loadTest
+	if state == nil || state.id != msg.loadID || state.finished {
+		return m, nil
+	}
+
+	state.snapshot = state.load.Snapshot()
+	if !state.snapshot.Done {
+		return m, loadTestTickCmd(state.id)
+	}
+
+	state.finished = true
+	outcome := "finished"
+	if state.snapshot.Stopped {
+		outcome = "stopped"
+	}
+	snapshot := state.snapshot
+	m.statusMessage = fmt.Sprintf("Load test %s: %d requests, %.1f req/s, %.2f%% errors, p99 %s", outcome,
+		snapshot.Requests, snapshot.RPS, snapshot.ErrorRate()*100, formatTimingDuration(snapshot.Latency.P99))
+	return m, nil
+}
+
+func handleLoadTestStopKey(m Model) (Model, tea.Cmd) {
+	state := m.loadTest
+	if state == nil || state.finished || state.stopping {
+		return m, nil
+	}
+
+	state.stopping = true
+	state.load.Stop()
+	m.statusMessage = "Stopping load test..."
+	return m, nil
+}
+
+func buildSparkline(values []int) string {
+	peak := 0
+	for _, value := range values {
+		peak = max(peak, value)
+	}
+
+	var sb strings.Builder
+	for _, value := range values {
+		level := 0
+		if peak > 0 {
+			level = value * (len(sparklineLevels) - 1) / peak
+		}
+		sb.WriteRune(sparklineLevels[level])
+	}
+	return sb.String()
+}
+
+func buildStatusHistogram(snapshot runner.LoadSnapshot, width int) []string {
+	type bucket struct {
+		label string
+		count int
+	}
+	var buckets []bucket
+	for _, status := range snapshot.Statuses {
+		buckets = append(buckets, bucket{fmt.Sprintf("%d", status.Code), status.Count})
+	}
+	if snapshot.Errors > 0 {
+		buckets = append(buckets, bucket{"err", snapshot.Errors})
+	}
+
+	peak := 0
+	for _, b := range buckets {
+		peak = max(peak, b.count)
+	}
+
+	var lines []string
+	for _, b := range buckets {
+		bar := max(b.count*width/max(peak, 1), 1)
+		percent := float64(b.count) * 100 / float64(max(snapshot.Requests, 1))
+		lines = append(lines, fmt.Sprintf("  %-4s %s %d (%.1f%%)", b.label, strings.Repeat("█", bar), b.count, percent))
+	}
+	return lines
+}
+
+func buildLoadProgressBar(snapshot runner.LoadSnapshot, width int) string {
+	filled := int(snapshot.Progress() * float64(width))
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+
+	var parts []string
+	if snapshot.Options.Duration > 0 {
+		parts = append(parts, fmt.Sprintf("%v / %v", snapshot.Elapsed.Round(time.Second), snapshot.Options.Duration))
+	} else {
+		parts = append(parts, snapshot.Elapsed.Round(time.Second).String())
+	}
+	if snapshot.Options.Iterations > 0 {
+		parts = append(parts, fmt.Sprintf("%d/%d iterations", snapshot.Iterations, snapshot.Options.Iterations))
+	}
+	return fmt.Sprintf("%s %3.0f%%  %s", bar, snapshot.Progress()*100, strings.Join(parts, " | "))
+}
+
+func (m Model) loadTestLines() []string {
+	state := m.loadTest
+	snapshot := state.snapshot
+	sectionStyle := lipgloss.NewStyle().Bold(true)
+	failedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+
+	stateLabel := "running"
+	switch {
+	case state.finished && snapshot.Stopped:
+		stateLabel = "stopped"
+	case state.finished:
+		stateLabel = "finished"
+	case state.stopping:
+		stateLabel = "stopping"
+	}
+
+	errors := fmt.Sprintf("Errors: %d (%.2f%%) | request errors %d | HTTP 4xx/5xx %d | failed tests %d",
+		snapshot.Failed(), snapshot.ErrorRate()*100, snapshot.Errors, snapshot.HTTPErrors, snapshot.FailedTests)
+	if snapshot.Failed() > 0 {
+		errors = failedStyle.Render(errors)
+	}
+
+	lines := []string{
+		fmt.Sprintf("%s | %s", snapshot.Options, stateLabel),
+		buildLoadProgressBar(snapshot, 30),
+		"",
+		fmt.Sprintf("VUs: %d/%d | Iterations: %d | Requests: %d | %.1f req/s (last second: %.0f)",
+			snapshot.ActiveVUs, snapshot.Options.VUs, snapshot.Iterations, snapshot.Requests, snapshot.RPS, snapshot.CurrentRPS),
+		errors,
+		"Latency: " + runner.FormatLatencySummary(snapshot.Latency),
+	}
+
+	if len(snapshot.Throughput) > 0 {
+		lines = append(lines, "", sectionStyle.Render("Throughput (req/s per second)"), "  "+buildSparkline(snapshot.Throughput))
+	}
+
+	if histogram := buildStatusHistogram(snapshot, 30); len(histogram) > 0 {
+		lines = append(lines, "", sectionStyle.Render("Status codes"))
+		lines = append(lines, histogram...)
+	}
+
+	if len(snapshot.Targets) > 1 {
+		lines = append(lines, "", sectionStyle.Render("Requests"))
+		for _, target := range snapshot.Targets {
+			lines = append(lines, fmt.Sprintf("  %-7s %s %6d  %5d failed  p50 %-8s p90 %-8s p99 %s",
+				target.Method, fitColumn(target.Name, 36), target.Requests, target.Failures,
+				formatTimingDuration(target.Latency.P50), formatTimingDuration(target.Latency.P90), formatTimingDuration(target.Latency.P99)))
+		}
+	}
+
+	if len(snapshot.TopErrors) > 0 {
+		lines = append(lines, "", sectionStyle.Render("Errors"))
+		for _, err := range snapshot.TopErrors {
+			lines = append(lines, failedStyle.Render(fmt.Sprintf("  %dx %s", err.Count, err.Message)))
+		}
+	}
+	return lines
+}
+
+func (m Model) renderLoadTestView() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("214"))
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	if m.loadTest == nil {
+		return m.renderEmptyPopup("No load test yet. Use :loadtest on a request, folder or collection.", m.height-10)
+	}
+
+	header := titleStyle.Render("Load test: "+m.loadTest.name) + " " + helpStyle.Render("(x: stop, esc: close)")
+	lines := append([]string{header}, m.loadTestLines()...)
+	if visible := max(m.height-14, 1); len(lines) > visible {
+		lines = lines[:visible]
+	}
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("33")).
+		Height(m.height-10).
+		Width(m.width-4).
+		Padding(1, 2).
+		Render(strings.Join(lines, "\n"))
+}
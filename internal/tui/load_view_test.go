@@ -0,0 +1,118 @@
+package tui
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func finishLoadTest(t *testing.T, m Model) Model {
+	t.Helper()
+	select {
+	case <-m.loadTest.load.Finished():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Load test did not finish")
+	}
+	m, cmd := m.handleLoadTestTick(loadTestTickMsg{loadID: m.loadTest.id})
+	if cmd != nil || !m.loadTest.finished {
+		t.Fatal("Expected the tick after the last request to finish the load test")
+	}
+	return m
+}
+
+func TestParseLoadOptions(t *testing.T) {
+	options, err := parseLoadOptions([]string{"-u 20 -duration 1m -ramp-up 10s -rps 12.5"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if options.VUs != 20 || options.Duration != time.Minute || options.RampUp != 10*time.Second || options.RPS != 12.5 {
+		t.Errorf("Unexpected options: %+v", options)
+	}
+
+	for _, args := range []string{"-u 0", "-n -1", "-rps fast", "extra"} {
+		if _, err := parseLoadOptions([]string{args}); err == nil {
+			t.Errorf("Expected an error for %q", args)
+		}
+	}
+}
+
+func TestLoadTestCommand_SelectedRequest(t *testing.T) {
+	m := createRunnerTestModel(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	m.cursor = 1
+	request := m.currentItems[1]
+
+	m, cmd := handleLoadTestCommand(m, []string{"-u 3 -n 6"})
+	if m.mode != ModeLoadTest || cmd == nil {
+		t.Fatalf("Expected the load test view to open, status: %s", m.statusMessage)
+	}
+	if m.loadTest.name != "Test Collection / "+request.Name {
+		t.Errorf("Expected the request under the cursor to be load tested, got %q", m.loadTest.name)
+	}
+
+	m, _ = handleLoadTestCommand(m, nil)
+	if !contains(m.statusMessage, "already in progress") || m.previousMode != ModeRequests {
+		t.Errorf("Expected the running load test to be shown again, got %s", m.statusMessage)
+	}
+
+	m = finishLoadTest(t, m)
+	snapshot := m.loadTest.snapshot
+	if snapshot.Requests != 6 || snapshot.Iterations != 6 || snapshot.Failed() != 0 {
+		t.Errorf("Expected 6 successful requests, got %+v", snapshot)
+	}
+	if !contains(m.statusMessage, "Load test finished: 6 requests") {
+		t.Errorf("Unexpected status: %s", m.statusMessage)
+	}
+
+	content := ansi.Strip(m.renderLoadTestView())
+	for _, expected := range []string{"Load test: Test Collection / " + request.Name, "3 VUs, 6 iterations | finished", "100%", "Requests: 6", "Status codes", "200  ", "6 (100.0%)"} {
+		if !contains(content, expected) {
+			t.Errorf("Expected %q in load test view, got:\n%s", expected, content)
+		}
+	}
+
+	m, _ = handleBackKey(m)
+	if m.mode != ModeRequests {
+		t.Errorf("Expected esc to close the load test view, got mode %v", m.mode)
+	}
+}
+
+func TestLoadTestCommand_Stop(t *testing.T) {
+	m := createRunnerTestModel(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	m, _ = handleLoadTestCommand(m, []string{"-u 2 -duration 1m"})
+	if m.loadTest == nil || m.loadTest.name != "Test Collection / Test Folder" {
+		t.Fatalf("Expected the folder under the cursor to be load tested, status: %s", m.statusMessage)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	m, _ = handleLoadTestStopKey(m)
+	if !m.loadTest.stopping || !contains(m.statusMessage, "Stopping load test") {
+		t.Fatalf("Expected the load test to stop, got %s", m.statusMessage)
+	}
+
+	m = finishLoadTest(t, m)
+	if !contains(m.statusMessage, "Load test stopped") || m.loadTest.snapshot.ErrorRate() != 1 {
+		t.Errorf("Unexpected status: %s", m.statusMessage)
+	}
+	content := ansi.Strip(m.renderLoadTestView())
+	for _, expected := range []string{"| stopped", "HTTP 4xx/5xx", "502  "} {
+		if !contains(content, expected) {
+			t.Errorf("Expected %q in load test view, got:\n%s", expected, content)
+		}
+	}
+}
+
+func TestBuildSparkline(t *testing.T) {
+	if got := buildSparkline([]int{0, 4, 8}); got != "▁▄█" {
+		t.Errorf("Expected a scaled sparkline, got %q", got)
+	}
+	if got := buildSparkline([]int{0, 0}); got != "▁▁" {
+		t.Errorf("Expected a flat sparkline, got %q", got)
+	}
+}
@@ -26,6 +26,8 @@ const (
 	ModeJSON
 	ModeLog
 	ModeFileBrowser
+	ModeRunner
+	ModeLoadTest
 )
 
 type EditType int
@@ -52,16 +54,19 @@ type RequestExecution struct {
 	Duration   time.Duration
 	Response   *http.Response
 	TestResult *script.TestResult
+	Sequence   int
 }
 
 type RequestCompleteMsg struct {
-	ItemID       string
-	Response     *http.Response
-	TestResult   *script.TestResult
-	Collection   *postman.Collection
-	Environment  *postman.Environment
-	ItemName     string
-	IsModified   bool
+	ItemID          string
+	Response        *http.Response
+	TestResult      *script.TestResult
+	Collection      *postman.Collection
+	Environment     *postman.Environment
+	ItemName        string
+	IsModified      bool
+	VariableChanges postman.VariableChanges
+	Sequence        int
 }
 
 type Model struct {
@@ -105,6 +110,7 @@ type Model struct {
 	editEnvVariable      *postman.EnvVariable
 	editItemName         string
 	editOriginalName     string
+	editItemID           string
 	editFieldCursor      int
 	editFieldInput       textinput.Model
 	editFieldTextArea    textarea.Model
@@ -126,6 +132,8 @@ type Model struct {
 	infoViewport     viewport.Model
 	jsonViewport     viewport.Model
 	logsViewport     viewport.Model
+	viewportContent  map[ViewMode]string
+	viewportSearch   *viewportSearch
 
 	fileBrowserActive  bool
 	fileBrowserCwd     string
@@ -133,18 +141,53 @@ type Model struct {
 	fileBrowserCommand string
 
 	requestExecutions  map[string]*RequestExecution
+	sessionLog         *sessionLog
+	inFlightRequests   map[string]int
+	requestSequence    int
 	lastExecutedItemID string
+	responseExample    string
+	bodyView           BodyView
+	showConnection     bool
+
+	filterMode         bool
+	filterInput        textinput.Model
+	filterHistory      []string
+	filterHistoryIndex int
+	responseFilter     string
+
+	pendingSync *postman.SyncPlan
+
+	runner   *runnerState
+	loadTest *loadTestState
+
+	snippetPicker *snippetPicker
+	examplePicker *examplePicker
+
+	cutItem *cutItem
+
+	undoStack     []historyEntry
+	redoStack     []historyEntry
+	editUndoStack []editHistoryState
+	editRedoStack []editHistoryState
+
+	diffItemID     string
+	diffSideBySide bool
+	changeIDs      []string
 }
 
 func NewModel(parser *postman.Parser) Model {
 	cmdInput := textinput.New()
 	cmdInput.Placeholder = "Enter command..."
-	cmdInput.CharLimit = 500
+	cmdInput.CharLimit = 20000
 
 	searchInput := textinput.New()
 	searchInput.Placeholder = "Search..."
 	searchInput.CharLimit = 100
 
+	filterInput := textinput.New()
+	filterInput.Placeholder = "gjson path or jq expression, e.g. items.#.id or .items[0].name"
+	filterInput.CharLimit = 1000
+
 	editFieldInput := textinput.New()
 	editFieldInput.CharLimit = 1000
 
@@ -167,6 +210,8 @@ func NewModel(parser *postman.Parser) Model {
 		breadcrumb:           []string{},
 		statusMessage:        "Press : to enter command mode",
 		searchInput:          searchInput,
+		filterInput:          filterInput,
+		filterHistoryIndex:   -1,
 		editFieldInput:       editFieldInput,
 		editFieldTextArea:    editFieldTextArea,
 		modifiedItems:        make(map[string]bool),
@@ -177,7 +222,11 @@ func NewModel(parser *postman.Parser) Model {
 		infoViewport:         viewport.New(0, 0),
 		jsonViewport:         viewport.New(0, 0),
 		logsViewport:         viewport.New(0, 0),
+		viewportContent:      make(map[ViewMode]string),
 		requestExecutions:    make(map[string]*RequestExecution),
+		sessionLog:           &sessionLog{},
+		inFlightRequests:     make(map[string]int),
+		diffSideBySide:       true,
 	}
 }
 
@@ -0,0 +1,146 @@
+package tui
+
+import (
+	"fmt"
+	"net/url"
+	"postOffice/internal/postman"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var sensitiveRedirectHeaders = []string{"Authorization", "Www-Authenticate", "Cookie", "Cookie2"}
+
+type redirectTarget struct {
+	method string
+	url    string
+}
+
+func (m Model) redirectTargets() []redirectTarget {
+	resp := m.lastResponse
+	if resp == nil || len(resp.Redirects) == 0 {
+		return nil
+	}
+
+	var targets []redirectTarget
+	for _, hop := range resp.Redirects {
+		targets = append(targets, redirectTarget{method: hop.Method, url: hop.URL})
+	}
+	if resp.FinalURL != "" {
+		targets = append(targets, redirectTarget{method: resp.FinalMethod, url: resp.FinalURL})
+	}
+	return targets
+}
+
+func (m Model) buildRedirectSection() []string {
+	resp := m.lastResponse
+	if len(resp.Redirects) == 0 {
+		return nil
+	}
+
+	lines := []string{requestStyle.Render(fmt.Sprintf("Redirects (%d):", len(resp.Redirects)))}
+	for i, hop := range resp.Redirects {
+		lines = append(lines, fmt.Sprintf("  [%d] %s %s → %s  %s", i, hop.Method, hop.URL, hop.Status, formatTimingDuration(hop.Duration)))
+		if hop.Location != "" {
+			lines = append(lines, folderStyle.Render("      Location: "+hop.Location))
+		}
+
+		keys := make([]string, 0, len(hop.Headers))
+		for key := range hop.Headers {
+			if key != "Location" {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			for _, value := range hop.Headers[key] {
+				lines = append(lines, markupNoteStyle.Render(fmt.Sprintf("      %s: %s", key, value)))
+			}
+		}
+	}
+
+	if resp.FinalURL != "" {
+		final := fmt.Sprintf("  [%d] %s %s", len(resp.Redirects), resp.FinalMethod, resp.FinalURL)
+		if resp.Error == nil {
+			final += " → " + resp.Status
+		}
+		lines = append(lines, final)
+	}
+	lines = append(lines, markupNoteStyle.Render("  Use :replay <n> to resend from a hop"))
+	return lines
+}
+
+func sameRedirectHost(from, to string) bool {
+	fromURL, err := url.Parse(from)
+	if err != nil {
+		return false
+	}
+	toURL, err := url.Parse(to)
+	if err != nil {
+		return false
+	}
+	fromHost, toHost := fromURL.Hostname(), toURL.Hostname()
+	return fromHost == toHost || strings.HasSuffix(toHost, "."+fromHost)
+}
+
+func (m Model) replayRedirectHop(arg string) (Model, tea.Cmd) {
+	targets := m.redirectTargets()
+	if len(targets) == 0 {
+		m.statusMessage = "No redirects to replay"
+		return m, nil
+	}
+
+	index, err := strconv.Atoi(arg)
+	if err != nil || index < 0 || index >= len(targets) {
+		m.statusMessage = fmt.Sprintf("Usage: :replay <0-%d>", len(targets)-1)
+		return m, nil
+	}
+
+	if len(m.currentItems) == 0 || m.cursor >= len(m.currentItems) {
+		m.statusMessage = "Cannot replay: request not found"
+		return m, nil
+	}
+	item := m.currentItems[m.cursor]
+	itemID := m.getRequestIdentifier(item)
+	if !item.IsRequest() || item.Request == nil || itemID != m.lastExecutedItemID {
+		m.statusMessage = "Cannot replay: request not found"
+		return m, nil
+	}
+
+	base := item.Request
+	isModified := m.isItemModified(itemID)
+	if modified, exists := m.modifiedRequests[itemID]; isModified && exists {
+		base = modified
+	}
+
+	target := targets[index]
+	request := *base
+	request.Method = target.method
+	request.URL = postman.URL{Raw: target.url}
+	if !strings.EqualFold(target.method, base.Method) {
+		request.Body = nil
+	}
+	if !sameRedirectHost(m.lastResponse.RequestURL, target.url) {
+		request.Header = nil
+		for _, header := range base.Header {
+			sensitive := false
+			for _, name := range sensitiveRedirectHeaders {
+				if strings.EqualFold(header.Key, name) {
+					sensitive = true
+				}
+			}
+			if !sensitive {
+				request.Header = append(request.Header, header)
+			}
+		}
+	}
+
+	m.statusMessage = fmt.Sprintf("Replaying hop %d: %s %s", index, target.method, target.url)
+	return m.sendRequest(item, itemID, &request, isModified)
+}
+
+func handleReplayCommand(m Model, args []string) (Model, tea.Cmd) {
+	return m.replayRedirectHop(strings.TrimSpace(strings.Join(args, " ")))
+}
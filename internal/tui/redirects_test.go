@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"postOffice/internal/postman"
+	"testing"
+
+	httpclient "postOffice/internal/http"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestReplayRedirectHop(t *testing.T) {
+	var received *http.Request
+	var receivedBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received, receivedBody = r, string(body)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	m := createTestModel()
+	m.width, m.height = 120, 60
+	m = selectRequest(t, m, "POST Request")
+	item := m.currentItems[m.cursor]
+	item.Request.Header = append(item.Request.Header, postman.Header{Key: "Authorization", Value: "Bearer secret"})
+	m.lastExecutedItemID = m.getRequestIdentifier(item)
+	m.mode = ModeResponse
+	m.lastResponse = &httpclient.Response{
+		StatusCode: 200,
+		Status:     "200 OK",
+		RequestURL: "https://example.com/api/create",
+		Redirects: []httpclient.RedirectHop{{
+			Method:     "POST",
+			URL:        "https://example.com/api/create",
+			StatusCode: 303,
+			Status:     "303 See Other",
+			Location:   server.URL + "/callback?code=abc",
+			Headers:    map[string][]string{"Set-Cookie": {"session=1"}},
+		}},
+		FinalMethod: "GET",
+		FinalURL:    server.URL + "/callback?code=abc",
+	}
+	m = m.refreshResponseViewport()
+
+	content := ansi.Strip(m.responseViewport.View())
+	for _, expected := range []string{"Redirects (1):", "[0] POST https://example.com/api/create → 303 See Other", "Location: " + server.URL + "/callback?code=abc", "Set-Cookie: session=1", "[1] GET " + server.URL + "/callback?code=abc → 200 OK", ":replay <n>"} {
+		if !contains(content, expected) {
+			t.Errorf("Expected %q in response view, got:\n%s", expected, content)
+		}
+	}
+
+	if updated, _ := handleReplayCommand(m, []string{"5"}); !contains(updated.statusMessage, "Usage: :replay <0-1>") {
+		t.Errorf("Expected usage for out-of-range hop, got: %s", updated.statusMessage)
+	}
+
+	m, cmd := handleReplayCommand(m, []string{"1"})
+	if cmd == nil {
+		t.Fatalf("Expected replay to send a request, status: %s", m.statusMessage)
+	}
+	msg, ok := cmd().(RequestCompleteMsg)
+	if !ok || msg.Response.Error != nil {
+		t.Fatalf("Expected replayed request to complete, got %+v", msg)
+	}
+
+	if received.Method != "GET" || received.URL.RequestURI() != "/callback?code=abc" {
+		t.Errorf("Expected GET /callback?code=abc, got %s %s", received.Method, received.URL.RequestURI())
+	}
+	if received.Header.Get("Authorization") != "" {
+		t.Error("Expected credentials to be dropped when replaying on another host")
+	}
+	if received.Header.Get("Content-Type") != "application/json" || receivedBody != "" {
+		t.Errorf("Expected other headers kept and body dropped, got %q / %q", received.Header.Get("Content-Type"), receivedBody)
+	}
+}
@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func (m Model) buildTransferSize() string {
+	resp := m.lastResponse
+	size := fmt.Sprintf("%s body", formatByteSize(int(resp.Size())))
+
+	encoding := headerValue(resp.Headers, "Content-Encoding")
+	if encoding != "" && resp.TransferSize != resp.Size() {
+		size = fmt.Sprintf("%s %s, %s decoded", formatByteSize(int(resp.TransferSize)), encoding, formatByteSize(int(resp.Size())))
+	}
+	return size
+}
+
+func (m Model) buildConnectionSummary() string {
+	resp := m.lastResponse
+	parts := []string{}
+	if resp.Connection.Proto != "" {
+		parts = append(parts, resp.Connection.Proto)
+	}
+	if resp.Connection.RemoteAddr != "" {
+		parts = append(parts, resp.Connection.RemoteAddr)
+	}
+	if resp.Connection.TLSVersion != "" {
+		parts = append(parts, resp.Connection.TLSVersion)
+	}
+	parts = append(parts, m.buildTransferSize())
+	return strings.Join(parts, " · ")
+}
+
+func (m Model) buildConnectionSection() []string {
+	resp := m.lastResponse
+	if !m.showConnection {
+		return []string{
+			requestStyle.Render("▸ Connection: ") + m.buildConnectionSummary() + markupNoteStyle.Render("  (c to expand)"),
+		}
+	}
+
+	lines := []string{requestStyle.Render("▾ Connection:") + markupNoteStyle.Render("  (c to collapse)")}
+	if resp.Connection.Proto != "" {
+		lines = append(lines, fmt.Sprintf("  Protocol: %s", resp.Connection.Proto))
+	}
+	if resp.Connection.RemoteAddr != "" {
+		lines = append(lines, fmt.Sprintf("  Remote address: %s", resp.Connection.RemoteAddr))
+	}
+
+	lines = append(lines, fmt.Sprintf("  Body size: %s (%d bytes)", formatByteSize(int(resp.Size())), resp.Size()))
+	if encoding := headerValue(resp.Headers, "Content-Encoding"); encoding != "" {
+		lines = append(lines, fmt.Sprintf("  Transferred: %s (%d bytes, %s)", formatByteSize(int(resp.TransferSize)), resp.TransferSize, encoding))
+	}
+	if resp.HeaderSize > 0 {
+		lines = append(lines, fmt.Sprintf("  Headers size: %s", formatByteSize(int(resp.HeaderSize))))
+	}
+
+	if resp.Connection.TLSVersion == "" {
+		return lines
+	}
+	lines = append(lines, fmt.Sprintf("  TLS: %s, %s", resp.Connection.TLSVersion, resp.Connection.CipherSuite))
+	if resp.Connection.ServerName != "" {
+		lines = append(lines, fmt.Sprintf("  Server name: %s", resp.Connection.ServerName))
+	}
+	if len(resp.Connection.Certificates) > 0 {
+		lines = append(lines, "  Certificate chain:")
+	}
+	for i, cert := range resp.Connection.Certificates {
+		lines = append(lines, fmt.Sprintf("    [%d] %s", i, cert.Subject))
+		lines = append(lines, folderStyle.Render(fmt.Sprintf("        Issuer: %s", cert.Issuer)))
+		lines = append(lines, folderStyle.Render(fmt.Sprintf("        Valid: %s to %s", cert.NotBefore.Format("2006-01-02"), cert.NotAfter.Format("2006-01-02"))))
+		if len(cert.DNSNames) > 0 {
+			lines = append(lines, folderStyle.Render(fmt.Sprintf("        DNS names: %s", strings.Join(cert.DNSNames, ", "))))
+		}
+	}
+	return lines
+}
+
+func handleConnectionKey(m Model) (Model, tea.Cmd) {
+	if m.lastResponse == nil || m.lastResponse.Error != nil {
+		return m, nil
+	}
+
+	m.showConnection = !m.showConnection
+	m = m.refreshResponseViewport()
+	return m, nil
+}
@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"postOffice/internal/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestConnectionSection_Toggle(t *testing.T) {
+	m := createTestModel()
+	m.width, m.height = 120, 60
+	m.mode = ModeResponse
+	m.lastResponse = &http.Response{
+		StatusCode:   200,
+		Status:       "200 OK",
+		Headers:      map[string][]string{"Content-Encoding": {"gzip"}},
+		Body:         strings.Repeat("a", 4096),
+		BodySize:     4096,
+		TransferSize: 512,
+		HeaderSize:   180,
+		Connection: http.ConnectionInfo{
+			Proto:       "HTTP/2.0",
+			RemoteAddr:  "93.184.216.34:443",
+			TLSVersion:  "TLS 1.3",
+			CipherSuite: "TLS_AES_128_GCM_SHA256",
+			ServerName:  "example.com",
+			Certificates: []http.CertificateInfo{{
+				Subject:   "CN=example.com",
+				Issuer:    "CN=Example CA",
+				DNSNames:  []string{"example.com", "www.example.com"},
+				NotBefore: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+				NotAfter:  time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+			}},
+		},
+	}
+	m = m.refreshResponseViewport()
+
+	content := ansi.Strip(m.responseViewport.View())
+	if !contains(content, "HTTP/2.0 · 93.184.216.34:443 · TLS 1.3 · 512 B gzip, 4.0 KB decoded") {
+		t.Errorf("Expected collapsed connection summary, got:\n%s", content)
+	}
+	if contains(content, "CN=Example CA") {
+		t.Error("Expected certificate chain to be hidden while collapsed")
+	}
+
+	m, _ = handleConnectionKey(m)
+	content = ansi.Strip(m.responseViewport.View())
+	for _, expected := range []string{"Remote address: 93.184.216.34:443", "Transferred: 512 B (512 bytes, gzip)", "TLS: TLS 1.3, TLS_AES_128_GCM_SHA256", "[0] CN=example.com", "Issuer: CN=Example CA", "Valid: 2026-01-01 to 2027-01-01", "DNS names: example.com, www.example.com"} {
+		if !contains(content, expected) {
+			t.Errorf("Expected %q in expanded connection section, got:\n%s", expected, content)
+		}
+	}
+
+	m, _ = handleConnectionKey(m)
+	if m.showConnection {
+		t.Error("Expected second toggle to collapse the section")
+	}
+}
@@ -37,7 +37,7 @@ func (m Model) buildEditLines() []string {
 
 	if m.editType == EditTypeScript {
 		lines = append(lines, m.buildScriptEditor()...)
-		shortcuts := "<:w> Save  <:wq> Save & Exit  <Esc> Cancel"
+		shortcuts := "<:w> Save  <:wq> Save & Exit  <ctrl+o> $EDITOR  <Esc> Cancel"
 		lines = append(lines, "")
 		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Render(shortcuts))
 		return lines
@@ -48,7 +48,7 @@ func (m Model) buildEditLines() []string {
 	}
 
 	lines = append(lines, "")
-	shortcuts := "<Enter> Edit field  <j/k> Navigate  <Esc> Cancel  <:w> Save  <:wq> Save & Exit"
+	shortcuts := "<Enter> Edit field  <j/k> Navigate  <ctrl+o> Body in $EDITOR  <u/ctrl+r> Undo/Redo  <Esc> Cancel  <:w> Save  <:wq> Save & Exit"
 	lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Render(shortcuts))
 
 	return lines
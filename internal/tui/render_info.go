@@ -188,6 +188,7 @@ func (m Model) buildRequestInfoLines() []string {
 	lines = append(lines, m.buildHeadersSection(req, variables)...)
 	lines = append(lines, m.buildBodySection(req, variables)...)
 	lines = append(lines, m.buildScriptsSection()...)
+	lines = append(lines, m.buildExamplesSection()...)
 	lines = append(lines, m.buildDescriptionSection()...)
 
 	return lines
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"postOffice/internal/postman"
 	"strings"
 	"time"
 
@@ -70,17 +71,25 @@ func (m Model) formatItemLine(index int) string {
 
 	if m.mode == ModeRequests && index < len(m.currentItems) {
 		item := m.currentItems[index]
+		if m.cutItem != nil && m.cutItem.id == item.ID {
+			modifiedPrefix = "✂ "
+		}
 		if item.IsRequest() {
 			itemID := m.getRequestIdentifier(item)
 			if m.isItemModified(itemID) {
 				modifiedPrefix = "* "
 			}
 
-			if exec, exists := m.requestExecutions[itemID]; exists {
+			if inFlight := m.inFlightRequests[itemID]; inFlight > 0 {
+				sending := "⟳ Sending..."
+				if inFlight > 1 {
+					sending += fmt.Sprintf(" (%d)", inFlight)
+				}
+				statusStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("14"))
+				executionInfo = "  " + statusStyle.Render(sending)
+			} else if exec, exists := m.requestExecutions[itemID]; exists {
 				statusColor := "8"
-				if exec.Status == "Sending..." {
-					statusColor = "14"
-				} else if strings.HasPrefix(exec.Status, "2") {
+				if strings.HasPrefix(exec.Status, "2") {
 					statusColor = "10"
 				} else if strings.HasPrefix(exec.Status, "3") {
 					statusColor = "11"
@@ -118,6 +127,10 @@ func (m Model) formatItemLine(index int) string {
 		return selectedItemStyle.Render(fullLine)
 	}
 
+	if m.mode == ModeChanges && m.pendingSync != nil && index < len(m.pendingSync.Changes) {
+		return syncChangeStyle(m.pendingSync.Changes[index].Type).Render(fullLine)
+	}
+
 	style := m.getItemStyle(m.items[index])
 	return style.Render(fullLine)
 }
@@ -146,6 +159,17 @@ func formatTimeAgo(d time.Duration) string {
 	}
 }
 
+func syncChangeStyle(changeType postman.SyncChangeType) lipgloss.Style {
+	switch changeType {
+	case postman.SyncAdded:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	case postman.SyncRemoved:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	default:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
+	}
+}
+
 func (m Model) getItemStyle(itemText string) lipgloss.Style {
 	if strings.HasPrefix(itemText, "[DIR]") {
 		return folderStyle
@@ -7,7 +7,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
-func (m Model) renderLogsView() string {
+func (m Model) refreshLogsViewport() Model {
 	logs := logger.GetLogs()
 
 	var content string
@@ -17,13 +17,17 @@ func (m Model) renderLogsView() string {
 		content = strings.Join(logs, "\n")
 	}
 
-	m.logsViewport.SetContent(content)
+	m.logsViewport.Width = m.width - 8
+	m.logsViewport.Height = m.height - 14
+	return m.setViewportContent(ModeLog, content)
+}
 
+func (m Model) renderLogsView() string {
 	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("214"))
 	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
 
 	title := titleStyle.Render("Session Logs")
-	help := helpStyle.Render("(j/k to scroll, esc to close)")
+	help := helpStyle.Render("(j/k to scroll, / to search, esc to close)")
 
 	header := title + " " + help
 
@@ -44,6 +44,10 @@ func (m Model) renderResponseView() string {
 func (m Model) buildResponseLines() []string {
 	var lines []string
 
+	if m.responseExample != "" {
+		lines = append(lines, diffChangedStyle.Render("Saved example: "+m.responseExample+" (ctrl+r for a live call)"))
+		lines = append(lines, "")
+	}
 	lines = append(lines, m.buildRequestSection()...)
 	lines = append(lines, "")
 	lines = append(lines, m.buildResponseSection()...)
@@ -95,6 +99,10 @@ func (m Model) buildResponseSection() []string {
 	if m.lastResponse.Error != nil {
 		lines = append(lines, requestStyle.Render("Error:"))
 		lines = append(lines, m.lastResponse.Error.Error())
+		if redirects := m.buildRedirectSection(); len(redirects) > 0 {
+			lines = append(lines, "")
+			lines = append(lines, redirects...)
+		}
 	} else {
 		statusStyle := requestStyle
 		if m.lastResponse.StatusCode < 200 || m.lastResponse.StatusCode >= 300 {
@@ -104,6 +112,19 @@ func (m Model) buildResponseSection() []string {
 		lines = append(lines, folderStyle.Render(fmt.Sprintf("Duration: %v", m.lastResponse.Duration)))
 		lines = append(lines, "")
 
+		if waterfall := buildTimingWaterfall(m.lastResponse.Timings, m.width-50); len(waterfall) > 0 {
+			lines = append(lines, waterfall...)
+			lines = append(lines, "")
+		}
+
+		lines = append(lines, m.buildConnectionSection()...)
+		lines = append(lines, "")
+
+		if redirects := m.buildRedirectSection(); len(redirects) > 0 {
+			lines = append(lines, redirects...)
+			lines = append(lines, "")
+		}
+
 		if len(m.lastResponse.Headers) > 0 {
 			lines = append(lines, requestStyle.Render("Response Headers:"))
 			for key, values := range m.lastResponse.Headers {
@@ -114,10 +135,12 @@ func (m Model) buildResponseSection() []string {
 			lines = append(lines, "")
 		}
 
-		if m.lastResponse.Body != "" {
-			lines = append(lines, requestStyle.Render("Response Body:"))
-			bodyLines := strings.Split(m.lastResponse.Body, "\n")
-			lines = append(lines, bodyLines...)
+		if m.lastResponse.BodyFile != "" {
+			lines = append(lines, m.buildBodySummaryLines()...)
+		} else if m.lastResponse.Body != "" && m.responseFilter != "" {
+			lines = append(lines, m.buildFilteredBodyLines(m.lastResponse.Body)...)
+		} else if m.lastResponse.Body != "" {
+			lines = append(lines, m.buildBodyLines(m.lastResponse.Body, headerValue(m.lastResponse.Headers, "Content-Type"))...)
 		}
 	}
 
@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"fmt"
+	"postOffice/internal/http"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var timingPhaseColors = map[string]lipgloss.Color{
+	"Pre-request": lipgloss.Color("8"),
+	"Redirects":   lipgloss.Color("1"),
+	"DNS":         lipgloss.Color("6"),
+	"Connect":     lipgloss.Color("3"),
+	"TLS":         lipgloss.Color("5"),
+	"TTFB":        lipgloss.Color("2"),
+	"Download":    lipgloss.Color("4"),
+	"Tests":       lipgloss.Color("8"),
+}
+
+func formatTimingDuration(d time.Duration) string {
+	if d < time.Millisecond {
+		return fmt.Sprintf("%.2fms", float64(d.Microseconds())/1000)
+	}
+	return fmt.Sprintf("%.1fms", float64(d.Microseconds())/1000)
+}
+
+func buildTimingWaterfall(timings http.Timings, barWidth int) []string {
+	total := timings.Total()
+	if total <= 0 {
+		return nil
+	}
+	barWidth = max(barWidth, 10)
+
+	lines := []string{requestStyle.Render("Timing:")}
+	for _, phase := range timings.Phases() {
+		if phase.Duration <= 0 {
+			continue
+		}
+
+		offset := int(float64(phase.Start) / float64(total) * float64(barWidth))
+		length := max(int(float64(phase.Duration)/float64(total)*float64(barWidth)+0.5), 1)
+		offset = min(offset, barWidth-1)
+		length = min(length, barWidth-offset)
+
+		bar := strings.Repeat(" ", offset) +
+			lipgloss.NewStyle().Foreground(timingPhaseColors[phase.Name]).Render(strings.Repeat("█", length)) +
+			strings.Repeat(" ", barWidth-offset-length)
+		lines = append(lines, fmt.Sprintf("  %-12s %s %10s", phase.Name, bar, formatTimingDuration(phase.Duration)))
+	}
+
+	summary := fmt.Sprintf("  %-12s %s %10s", "Total", strings.Repeat(" ", barWidth), formatTimingDuration(total))
+	if timings.ConnectionReused {
+		summary += folderStyle.Render("  (connection reused)")
+	}
+	return append(lines, summary)
+}
@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"postOffice/internal/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestBuildTimingWaterfall(t *testing.T) {
+	timings := http.Timings{
+		DNS:              2 * time.Millisecond,
+		Connect:          8 * time.Millisecond,
+		TTFB:             30 * time.Millisecond,
+		Download:         10 * time.Millisecond,
+		ConnectionReused: true,
+	}
+
+	lines := buildTimingWaterfall(timings, 20)
+	content := ansi.Strip(strings.Join(lines, "\n"))
+
+	for _, expected := range []string{"Timing:", "DNS", "Connect", "TTFB", "Download", "30.0ms", "Total", "50.0ms", "(connection reused)"} {
+		if !contains(content, expected) {
+			t.Errorf("Expected %q in waterfall, got:\n%s", expected, content)
+		}
+	}
+	for _, unexpected := range []string{"TLS", "Pre-request", "Tests"} {
+		if contains(content, unexpected) {
+			t.Errorf("Expected zero-length phase %q to be omitted, got:\n%s", unexpected, content)
+		}
+	}
+	if len(lines) != 6 {
+		t.Errorf("Expected header, 4 phases and total, got %d lines", len(lines))
+	}
+
+	if lines := buildTimingWaterfall(http.Timings{}, 20); lines != nil {
+		t.Errorf("Expected no waterfall without timings, got %v", lines)
+	}
+}
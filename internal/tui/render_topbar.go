@@ -184,6 +184,10 @@ func (m Model) getModeString() string {
 		return "Logs"
 	case ModeFileBrowser:
 		return "File Browser"
+	case ModeRunner:
+		return "Runner"
+	case ModeLoadTest:
+		return "Load Test"
 	default:
 		return ""
 	}
@@ -278,6 +282,12 @@ func (m Model) renderCommandBar() string {
 	if m.searchMode {
 		return commandBarStyle.Width(m.width).Render("/" + m.searchInput.View())
 	}
+	if m.filterMode {
+		return commandBarStyle.Width(m.width).Render("filter: " + m.filterInput.View())
+	}
+	if status := m.viewportSearchStatus(); status != "" {
+		return commandBarStyle.Width(m.width).Render(status)
+	}
 	return commandBarStyle.Width(m.width).Render("")
 }
 
@@ -286,9 +296,13 @@ func (m Model) renderStatusBar() string {
 
 	switch m.mode {
 	case ModeResponse:
-		help = "ctrl+r: resend | q: close | j/k: scroll"
+		help = "ctrl+r: resend | F: filter | /: search | q: close | j/k: scroll"
 	case ModeInfo, ModeJSON, ModeLog:
-		help = "q: close | j/k: scroll"
+		help = "/: search | q: close | j/k: scroll"
+	case ModeRunner:
+		help = "enter: open response | p: pause/resume | x: stop | esc: close | j/k: navigate"
+	case ModeLoadTest:
+		help = "x: stop | esc: close"
 	default:
 		help = "q: quit | ↑↓/jk: navigate | enter: select | backspace/h: back | /: search | :: command"
 	}
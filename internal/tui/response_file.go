@@ -0,0 +1,145 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"postOffice/internal/http"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sessionLogLimit bounds how many responses the session log keeps for
+// :export har; the oldest ones are dropped first.
+const sessionLogLimit = 500
+
+// sessionLog records every response received in this session, oldest first,
+// so re-sending a request does not lose its earlier executions. It is shared
+// between copies of the Model.
+type sessionLog struct {
+	responses []*http.Response
+}
+
+func (m *Model) SetMaxBodyBytes(limit int64) {
+	m.executor.SetMaxBodyBytes(limit)
+}
+
+func (m Model) RemoveResponseFiles() {
+	for _, exec := range m.requestExecutions {
+		if exec.Response != nil {
+			exec.Response.RemoveBodyFile()
+		}
+	}
+	if m.lastResponse != nil {
+		m.lastResponse.RemoveBodyFile()
+	}
+	for _, resp := range m.sessionLog.responses {
+		resp.RemoveBodyFile()
+	}
+	m.removeRunnerFiles()
+}
+
+// setRequestExecution records exec as the latest execution of itemID, appends
+// its response to the session log and removes the body file of any response
+// that is replaced or dropped from the log once nothing else refers to it.
+func (m Model) setRequestExecution(itemID string, exec *RequestExecution) {
+	previous, exists := m.requestExecutions[itemID]
+	m.requestExecutions[itemID] = exec
+
+	var released []*http.Response
+	if exists && previous.Response != nil {
+		released = append(released, previous.Response)
+	}
+	if exec.Response != nil {
+		log := m.sessionLog
+		log.responses = append(log.responses, exec.Response)
+		if overflow := len(log.responses) - sessionLogLimit; overflow > 0 {
+			released = append(released, log.responses[:overflow]...)
+			log.responses = append([]*http.Response(nil), log.responses[overflow:]...)
+		}
+	}
+
+	for _, resp := range released {
+		if !m.responseInUse(resp, true) {
+			resp.RemoveBodyFile()
+		}
+	}
+}
+
+// responseInUse reports whether resp is the open response, an item's last
+// execution or in the session log, or, when includeRunner is set, a result of
+// the current run.
+func (m Model) responseInUse(resp *http.Response, includeRunner bool) bool {
+	if resp == m.lastResponse {
+		return true
+	}
+	for _, exec := range m.requestExecutions {
+		if exec.Response == resp {
+			return true
+		}
+	}
+	if slices.Contains(m.sessionLog.responses, resp) {
+		return true
+	}
+	if includeRunner && m.runner != nil {
+		for _, result := range m.runner.results {
+			if result.Response == resp {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (m Model) buildBodySummaryLines() []string {
+	resp := m.lastResponse
+
+	reason := "too large to display"
+	if resp.Binary {
+		reason = "binary"
+	}
+
+	contentType := headerValue(resp.Headers, "Content-Type")
+	if contentType == "" {
+		contentType = "unknown"
+	}
+
+	return []string{
+		requestStyle.Render(fmt.Sprintf("Response Body (%s, not shown):", reason)),
+		fmt.Sprintf("  Content-Type: %s", contentType),
+		fmt.Sprintf("  Size: %s (%d bytes)", formatByteSize(int(resp.BodySize)), resp.BodySize),
+		fmt.Sprintf("  SHA-256: %s", resp.BodySHA256),
+		fmt.Sprintf("  Stored in: %s", resp.BodyFile),
+		markupNoteStyle.Render("  Use :saveresponse <path> to write it to a file"),
+	}
+}
+
+func (m Model) saveResponseBody(path string) Model {
+	if m.lastResponse == nil || m.lastResponse.Error != nil {
+		m.statusMessage = "No response body to save"
+		return m
+	}
+	if path == "" {
+		m.statusMessage = "Usage: :saveresponse <path>"
+		return m
+	}
+
+	path = expandPath(path)
+	if _, err := os.Stat(path); err == nil {
+		m.statusMessage = fmt.Sprintf("File already exists: %s", path)
+		return m
+	}
+
+	if err := m.lastResponse.WriteBody(path); err != nil {
+		m.statusMessage = fmt.Sprintf("Failed to save response: %v", err)
+		return m
+	}
+
+	m.statusMessage = fmt.Sprintf("Saved response body (%s) to %s", formatByteSize(int(m.lastResponse.Size())), path)
+	return m
+}
+
+func handleSaveResponseCommand(m Model, args []string) (Model, tea.Cmd) {
+	return m.saveResponseBody(strings.TrimSpace(strings.Join(args, " "))), nil
+}
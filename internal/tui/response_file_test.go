@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"postOffice/internal/http"
+	"postOffice/internal/runner"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func createStreamedResponseModel(t *testing.T) Model {
+	t.Helper()
+	bodyFile := filepath.Join(t.TempDir(), "body")
+	if err := os.WriteFile(bodyFile, []byte("\x89PNG\r\n"), 0644); err != nil {
+		t.Fatalf("Failed to write body file: %v", err)
+	}
+
+	m := createTestModel()
+	m.width, m.height = 120, 40
+	m.mode = ModeResponse
+	m.lastResponse = &http.Response{
+		StatusCode: 200,
+		Status:     "200 OK",
+		Headers:    map[string][]string{"Content-Type": {"image/png"}},
+		BodyFile:   bodyFile,
+		BodySize:   6,
+		BodySHA256: "abc123",
+		Binary:     true,
+	}
+	return m.refreshResponseViewport()
+}
+
+func TestResponseView_ShowsSummaryForStreamedBody(t *testing.T) {
+	m := createStreamedResponseModel(t)
+
+	content := ansi.Strip(m.responseViewport.View())
+	for _, expected := range []string{"Response Body (binary, not shown):", "Content-Type: image/png", "Size: 6 B (6 bytes)", "SHA-256: abc123", ":saveresponse"} {
+		if !contains(content, expected) {
+			t.Errorf("Expected %q in response view, got:\n%s", expected, content)
+		}
+	}
+}
+
+func TestSaveResponseCommand(t *testing.T) {
+	m := createStreamedResponseModel(t)
+	target := filepath.Join(t.TempDir(), "image.png")
+
+	m, _ = handleSaveResponseCommand(m, []string{target})
+	data, err := os.ReadFile(target)
+	if err != nil || string(data) != "\x89PNG\r\n" {
+		t.Fatalf("Expected body to be written, got %q, %v (status: %s)", data, err, m.statusMessage)
+	}
+
+	m, _ = handleSaveResponseCommand(m, []string{target})
+	if !contains(m.statusMessage, "already exists") {
+		t.Errorf("Expected existing files to be left alone, got: %s", m.statusMessage)
+	}
+
+	m.lastResponse = &http.Response{StatusCode: 200, Body: `{"a":1}`}
+	inMemoryTarget := filepath.Join(t.TempDir(), "body.json")
+	m, _ = handleSaveResponseCommand(m, []string{inMemoryTarget})
+	if data, _ := os.ReadFile(inMemoryTarget); string(data) != `{"a":1}` {
+		t.Errorf("Expected in-memory body to be written, got %q", data)
+	}
+}
+
+func TestRemoveResponseFiles(t *testing.T) {
+	m := createStreamedResponseModel(t)
+	bodyFile := m.lastResponse.BodyFile
+	m.requestExecutions["id"] = &RequestExecution{Response: m.lastResponse}
+
+	m.RemoveResponseFiles()
+	if _, err := os.Stat(bodyFile); !os.IsNotExist(err) {
+		t.Error("Expected streamed body files to be removed")
+	}
+}
+
+func TestSetRequestExecution_RemovesReplacedBodyFilesOnlyWhenUnused(t *testing.T) {
+	spilled := func(name string) *http.Response {
+		path := filepath.Join(t.TempDir(), name)
+		if err := os.WriteFile(path, []byte(name), 0644); err != nil {
+			t.Fatalf("Failed to write body file: %v", err)
+		}
+		return &http.Response{BodyFile: path}
+	}
+	exists := func(resp *http.Response) bool {
+		_, err := os.Stat(resp.BodyFile)
+		return err == nil
+	}
+
+	m := createTestModel()
+	first, second, third := spilled("first"), spilled("second"), spilled("third")
+	m.runner = &runnerState{results: []runner.RequestResult{{Response: first}, {Response: second}}}
+
+	m.setRequestExecution("item", &RequestExecution{Response: first})
+	m.setRequestExecution("item", &RequestExecution{Response: second})
+	if !exists(first) {
+		t.Fatal("Expected a response still listed in the runner to keep its body file")
+	}
+
+	m.removeRunnerFiles()
+	if !exists(first) || !exists(second) {
+		t.Fatal("Expected responses in the session log to keep their body files")
+	}
+
+	m.runner = nil
+	m.setRequestExecution("item", &RequestExecution{Response: third})
+	for i := 0; i < sessionLogLimit; i++ {
+		m.setRequestExecution("other", &RequestExecution{Response: &http.Response{Body: "ok"}})
+	}
+	if exists(first) || exists(second) {
+		t.Error("Expected body files dropped from the session log to be removed")
+	}
+	if !exists(third) {
+		t.Error("Expected the item's last execution to keep its body file")
+	}
+	if len(m.sessionLog.responses) != sessionLogLimit {
+		t.Errorf("Expected the session log to be capped at %d, got %d", sessionLogLimit, len(m.sessionLog.responses))
+	}
+}
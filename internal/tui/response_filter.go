@@ -0,0 +1,355 @@
+package tui
+
+import (
+	"fmt"
+	"postOffice/internal/postman"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tidwall/gjson"
+)
+
+const maxFilterHistory = 50
+
+var jqFunctions = map[string]string{
+	"length":  "#",
+	"keys":    "@keys",
+	"values":  "@values",
+	"reverse": "@reverse",
+	"flatten": "@flatten",
+}
+
+func splitTopLevel(expr string, separator byte) []string {
+	var parts []string
+	depth := 0
+	quote := byte(0)
+	start := 0
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '(':
+			depth++
+		case c == ']' || c == ')':
+			depth--
+		case c == separator && depth == 0:
+			parts = append(parts, expr[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, expr[start:])
+}
+
+func escapeGJSONKey(key string) string {
+	var out strings.Builder
+	for _, r := range key {
+		if strings.ContainsRune(`.*?|#@\`, r) {
+			out.WriteByte('\\')
+		}
+		out.WriteRune(r)
+	}
+	return out.String()
+}
+
+func jqPathToGJSON(path string) (string, error) {
+	var components []string
+	rest := path
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, "[]"):
+			components = append(components, "#")
+			rest = rest[2:]
+		case strings.HasPrefix(rest, "["):
+			end := strings.Index(rest, "]")
+			if end == -1 {
+				return "", fmt.Errorf("unclosed [ in %q", path)
+			}
+			inner := strings.TrimSpace(rest[1:end])
+			if unquoted, err := strconv.Unquote(inner); err == nil {
+				components = append(components, escapeGJSONKey(unquoted))
+			} else if _, err := strconv.Atoi(inner); err == nil {
+				components = append(components, inner)
+			} else {
+				return "", fmt.Errorf("unsupported index %q", inner)
+			}
+			rest = rest[end+1:]
+		case strings.HasPrefix(rest, "."):
+			rest = rest[1:]
+			if strings.HasPrefix(rest, "\"") {
+				end := strings.Index(rest[1:], "\"")
+				if end == -1 {
+					return "", fmt.Errorf("unclosed quote in %q", path)
+				}
+				components = append(components, escapeGJSONKey(rest[1:end+1]))
+				rest = rest[end+2:]
+				continue
+			}
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+			if end > 0 {
+				components = append(components, escapeGJSONKey(rest[:end]))
+			}
+			rest = rest[end:]
+		default:
+			return "", fmt.Errorf("unexpected %q", rest)
+		}
+	}
+
+	if len(components) > 0 && components[len(components)-1] == "#" {
+		components = components[:len(components)-1]
+	}
+	if len(components) == 0 {
+		return "@this", nil
+	}
+	return strings.Join(components, "."), nil
+}
+
+func filterToGJSON(expr string) (string, error) {
+	expr = strings.TrimSpace(expr)
+	if !strings.HasPrefix(expr, ".") {
+		return expr, nil
+	}
+
+	var stages []string
+	for _, stage := range splitTopLevel(expr, '|') {
+		stage = strings.TrimSpace(stage)
+		if function, exists := jqFunctions[stage]; exists {
+			if len(stages) == 0 {
+				stages = append(stages, "@this")
+			}
+			stages = append(stages, function)
+			continue
+		}
+		if !strings.HasPrefix(stage, ".") {
+			return "", fmt.Errorf("unsupported jq expression %q", stage)
+		}
+		path, err := jqPathToGJSON(stage)
+		if err != nil {
+			return "", err
+		}
+		stages = append(stages, path)
+	}
+	return strings.Join(stages, "|"), nil
+}
+
+func applyResponseFilter(body string, expr string) (gjson.Result, error) {
+	path, err := filterToGJSON(expr)
+	if err != nil {
+		return gjson.Result{}, err
+	}
+	if !gjson.Valid(body) {
+		return gjson.Result{}, fmt.Errorf("response body is not JSON")
+	}
+
+	result := gjson.Get(body, path)
+	if !result.Exists() {
+		return result, fmt.Errorf("no match for %s", expr)
+	}
+	return result, nil
+}
+
+func filterResultLines(result gjson.Result) []string {
+	switch {
+	case result.IsObject() || result.IsArray():
+		return prettyBodyLines(result.Raw, "json")
+	case result.Type == gjson.String:
+		return rawBodyLines(result.String())
+	}
+	return []string{highlightJSONLine(result.Raw)}
+}
+
+func (m Model) buildFilteredBodyLines(body string) []string {
+	label := requestStyle.Render(fmt.Sprintf("Response Body (filter: %s):", m.responseFilter)) + markupNoteStyle.Render("  F to change, :filter to clear")
+
+	result, err := applyResponseFilter(body, m.responseFilter)
+	if err != nil {
+		return []string{label, markupNoteStyle.Render(err.Error())}
+	}
+
+	lines := filterResultLines(result)
+	if len(lines) > maxBodyLines {
+		hidden := len(lines) - maxBodyLines
+		lines = append(lines[:maxBodyLines:maxBodyLines], markupNoteStyle.Render(fmt.Sprintf("... %d more lines not shown", hidden)))
+	}
+	return append([]string{label}, lines...)
+}
+
+func (m Model) setResponseFilter(expr string) Model {
+	expr = strings.TrimSpace(expr)
+	m.responseFilter = expr
+
+	if expr == "" {
+		m.statusMessage = "Filter cleared"
+	} else {
+		m.filterHistory = append(slices.DeleteFunc(slices.Clone(m.filterHistory), func(v string) bool { return v == expr }), expr)
+		if len(m.filterHistory) > maxFilterHistory {
+			m.filterHistory = m.filterHistory[len(m.filterHistory)-maxFilterHistory:]
+		}
+
+		if m.lastResponse != nil {
+			if result, err := applyResponseFilter(m.lastResponse.Body, expr); err != nil {
+				m.statusMessage = fmt.Sprintf("Filter: %v", err)
+			} else if result.IsArray() {
+				m.statusMessage = fmt.Sprintf("Filter matched %d items", len(result.Array()))
+			} else {
+				m.statusMessage = "Filter applied"
+			}
+		}
+	}
+
+	if m.mode == ModeResponse {
+		m = m.refreshResponseViewport()
+		m.responseViewport.GotoTop()
+	}
+	return m
+}
+
+func (m Model) handleFilterMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.filterMode = false
+		m.filterInput.Blur()
+		m.filterHistoryIndex = -1
+		m.statusMessage = "Filter unchanged"
+		return m, nil
+
+	case tea.KeyEnter:
+		m.filterMode = false
+		m.filterInput.Blur()
+		m.filterHistoryIndex = -1
+		return m.setResponseFilter(m.filterInput.Value()), nil
+
+	case tea.KeyUp:
+		if len(m.filterHistory) > 0 {
+			if m.filterHistoryIndex == -1 {
+				m.filterHistoryIndex = len(m.filterHistory) - 1
+			} else if m.filterHistoryIndex > 0 {
+				m.filterHistoryIndex--
+			}
+			m.filterInput.SetValue(m.filterHistory[m.filterHistoryIndex])
+			m.filterInput.CursorEnd()
+		}
+		return m, nil
+
+	case tea.KeyDown:
+		if m.filterHistoryIndex >= 0 {
+			if m.filterHistoryIndex < len(m.filterHistory)-1 {
+				m.filterHistoryIndex++
+				m.filterInput.SetValue(m.filterHistory[m.filterHistoryIndex])
+			} else {
+				m.filterHistoryIndex = -1
+				m.filterInput.SetValue("")
+			}
+			m.filterInput.CursorEnd()
+		}
+		return m, nil
+
+	default:
+		m.filterInput, cmd = m.filterInput.Update(msg)
+		return m, cmd
+	}
+}
+
+func (m Model) setEnvironmentVariable(key string, value string) Model {
+	if m.environment == nil {
+		m.statusMessage = "No active environment. Select one with :env first"
+		return m
+	}
+
+	before := m.captureState(nil, []string{m.environment.Name})
+
+	found := false
+	for i := range m.environment.Values {
+		if m.environment.Values[i].Key == key {
+			m.environment.Values[i].Value = value
+			m.environment.Values[i].Enabled = true
+			found = true
+			break
+		}
+	}
+	if !found {
+		m.environment.Values = append(m.environment.Values, postman.EnvVariable{
+			Key:     key,
+			Value:   value,
+			Enabled: true,
+			Type:    "default",
+		})
+	}
+
+	m.modifiedEnvironments[m.environment.Name] = true
+	m = m.recordChange(fmt.Sprintf("Set variable %s", key), before)
+
+	preview := value
+	if runes := []rune(preview); len(runes) > 40 {
+		preview = string(runes[:40]) + "..."
+	}
+	m.statusMessage = fmt.Sprintf("Set %s = %s in %s (use :w to write to file)", key, preview, m.environment.Name)
+	return m
+}
+
+func handleFilterKey(m Model) (Model, tea.Cmd) {
+	if m.lastResponse == nil {
+		m.statusMessage = "No response to filter"
+		return m, nil
+	}
+
+	m.filterMode = true
+	m.filterHistoryIndex = -1
+	m.filterInput.SetValue(m.responseFilter)
+	m.filterInput.CursorEnd()
+	m.statusMessage = "Filter with a gjson path or jq expression (↑/↓ history, Enter to apply, empty to clear)"
+	return m, m.filterInput.Focus()
+}
+
+func handleFilterCommand(m Model, args []string) (Model, tea.Cmd) {
+	return m.setResponseFilter(strings.Join(args, " ")), nil
+}
+
+func handleSetVarKey(m Model) (Model, tea.Cmd) {
+	m.commandMode = true
+	m.commandInput.SetValue("setvar ")
+	m.commandInput.CursorEnd()
+	return m, m.commandInput.Focus()
+}
+
+func handleSetVarCommand(m Model, args []string) (Model, tea.Cmd) {
+	fields := strings.Fields(strings.Join(args, " "))
+	if len(fields) == 0 {
+		m.statusMessage = "Usage: :setvar <name> [gjson path or jq expression]"
+		return m, nil
+	}
+	if m.lastResponse == nil {
+		m.statusMessage = "No response to extract a value from"
+		return m, nil
+	}
+
+	expr := m.responseFilter
+	if len(fields) > 1 {
+		expr = strings.Join(fields[1:], " ")
+	}
+
+	value := m.lastResponse.Body
+	if expr != "" {
+		result, err := applyResponseFilter(m.lastResponse.Body, expr)
+		if err != nil {
+			m.statusMessage = fmt.Sprintf("Cannot set %s: %v", fields[0], err)
+			return m, nil
+		}
+		value = result.String()
+	}
+
+	return m.setEnvironmentVariable(fields[0], value), nil
+}
@@ -0,0 +1,180 @@
+package tui
+
+import (
+	"path/filepath"
+	"postOffice/internal/http"
+	"postOffice/internal/postman"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const filterTestBody = `{"items":[{"id":1,"name":"first"},{"id":2,"name":"second"}],"meta":{"total":2,"next.page":"abc"}}`
+
+func createFilterTestModel() Model {
+	m := createTestModel()
+	m.width, m.height = 120, 40
+	m.mode = ModeResponse
+	m.lastResponse = &http.Response{
+		StatusCode: 200,
+		Status:     "200 OK",
+		Headers:    map[string][]string{"Content-Type": {"application/json"}},
+		Body:       filterTestBody,
+	}
+	return m
+}
+
+func TestFilterToGJSON(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected string
+	}{
+		{"items.#.name", "items.#.name"},
+		{".", "@this"},
+		{".items[0].name", "items.0.name"},
+		{".items[].id", "items.#.id"},
+		{".items[]", "items"},
+		{`.meta["next.page"]`, `meta.next\.page`},
+		{`.meta."next.page"`, `meta.next\.page`},
+		{".items | length", "items|#"},
+		{". | keys", "@this|@keys"},
+	}
+
+	for _, tt := range tests {
+		got, err := filterToGJSON(tt.expr)
+		if err != nil || got != tt.expected {
+			t.Errorf("filterToGJSON(%q) = %q, %v; expected %q", tt.expr, got, err, tt.expected)
+		}
+	}
+
+	if _, err := filterToGJSON(".items | select(.id > 1)"); err == nil {
+		t.Error("Expected unsupported jq functions to be rejected")
+	}
+}
+
+func TestApplyResponseFilter(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected string
+	}{
+		{"items.#.name", `["first","second"]`},
+		{".items[1].name", `"second"`},
+		{".items | length", "2"},
+		{`.meta["next.page"]`, `"abc"`},
+		{"items.#(id==2).name", `"second"`},
+	}
+
+	for _, tt := range tests {
+		result, err := applyResponseFilter(filterTestBody, tt.expr)
+		if err != nil || result.Raw != tt.expected {
+			t.Errorf("applyResponseFilter(%q) = %s, %v; expected %s", tt.expr, result.Raw, err, tt.expected)
+		}
+	}
+
+	if _, err := applyResponseFilter(filterTestBody, "missing"); err == nil {
+		t.Error("Expected an error for a path without a match")
+	}
+	if _, err := applyResponseFilter("<html/>", "a"); err == nil {
+		t.Error("Expected an error for a non-JSON body")
+	}
+}
+
+func TestFilterPrompt_AppliesAndKeepsHistory(t *testing.T) {
+	m := createFilterTestModel()
+
+	for _, expr := range []string{"items.#.id", ".meta.total"} {
+		m, _ = handleFilterKey(m)
+		if !m.filterMode {
+			t.Fatal("Expected F to open the filter prompt")
+		}
+		m.filterInput.SetValue(expr)
+		newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m = newModel.(Model)
+	}
+
+	if m.filterMode || m.responseFilter != ".meta.total" {
+		t.Fatalf("Expected filter to be applied, got %q", m.responseFilter)
+	}
+	content := ansi.Strip(m.responseViewport.View())
+	if !contains(content, "Response Body (filter: .meta.total)") || contains(content, "first") {
+		t.Errorf("Expected only the filtered value to be shown, got:\n%s", content)
+	}
+
+	m, _ = handleFilterKey(m)
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m = newModel.(Model)
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m = newModel.(Model)
+	if m.filterInput.Value() != "items.#.id" {
+		t.Errorf("Expected history to recall the earlier filter, got %q", m.filterInput.Value())
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	if m.filterMode || m.responseFilter != ".meta.total" {
+		t.Error("Expected esc to leave the active filter unchanged")
+	}
+
+	m, _ = handleFilterCommand(m, nil)
+	if m.responseFilter != "" || !contains(ansi.Strip(m.responseViewport.View()), "Response Body (pretty") {
+		t.Error("Expected :filter without arguments to clear the filter")
+	}
+	if len(m.filterHistory) != 2 {
+		t.Errorf("Expected history to be kept after clearing, got %v", m.filterHistory)
+	}
+}
+
+func TestSetVarCommand(t *testing.T) {
+	m := createFilterTestModel()
+	environment, err := m.parser.NewEnvironment("Dev", filepath.Join(t.TempDir(), "dev.json"))
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+	environment.Values = []postman.EnvVariable{{Key: "firstId", Value: "old", Enabled: true}}
+	m.environment = environment
+
+	m, _ = handleFilterCommand(m, []string{".items[0].id"})
+	m, _ = handleSetVarCommand(m, []string{"firstId"})
+	if m.environment.Values[0].Value != "1" {
+		t.Errorf("Expected filtered value to be stored, got %q", m.environment.Values[0].Value)
+	}
+
+	m, _ = handleSetVarCommand(m, []string{"names items.#.name"})
+	if len(m.environment.Values) != 2 || m.environment.Values[1].Key != "names" || m.environment.Values[1].Value != `["first","second"]` {
+		t.Errorf("Expected new variable from explicit expression, got %+v", m.environment.Values)
+	}
+	if !m.modifiedEnvironments["Dev"] {
+		t.Error("Expected environment to be marked as modified")
+	}
+
+	m, _ = handleUndoKey(m)
+	if len(m.environment.Values) != 1 {
+		t.Errorf("Expected undo to remove the new variable, got %+v", m.environment.Values)
+	}
+
+	m, _ = handleSetVarCommand(m, []string{"x missing"})
+	if !contains(m.statusMessage, "no match") {
+		t.Errorf("Unexpected status: %s", m.statusMessage)
+	}
+}
+
+func TestSetVarCommand_PreviewKeepsRunesIntact(t *testing.T) {
+	m := createFilterTestModel()
+	m.lastResponse.Body = `{"greeting":"` + strings.Repeat("é", 39) + `ü€ and more"}`
+	environment, err := m.parser.NewEnvironment("Dev", filepath.Join(t.TempDir(), "dev.json"))
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+	m.environment = environment
+
+	m, _ = handleSetVarCommand(m, []string{"greeting greeting"})
+	if !utf8.ValidString(m.statusMessage) {
+		t.Fatalf("Expected a valid UTF-8 status, got %q", m.statusMessage)
+	}
+	if !contains(m.statusMessage, strings.Repeat("é", 39)+"ü...") {
+		t.Errorf("Expected the preview to be cut after 40 runes, got %q", m.statusMessage)
+	}
+}
@@ -0,0 +1,160 @@
+package tui
+
+import (
+	"fmt"
+	"postOffice/internal/postman"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func (m Model) startResync(specPath string) Model {
+	if m.collection == nil {
+		m.statusMessage = "No collection loaded"
+		return m
+	}
+
+	if specPath == "" {
+		path, exists := m.parser.GetSpecPath(m.collection.Info.Name)
+		if !exists {
+			m.statusMessage = "Collection was not imported from an OpenAPI spec (use :resync <spec-path>)"
+			return m
+		}
+		specPath = path
+	}
+
+	spec, err := m.parser.LoadOpenAPISpec(specPath)
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Failed to load spec: %v", err)
+		return m
+	}
+
+	plan, err := postman.PlanOpenAPISync(m.collection, spec)
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Failed to compare spec: %v", err)
+		return m
+	}
+
+	if len(plan.Changes) == 0 {
+		m.statusMessage = "Collection is already in sync with the spec"
+		return m
+	}
+
+	m.pendingSync = plan
+	m.mode = ModeChanges
+	m.cursor = 0
+	m = m.loadSyncChangesList()
+	return m
+}
+
+func (m Model) loadSyncChangesList() Model {
+	m.items = []string{}
+	added, updated, removed := 0, 0, 0
+	for _, change := range m.pendingSync.Changes {
+		m.items = append(m.items, change.Label())
+		switch change.Type {
+		case postman.SyncAdded:
+			added++
+		case postman.SyncUpdated:
+			updated++
+		case postman.SyncRemoved:
+			removed++
+		}
+	}
+
+	m.statusMessage = fmt.Sprintf("Spec sync: %d added, %d updated, %d removed - <a> apply, <d> skip selected, <i> details, <esc> cancel",
+		added, updated, removed)
+	return m
+}
+
+func (m Model) applyResync() Model {
+	if m.pendingSync == nil {
+		m.statusMessage = "No pending spec sync"
+		return m
+	}
+
+	collection, exists := m.parser.GetCollection(m.pendingSync.CollectionName)
+	if !exists {
+		m.statusMessage = fmt.Sprintf("Collection not found: %s", m.pendingSync.CollectionName)
+		return m
+	}
+
+	before := m.captureCollection(collection.Info.Name)
+	if err := postman.ApplyOpenAPISync(collection, m.pendingSync); err != nil {
+		m.statusMessage = fmt.Sprintf("Failed to apply spec sync: %v", err)
+		return m
+	}
+
+	count := len(m.pendingSync.Changes)
+	m.modifiedCollections[collection.Info.Name] = true
+	m = m.recordChange(fmt.Sprintf("Apply %d spec change(s)", count), before)
+	m.pendingSync = nil
+	m.mode = ModeRequests
+	m = m.refreshCurrentView()
+	m.statusMessage = fmt.Sprintf("Applied %d spec change(s) (use :w to write to file)", count)
+	return m
+}
+
+func (m Model) skipSyncChange(index int) Model {
+	if m.pendingSync == nil || index >= len(m.pendingSync.Changes) {
+		return m
+	}
+
+	skipped := m.pendingSync.Changes[index]
+	m.pendingSync.Changes = append(m.pendingSync.Changes[:index], m.pendingSync.Changes[index+1:]...)
+
+	if len(m.pendingSync.Changes) == 0 {
+		return m.cancelResync()
+	}
+
+	m = m.loadSyncChangesList()
+	if m.cursor >= len(m.items) {
+		m.cursor = len(m.items) - 1
+	}
+	m.statusMessage = fmt.Sprintf("Skipped: %s", skipped.Label())
+	return m
+}
+
+func (m Model) cancelResync() Model {
+	m.pendingSync = nil
+	m.mode = ModeRequests
+	m = m.refreshCurrentView()
+	m.statusMessage = "Spec sync cancelled"
+	return m
+}
+
+func (m Model) showSyncChangeDetails(index int) Model {
+	if m.pendingSync == nil || index >= len(m.pendingSync.Changes) {
+		return m
+	}
+
+	change := m.pendingSync.Changes[index]
+
+	var lines []string
+	lines = append(lines, lipgloss.NewStyle().Bold(true).Render(change.Label()+" (q: close)"))
+	lines = append(lines, "")
+	if len(change.Folder) > 0 {
+		lines = append(lines, requestStyle.Render("Folder:"))
+		lines = append(lines, "  "+strings.Join(change.Folder, " / "))
+		lines = append(lines, "")
+	}
+
+	switch change.Type {
+	case postman.SyncAdded:
+		lines = append(lines, requestStyle.Render("New operation from spec"))
+	default:
+		lines = append(lines, requestStyle.Render("Changes:"))
+		for _, detail := range change.Details {
+			lines = append(lines, "  "+detail)
+		}
+	}
+
+	m.previousMode = ModeChanges
+	m.mode = ModeInfo
+	m.scrollOffset = 0
+	m.infoViewport.Width = m.width - 8
+	m.infoViewport.Height = m.height - 8
+	m = m.setViewportContent(ModeInfo, strings.Join(lines, "\n"))
+	m.statusMessage = "Showing spec change (q to close)"
+	return m
+}
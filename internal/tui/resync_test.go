@@ -0,0 +1,144 @@
+package tui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"postOffice/internal/postman"
+)
+
+func writeResyncSpec(t *testing.T, paths map[string]postman.OpenAPIPathItem) string {
+	t.Helper()
+	spec := postman.OpenAPISpec{
+		OpenAPI: "3.0.0",
+		Info:    postman.OpenAPIInfo{Title: "Resync API"},
+		Servers: []postman.OpenAPIServer{{URL: "https://api.example.com"}},
+		Paths:   paths,
+	}
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("Failed to marshal spec: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "spec.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("Failed to write spec: %v", err)
+	}
+	return path
+}
+
+func createResyncModel(t *testing.T) (Model, string) {
+	t.Helper()
+	specPath := writeResyncSpec(t, map[string]postman.OpenAPIPathItem{
+		"/pets": {Get: &postman.OpenAPIOperation{OperationID: "listPets"}},
+	})
+
+	parser := postman.NewParser()
+	collection, err := parser.LoadCollection(specPath)
+	if err != nil {
+		t.Fatalf("Failed to load spec: %v", err)
+	}
+
+	m := NewModel(parser)
+	m.collection = collection
+	m.mode = ModeRequests
+	m = m.loadRequestsList()
+	return m, specPath
+}
+
+func TestStartResync_NoChanges(t *testing.T) {
+	m, _ := createResyncModel(t)
+
+	m = m.startResync("")
+
+	if m.pendingSync != nil {
+		t.Error("Expected no pending sync when spec is unchanged")
+	}
+	if m.mode != ModeRequests {
+		t.Errorf("Expected to stay in ModeRequests, got %v", m.mode)
+	}
+}
+
+func TestStartResync_NotFromSpec(t *testing.T) {
+	m := createTestModel()
+
+	m = m.startResync("")
+
+	if !contains(m.statusMessage, "not imported from an OpenAPI spec") {
+		t.Errorf("Unexpected status: %s", m.statusMessage)
+	}
+}
+
+func TestResync_ApplyChanges(t *testing.T) {
+	m, _ := createResyncModel(t)
+	newSpec := writeResyncSpec(t, map[string]postman.OpenAPIPathItem{
+		"/pets":   {Get: &postman.OpenAPIOperation{OperationID: "listPets"}},
+		"/owners": {Get: &postman.OpenAPIOperation{OperationID: "listOwners"}},
+	})
+
+	m = m.startResync(newSpec)
+
+	if m.mode != ModeChanges || m.pendingSync == nil {
+		t.Fatalf("Expected pending sync in ModeChanges, got mode %v", m.mode)
+	}
+	if len(m.items) != 1 {
+		t.Fatalf("Expected 1 change, got %d", len(m.items))
+	}
+
+	m, _ = handleApplySyncKey(m)
+
+	if m.pendingSync != nil {
+		t.Error("Expected pending sync to be cleared")
+	}
+	if !m.modifiedCollections[m.collection.Info.Name] {
+		t.Error("Expected collection to be marked modified")
+	}
+	if len(m.collection.Items) != 2 {
+		t.Errorf("Expected 2 items after sync, got %d", len(m.collection.Items))
+	}
+}
+
+func TestResync_SkipAllCancels(t *testing.T) {
+	m, _ := createResyncModel(t)
+	newSpec := writeResyncSpec(t, map[string]postman.OpenAPIPathItem{
+		"/owners": {Get: &postman.OpenAPIOperation{OperationID: "listOwners"}},
+	})
+
+	m = m.startResync(newSpec)
+	if m.pendingSync == nil {
+		t.Fatal("Expected pending sync")
+	}
+
+	for m.pendingSync != nil {
+		m, _ = handleDiscardSelectedKey(m)
+	}
+
+	if m.mode != ModeRequests {
+		t.Errorf("Expected ModeRequests after skipping all changes, got %v", m.mode)
+	}
+	if len(m.collection.Items) != 1 || m.collection.Items[0].Name != "listPets" {
+		t.Error("Expected collection to be unchanged")
+	}
+}
+
+func TestResync_WriteKeepsSpecFile(t *testing.T) {
+	m, specPath := createResyncModel(t)
+	original, err := os.ReadFile(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m = m.duplicateRequest(m.currentItems[0])
+	m = m.saveAllModifiedRequests()
+
+	if data, _ := os.ReadFile(specPath); string(data) != string(original) {
+		t.Fatal("Expected the OpenAPI spec not to be overwritten")
+	}
+	if !m.modifiedCollections[m.collection.Info.Name] {
+		t.Error("Expected collection to stay modified until :saveas")
+	}
+	if !contains(m.statusMessage, ":saveas") {
+		t.Errorf("Expected status to point to :saveas, got %s", m.statusMessage)
+	}
+}
@@ -0,0 +1,478 @@
+package tui
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"postOffice/internal/postman"
+	"postOffice/internal/runner"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type runnerState struct {
+	id          int
+	name        string
+	run         *runner.Run
+	collection  *postman.Collection
+	environment *postman.Environment
+	options     runner.Options
+	total       int
+	results     []runner.RequestResult
+	cursor      int
+	inFlight    bool
+	paused      bool
+	stopping    bool
+	finished    bool
+	outcome     string
+	drilled     bool
+
+	variablesBefore historySnapshot
+}
+
+type RunnerStepMsg struct {
+	Result runner.RequestResult
+	runID  int
+}
+
+type runnerTickMsg struct {
+	runID int
+}
+
+func parseRunOptions(args []string) (runner.Options, error) {
+	var options runner.Options
+	var dataPath string
+
+	flags := flag.NewFlagSet("run", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.IntVar(&options.Iterations, "n", 0, "")
+	flags.DurationVar(&options.Delay, "delay", 0, "")
+	flags.BoolVar(&options.StopOnFailure, "bail", false, "")
+	flags.StringVar(&dataPath, "d", "", "")
+	if err := flags.Parse(strings.Fields(strings.Join(args, " "))); err != nil {
+		return options, err
+	}
+	if flags.NArg() > 0 {
+		return options, fmt.Errorf("unexpected argument: %s", flags.Arg(0))
+	}
+
+	if dataPath != "" {
+		data, err := runner.LoadData(expandPath(dataPath))
+		if err != nil {
+			return options, err
+		}
+		options.Data = data
+	}
+	return options, nil
+}
+
+func (m Model) runTarget() (*postman.Collection, []string, error) {
+	switch m.mode {
+	case ModeCollections:
+		if m.cursor >= len(m.items) {
+			return nil, nil, fmt.Errorf("no collection selected")
+		}
+		collection, exists := m.parser.GetCollection(m.items[m.cursor])
+		if !exists {
+			return nil, nil, fmt.Errorf("collection not found: %s", m.items[m.cursor])
+		}
+		return collection, nil, nil
+	case ModeRequests:
+		if m.collection == nil {
+			return nil, nil, fmt.Errorf("no collection loaded")
+		}
+		path := append([]string{}, m.breadcrumb...)
+		if m.cursor < len(m.currentItems) && m.currentItems[m.cursor].IsFolder() {
+			path = append(path, m.currentItems[m.cursor].Name)
+		}
+		return m.collection, path, nil
+	}
+	return nil, nil, fmt.Errorf("open a collection or folder to run it")
+}
+
+func (m Model) runTargets(collection *postman.Collection, path []string) ([]runner.Target, error) {
+	items, err := runner.FindFolder(collection, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.applyModifiedRequests(collection, runner.CollectTargets(items, path)), nil
+}
+
+func (m Model) applyModifiedRequests(collection *postman.Collection, targets []runner.Target) []runner.Target {
+	for i, target := range targets {
+		itemID := m.getItemIdentifier(collection.Info.Name, target.Path, target.Item)
+		if modified, exists := m.modifiedRequests[itemID]; exists && m.isItemModified(itemID) {
+			targets[i].Item.Request = modified
+		}
+	}
+	return targets
+}
+
+func handleRunCommand(m Model, args []string) (Model, tea.Cmd) {
+	if m.runner != nil && !m.runner.finished {
+		m.mode = ModeRunner
+		m.statusMessage = "A run is already in progress"
+		return m, nil
+	}
+
+	options, err := parseRunOptions(args)
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Usage: :run [-n iterations] [-delay 500ms] [-bail] [-d data.csv] (%v)", err)
+		return m, nil
+	}
+
+	collection, path, err := m.runTarget()
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Cannot run: %v", err)
+		return m, nil
+	}
+	targets, err := m.runTargets(collection, path)
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Cannot run: %v", err)
+		return m, nil
+	}
+	if len(targets) == 0 {
+		m.statusMessage = "Cannot run: no requests found"
+		return m, nil
+	}
+
+	return m.startRun(collection, path, targets, options)
+}
+
+func (m Model) startRun(collection *postman.Collection, path []string, targets []runner.Target, options runner.Options) (Model, tea.Cmd) {
+	m.removeRunnerFiles()
+
+	name := collection.Info.Name
+	if len(path) > 0 {
+		name += " / " + strings.Join(path, " / ")
+	}
+
+	var environmentNames []string
+	if m.environment != nil {
+		environmentNames = append(environmentNames, m.environment.Name)
+	}
+
+	run := runner.New(m.executor, m.parser, collection, m.environment, targets, options)
+	id := 1
+	if m.runner != nil {
+		id = m.runner.id + 1
+	}
+	m.runner = &runnerState{
+		id:              id,
+		name:            name,
+		run:             run,
+		collection:      collection,
+		environment:     m.environment,
+		options:         run.Options(),
+		total:           run.Total(),
+		inFlight:        true,
+		variablesBefore: m.captureState([]string{collection.Info.Name}, environmentNames),
+	}
+
+	m.previousMode = m.mode
+	m.mode = ModeRunner
+	m.statusMessage = fmt.Sprintf("Running %s (%d requests)", name, m.runner.total)
+	return m, runnerStepCmd(m.runner)
+}
+
+func runnerStepCmd(state *runnerState) tea.Cmd {
+	run, id := state.run, state.id
+	return func() tea.Msg {
+		return RunnerStepMsg{Result: run.Step(), runID: id}
+	}
+}
+
+func (m Model) scheduleRunnerStep() (Model, tea.Cmd) {
+	state := m.runner
+	state.inFlight = true
+	if state.options.Delay > 0 {
+		id := state.id
+		return m, tea.Tick(state.options.Delay, func(time.Time) tea.Msg {
+			return runnerTickMsg{runID: id}
+		})
+	}
+	return m, runnerStepCmd(state)
+}
+
+func (m Model) handleRunnerStep(msg RunnerStepMsg) (Model, tea.Cmd) {
+	state := m.runner
+	if state == nil || state.id != msg.runID || state.finished {
+		return m, nil
+	}
+
+	state.inFlight = false
+	msg.Result.VariableChanges.ApplyTo(state.collection, state.environment)
+	follow := len(state.results) == 0 || state.cursor == len(state.results)-1
+	state.results = append(state.results, msg.Result)
+	if follow {
+		state.cursor = len(state.results) - 1
+	}
+
+	result := msg.Result
+	itemID := m.getItemIdentifier(state.run.Result.Collection, result.Target.Path, result.Target.Item)
+	status := "Error"
+	if result.Response.Error == nil {
+		status = result.Response.Status
+	}
+	m.setRequestExecution(itemID, &RequestExecution{
+		Status:     status,
+		Timestamp:  time.Now(),
+		Duration:   result.Response.Duration,
+		Response:   result.Response,
+		TestResult: result.TestResult,
+	})
+
+	switch {
+	case state.stopping:
+		return m.finishRun("Stopped"), nil
+	case state.options.StopOnFailure && result.Failed():
+		return m.finishRun("Stopped on first failure"), nil
+	case state.run.Done():
+		return m.finishRun("Finished"), nil
+	case state.paused:
+		return m, nil
+	}
+	return m.scheduleRunnerStep()
+}
+
+func (m Model) handleRunnerTick(msg runnerTickMsg) (Model, tea.Cmd) {
+	state := m.runner
+	if state == nil || state.id != msg.runID || state.finished {
+		return m, nil
+	}
+
+	if state.stopping {
+		state.inFlight = false
+		return m.finishRun("Stopped"), nil
+	}
+	if state.paused {
+		state.inFlight = false
+		return m, nil
+	}
+	return m, runnerStepCmd(state)
+}
+
+func (m Model) finishRun(outcome string) Model {
+	state := m.runner
+	state.finished = true
+	state.inFlight = false
+	state.outcome = outcome
+
+	for name := range state.variablesBefore.collections {
+		if _, err := m.autoSaveCollection(name); err != nil {
+			m.statusMessage = fmt.Sprintf("Warning: failed to save collection variables: %v", err)
+			return m.recordVariableChanges("Run "+state.name, state.variablesBefore)
+		}
+	}
+	for name := range state.variablesBefore.environments {
+		if err := m.parser.SaveEnvironment(name); err != nil {
+			m.statusMessage = fmt.Sprintf("Warning: failed to save environment variables: %v", err)
+			return m.recordVariableChanges("Run "+state.name, state.variablesBefore)
+		}
+	}
+
+	summary := state.run.Result.Summary()
+	m.statusMessage = fmt.Sprintf("%s: %d requests (%d failed), %d tests (%d failed)", outcome, summary.Requests, summary.FailedRequests, summary.Tests, summary.FailedTests)
+	return m.recordVariableChanges("Run "+state.name, state.variablesBefore)
+}
+
+// removeRunnerFiles removes the body files of the current run's responses,
+// except those still shown as an item's last execution or the open response,
+// or kept in the session log.
+func (m Model) removeRunnerFiles() {
+	if m.runner == nil {
+		return
+	}
+	for _, result := range m.runner.results {
+		if result.Response != nil && !m.responseInUse(result.Response, false) {
+			result.Response.RemoveBodyFile()
+		}
+	}
+}
+
+func handleRunnerPauseKey(m Model) (Model, tea.Cmd) {
+	state := m.runner
+	if state == nil || state.finished {
+		return m, nil
+	}
+
+	state.paused = !state.paused
+	if state.paused {
+		m.statusMessage = "Run paused (p to resume)"
+		return m, nil
+	}
+
+	m.statusMessage = "Run resumed"
+	if state.inFlight {
+		return m, nil
+	}
+	return m.scheduleRunnerStep()
+}
+
+func handleRunnerStopKey(m Model) (Model, tea.Cmd) {
+	state := m.runner
+	if state == nil || state.finished {
+		return m, nil
+	}
+
+	if state.inFlight {
+		state.stopping = true
+		m.statusMessage = "Stopping run after the current request..."
+		return m, nil
+	}
+	return m.finishRun("Stopped"), nil
+}
+
+func (m Model) openRunnerResult() Model {
+	state := m.runner
+	if state == nil || state.cursor >= len(state.results) {
+		return m
+	}
+
+	result := state.results[state.cursor]
+	state.drilled = true
+	m.lastResponse = result.Response
+	m.lastTestResult = result.TestResult
+	m.responseExample = ""
+	m.scrollOffset = 0
+	m.mode = ModeResponse
+	m = m.refreshResponseViewport()
+	m.responseViewport.GotoTop()
+	m.statusMessage = fmt.Sprintf("Iteration %d: %s (esc to return to the run)", result.Iteration+1, result.Target.Name())
+	return m
+}
+
+func (m Model) runnerProgressLine() string {
+	state := m.runner
+	result := runner.Result{Iterations: []runner.IterationResult{{Requests: state.results}}}
+	summary := result.Summary()
+
+	stateLabel := "running"
+	switch {
+	case state.finished:
+		stateLabel = strings.ToLower(state.outcome)
+	case state.stopping:
+		stateLabel = "stopping"
+	case state.paused:
+		stateLabel = "paused"
+	}
+
+	iterations := max(state.options.Iterations, 1)
+	iteration := 1
+	if len(state.results) > 0 {
+		iteration = state.results[len(state.results)-1].Iteration + 1
+	}
+
+	return fmt.Sprintf("Iteration %d/%d | %d/%d requests | %d failed | Tests: %d passed, %d failed | %s",
+		iteration, iterations, len(state.results), state.total, summary.FailedRequests,
+		summary.Tests-summary.FailedTests, summary.FailedTests, stateLabel)
+}
+
+func buildRunnerRow(result runner.RequestResult) string {
+	icon := "✓"
+	if result.Failed() {
+		icon = "✗"
+	}
+
+	status := "error"
+	if result.Response.Error == nil {
+		status = result.Response.Status
+	}
+
+	tests := ""
+	if result.TestResult != nil && len(result.TestResult.Tests) > 0 {
+		passed := 0
+		for _, test := range result.TestResult.Tests {
+			if test.Passed {
+				passed++
+			}
+		}
+		tests = fmt.Sprintf("tests %d/%d", passed, len(result.TestResult.Tests))
+	}
+
+	row := fmt.Sprintf("%s #%-3d %-7s %s %-20s %8v  %s", icon, result.Iteration+1, result.Target.Item.Request.Method,
+		fitColumn(result.Target.Name(), 40), status, result.Response.Duration.Round(time.Millisecond), tests)
+	if result.Failed() && len(result.Data) > 0 {
+		row += "  [" + runner.FormatData(result.Data) + "]"
+	}
+	return row
+}
+
+func (m Model) renderRunnerView() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("214"))
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	failedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+
+	state := m.runner
+	if state == nil {
+		return m.renderEmptyPopup("No run yet. Use :run on a folder or collection.", m.height-10)
+	}
+
+	header := titleStyle.Render("Run: "+state.name) + " " + helpStyle.Render("(enter: open response, p: pause/resume, x: stop, esc: close)")
+	lines := []string{header, m.runnerProgressLine(), ""}
+
+	visible := max(m.height-16, 1)
+	start := max(state.cursor-visible+1, 0)
+	end := min(start+visible, len(state.results))
+	for i := start; i < end; i++ {
+		row := buildRunnerRow(state.results[i])
+		switch {
+		case i == state.cursor:
+			row = selectedItemStyle.Render("> " + row)
+		case state.results[i].Failed():
+			row = failedStyle.Render("  " + row)
+		default:
+			row = normalItemStyle.Render("  " + row)
+		}
+		lines = append(lines, row)
+	}
+	if len(state.results) == 0 {
+		lines = append(lines, helpStyle.Render("  Waiting for the first response..."))
+	}
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("33")).
+		Height(m.height-10).
+		Width(m.width-4).
+		Padding(1, 2).
+		Render(strings.Join(lines, "\n"))
+}
+
+func (m Model) writeRunReport(format, path string) Model {
+	if m.runner == nil {
+		m.statusMessage = "No run to report on. Use :run first"
+		return m
+	}
+	if !m.runner.finished {
+		m.statusMessage = "Wait for the run to finish (or stop it with x) before writing a report"
+		return m
+	}
+
+	path = expandPath(path)
+	if err := runner.WriteReportFile(path, format, m.runner.run.Result); err != nil {
+		m.statusMessage = fmt.Sprintf("Failed to write report: %v", err)
+		return m
+	}
+	m.statusMessage = fmt.Sprintf("Wrote %s report to %s", format, path)
+	return m
+}
+
+func handleReportCommand(m Model, args []string) (Model, tea.Cmd) {
+	fields := strings.Fields(strings.Join(args, " "))
+	if len(fields) < 2 {
+		m.statusMessage = fmt.Sprintf("Usage: :report %s <path>", strings.Join(runner.ReportFormats, "|"))
+		return m, nil
+	}
+	format := strings.ToLower(fields[0])
+	if !slices.Contains(runner.ReportFormats, format) {
+		m.statusMessage = fmt.Sprintf("Failed to write report: unknown report format: %s (expected %s)", fields[0], strings.Join(runner.ReportFormats, ", "))
+		return m, nil
+	}
+	return m.writeRunReport(format, strings.Join(fields[1:], " ")), nil
+}
@@ -0,0 +1,229 @@
+package tui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"postOffice/internal/postman"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func createRunnerTestModel(t *testing.T, handler http.HandlerFunc) Model {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	m := createSavedTestModel(t)
+	m.width, m.height = 140, 40
+	var rewrite func(items []postman.Item)
+	rewrite = func(items []postman.Item) {
+		for _, item := range items {
+			if item.Request != nil {
+				item.Request.URL.Raw = strings.Replace(item.Request.URL.Raw, "https://example.com", server.URL, 1)
+			}
+			rewrite(item.Items)
+		}
+	}
+	rewrite(m.collection.Items)
+	return m
+}
+
+func driveRun(t *testing.T, m Model, cmd tea.Cmd) Model {
+	t.Helper()
+	for i := 0; cmd != nil; i++ {
+		if i > 100 {
+			t.Fatal("Run did not finish")
+		}
+		updated, next := m.Update(cmd())
+		m, cmd = updated.(Model), next
+	}
+	return m
+}
+
+func TestParseRunOptions(t *testing.T) {
+	options, err := parseRunOptions([]string{"-n 3 -delay 250ms -bail"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if options.Iterations != 3 || options.Delay != 250*time.Millisecond || !options.StopOnFailure {
+		t.Errorf("Unexpected options: %+v", options)
+	}
+
+	for _, args := range []string{"-n three", "extra", "-d /does/not/exist.csv"} {
+		if _, err := parseRunOptions([]string{args}); err == nil {
+			t.Errorf("Expected an error for %q", args)
+		}
+	}
+}
+
+func TestRunCommand_RunsCollection(t *testing.T) {
+	m := createRunnerTestModel(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	m.cursor = 1
+
+	m, cmd := handleRunCommand(m, []string{"-n 2"})
+	if m.mode != ModeRunner || cmd == nil {
+		t.Fatalf("Expected the runner view to open, status: %s", m.statusMessage)
+	}
+	if m.runner.name != "Test Collection" || m.runner.total != 4 {
+		t.Fatalf("Expected 2 iterations of the whole collection, got %q with %d requests", m.runner.name, m.runner.total)
+	}
+
+	m = driveRun(t, m, cmd)
+	if !m.runner.finished || len(m.runner.results) != 4 {
+		t.Fatalf("Expected the run to finish with 4 results, got %d", len(m.runner.results))
+	}
+	if !contains(m.statusMessage, "Finished: 4 requests (0 failed)") {
+		t.Errorf("Unexpected status: %s", m.statusMessage)
+	}
+	if m.runner.cursor != 3 {
+		t.Errorf("Expected cursor to follow the latest result, got %d", m.runner.cursor)
+	}
+	if len(m.requestExecutions) != 2 {
+		t.Errorf("Expected each request's last execution to be recorded, got %d", len(m.requestExecutions))
+	}
+
+	content := ansi.Strip(m.renderRunnerView())
+	for _, expected := range []string{"Run: Test Collection", "Iteration 2/2 | 4/4 requests | 0 failed", "finished", "✓ #1   GET", "Test Folder / GET Request", "200 OK"} {
+		if !contains(content, expected) {
+			t.Errorf("Expected %q in runner view, got:\n%s", expected, content)
+		}
+	}
+}
+
+func TestRunCommand_RunsSelectedFolder(t *testing.T) {
+	m := createRunnerTestModel(t, func(w http.ResponseWriter, r *http.Request) {})
+
+	m, _ = handleRunCommand(m, nil)
+	if m.runner == nil || m.runner.name != "Test Collection / Test Folder" || m.runner.total != 1 {
+		t.Fatalf("Expected the folder under the cursor to run, status: %s", m.statusMessage)
+	}
+}
+
+func TestRunner_StopOnFailureAndDrillDown(t *testing.T) {
+	m := createRunnerTestModel(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	m.cursor = 1
+	m.collection.Items[0].Items[0].Events = []postman.Event{{
+		Listen: "test",
+		Script: postman.Script{Exec: []string{"pm.test('ok', () => { pm.response.to.have.status(200); });"}},
+	}}
+
+	m, cmd := handleRunCommand(m, []string{"-bail"})
+	m = driveRun(t, m, cmd)
+	if len(m.runner.results) != 1 || !contains(m.statusMessage, "Stopped on first failure") {
+		t.Fatalf("Expected the run to stop after the first failure, got %d results (%s)", len(m.runner.results), m.statusMessage)
+	}
+
+	m, _ = handleEnterKey(m)
+	if m.mode != ModeResponse || m.lastResponse != m.runner.results[0].Response || m.lastTestResult.Tests[0].Passed {
+		t.Fatalf("Expected enter to open the failed response, got mode %v", m.mode)
+	}
+
+	m, _ = handleBackKey(m)
+	if m.mode != ModeRunner {
+		t.Fatalf("Expected esc to return to the runner, got mode %v", m.mode)
+	}
+	m, _ = handleBackKey(m)
+	if m.mode != ModeRequests {
+		t.Errorf("Expected esc to close the runner, got mode %v", m.mode)
+	}
+}
+
+func TestRunner_PauseResumeAndStop(t *testing.T) {
+	m := createRunnerTestModel(t, func(w http.ResponseWriter, r *http.Request) {})
+	m.cursor = 1
+
+	m, cmd := handleRunCommand(m, []string{"-n 3"})
+	m, _ = handleRunnerPauseKey(m)
+	if !m.runner.paused {
+		t.Fatal("Expected the run to pause")
+	}
+
+	updated, next := m.Update(cmd())
+	m = updated.(Model)
+	if next != nil || len(m.runner.results) != 1 {
+		t.Fatalf("Expected a paused run to wait after the current request, got %d results", len(m.runner.results))
+	}
+
+	m, cmd = handleRunnerPauseKey(m)
+	if m.runner.paused || cmd == nil {
+		t.Fatal("Expected resume to schedule the next request")
+	}
+
+	m, _ = handleRunnerStopKey(m)
+	if !m.runner.stopping {
+		t.Fatal("Expected stop to wait for the in-flight request")
+	}
+	m = driveRun(t, m, cmd)
+	if !m.runner.finished || len(m.runner.results) != 2 || !contains(m.statusMessage, "Stopped: 2 requests") {
+		t.Errorf("Expected the run to stop after 2 requests, got %d (%s)", len(m.runner.results), m.statusMessage)
+	}
+}
+
+func TestReportCommand(t *testing.T) {
+	m := createRunnerTestModel(t, func(w http.ResponseWriter, r *http.Request) {})
+	m.cursor = 1
+	path := filepath.Join(t.TempDir(), "results.xml")
+
+	m, _ = handleReportCommand(m, []string{"junit " + path})
+	if !contains(m.statusMessage, "No run to report on") {
+		t.Fatalf("Expected an error without a run, got %s", m.statusMessage)
+	}
+
+	m, cmd := handleRunCommand(m, nil)
+	m, _ = handleReportCommand(m, []string{"junit " + path})
+	if !contains(m.statusMessage, "Wait for the run to finish") {
+		t.Fatalf("Expected an error while the run is active, got %s", m.statusMessage)
+	}
+
+	m = driveRun(t, m, cmd)
+	m, _ = handleReportCommand(m, []string{"junit"})
+	if !contains(m.statusMessage, "Usage: :report junit|json|html <path>") {
+		t.Errorf("Expected usage, got %s", m.statusMessage)
+	}
+	m, _ = handleReportCommand(m, []string{"csv " + path})
+	if !contains(m.statusMessage, "unknown report format") {
+		t.Errorf("Expected an unknown format error, got %s", m.statusMessage)
+	}
+
+	m, _ = handleReportCommand(m, []string{"JUnit " + path})
+	if m.statusMessage != "Wrote junit report to "+path {
+		t.Fatalf("Unexpected status: %s", m.statusMessage)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || !contains(string(data), `<testsuites name="Test Collection" tests="0" failures="0" errors="0"`) {
+		t.Errorf("Unexpected report: %s (%v)", data, err)
+	}
+}
+
+func TestRunner_MergesScriptVariables(t *testing.T) {
+	m := createRunnerTestModel(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.Path))
+	})
+	m.cursor = 1
+	m.collection.Items[1].Events = []postman.Event{{
+		Listen: "test",
+		Script: postman.Script{Exec: []string{"pm.collectionVariables.set('lastPath', pm.response.text());"}},
+	}}
+
+	m, cmd := handleRunCommand(m, nil)
+	m = driveRun(t, m, cmd)
+
+	last := m.collection.Variables[len(m.collection.Variables)-1]
+	if last.Key != "lastPath" || last.Value != "/api/create" {
+		t.Fatalf("Expected the script write to reach the collection, got %+v", m.collection.Variables)
+	}
+	if len(m.undoStack) != 1 || !contains(m.undoStack[0].description, "Run Test Collection") {
+		t.Errorf("Expected one undo entry for the run, got %d", len(m.undoStack))
+	}
+}
@@ -0,0 +1,220 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"postOffice/internal/codegen"
+	"postOffice/internal/postman"
+	"strings"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type snippetPicker struct {
+	itemName  string
+	request   *postman.Request
+	variables []postman.VariableSource
+	cursor    int
+}
+
+func (m Model) resolveSnippetRequest() (*snippetPicker, error) {
+	if m.collection == nil || len(m.currentItems) == 0 || m.cursor >= len(m.currentItems) {
+		return nil, fmt.Errorf("no request selected")
+	}
+
+	item := m.currentItems[m.cursor]
+	if !item.IsRequest() {
+		return nil, fmt.Errorf("not a request")
+	}
+
+	request := item.Request
+	itemID := m.getRequestIdentifier(item)
+	if modifiedReq, exists := m.modifiedRequests[itemID]; exists && m.isItemModified(itemID) {
+		request = modifiedReq
+	}
+
+	variables := m.parser.GetAllVariables(m.collection, m.breadcrumb, m.environment)
+	resolved, err := m.executor.ResolveVariables(&item, m.collection, m.environment, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	return &snippetPicker{
+		itemName:  item.Name,
+		request:   request,
+		variables: resolved,
+	}, nil
+}
+
+func (m Model) openSnippetPicker() Model {
+	picker, err := m.resolveSnippetRequest()
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Cannot generate snippet: %v", err)
+		return m
+	}
+
+	m.snippetPicker = picker
+	m.statusMessage = "Copy as: <enter> clipboard, <w> write to file, <esc> cancel"
+	return m
+}
+
+func (m Model) handleSnippetPickerKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	targets := codegen.Targets()
+	key := msg.String()
+
+	switch key {
+	case "esc", "q":
+		m.snippetPicker = nil
+		m.statusMessage = "Snippet cancelled"
+		return m, nil
+	case "up", "k":
+		if m.snippetPicker.cursor > 0 {
+			m.snippetPicker.cursor--
+		}
+		return m, nil
+	case "down", "j":
+		if m.snippetPicker.cursor < len(targets)-1 {
+			m.snippetPicker.cursor++
+		}
+		return m, nil
+	case "enter":
+		return m.copySnippet(targets[m.snippetPicker.cursor].Name)
+	case "w":
+		target := targets[m.snippetPicker.cursor].Name
+		m.snippetPicker = nil
+		m.commandMode = true
+		m.commandInput.SetValue("snippet " + target + " ")
+		m.commandInput.CursorEnd()
+		return m, m.commandInput.Focus()
+	}
+
+	if len(key) == 1 && key[0] >= '1' && int(key[0]-'1') < len(targets) {
+		return m.copySnippet(targets[key[0]-'1'].Name)
+	}
+
+	return m, nil
+}
+
+func (m Model) copySnippet(target string) (Model, tea.Cmd) {
+	picker := m.snippetPicker
+	m.snippetPicker = nil
+
+	if picker == nil {
+		resolved, err := m.resolveSnippetRequest()
+		if err != nil {
+			m.statusMessage = fmt.Sprintf("Cannot generate snippet: %v", err)
+			return m, nil
+		}
+		picker = resolved
+	}
+
+	snippet, err := codegen.Generate(target, picker.request, picker.variables)
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Failed to generate snippet: %v", err)
+		return m, nil
+	}
+
+	m.statusMessage = fmt.Sprintf("Copied %s as %s to clipboard", picker.itemName, target)
+	return m, copyToClipboard(snippet)
+}
+
+func (m Model) writeSnippet(target string, path string) Model {
+	picker, err := m.resolveSnippetRequest()
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Cannot generate snippet: %v", err)
+		return m
+	}
+
+	snippet, err := codegen.Generate(target, picker.request, picker.variables)
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Failed to generate snippet: %v", err)
+		return m
+	}
+
+	path = expandPath(path)
+	if err := os.WriteFile(path, []byte(snippet), 0644); err != nil {
+		m.statusMessage = fmt.Sprintf("Failed to write snippet: %v", err)
+		return m
+	}
+
+	m.statusMessage = fmt.Sprintf("Wrote %s snippet to %s", target, path)
+	return m
+}
+
+type clipboardMsg struct {
+	err error
+}
+
+// clipboardCommand writes an OSC52 sequence to the program's output. It runs
+// through tea.Exec, so the renderer is stopped while the sequence is written
+// and it never interleaves with a frame.
+type clipboardCommand struct {
+	seq    osc52.Sequence
+	stdout io.Writer
+}
+
+func (c *clipboardCommand) Run() error {
+	_, err := c.seq.WriteTo(c.stdout)
+	return err
+}
+
+func (c *clipboardCommand) SetStdin(io.Reader) {}
+
+func (c *clipboardCommand) SetStdout(w io.Writer) { c.stdout = w }
+
+func (c *clipboardCommand) SetStderr(io.Writer) {}
+
+func copyToClipboard(text string) tea.Cmd {
+	seq := osc52.New(text)
+	if os.Getenv("TMUX") != "" {
+		seq = seq.Tmux()
+	} else if strings.HasPrefix(os.Getenv("TERM"), "screen") {
+		seq = seq.Screen()
+	}
+	return tea.Exec(&clipboardCommand{seq: seq}, func(err error) tea.Msg {
+		return clipboardMsg{err: err}
+	})
+}
+
+func (m Model) renderSnippetPicker() string {
+	metrics := m.calculateLayout()
+	targets := codegen.Targets()
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("214"))
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("Copy "+m.snippetPicker.itemName+" as")+" "+
+		helpStyle.Render("(j/k select, enter copy, w write to file, esc cancel)"))
+	lines = append(lines, "")
+
+	for i, target := range targets {
+		line := fmt.Sprintf("%d. %s", i+1, target.Label)
+		if i == m.snippetPicker.cursor {
+			lines = append(lines, selectedItemStyle.Render("> "+line))
+		} else {
+			lines = append(lines, normalItemStyle.Render("  "+line))
+		}
+	}
+	lines = append(lines, "")
+
+	snippet, err := codegen.Generate(targets[m.snippetPicker.cursor].Name, m.snippetPicker.request, m.snippetPicker.variables)
+	if err != nil {
+		snippet = err.Error()
+	}
+
+	previewHeight := metrics.contentHeight - len(lines) - 3
+	previewLines := strings.Split(snippet, "\n")
+	if previewHeight > 0 && len(previewLines) > previewHeight {
+		previewLines = append(previewLines[:previewHeight-1], helpStyle.Render("..."))
+	}
+	lines = append(lines, previewLines...)
+
+	return mainWindowStyle.
+		Height(metrics.contentHeight).
+		Width(m.width - 4).
+		Render(strings.Join(lines, "\n"))
+}
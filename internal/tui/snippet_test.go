@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func selectRequest(t *testing.T, m Model, name string) Model {
+	t.Helper()
+	for i, item := range m.currentItems {
+		if item.Name == name {
+			m.cursor = i
+			return m
+		}
+	}
+	t.Fatalf("Request not found: %s", name)
+	return m
+}
+
+func TestSnippetPicker_OpenAndCopy(t *testing.T) {
+	m := createTestModel()
+	m = selectRequest(t, m, "POST Request")
+
+	m, _ = handleSnippetKey(m)
+	if m.snippetPicker == nil {
+		t.Fatalf("Expected snippet picker to open, status: %s", m.statusMessage)
+	}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m = newModel.(Model)
+	if m.snippetPicker.cursor != 1 {
+		t.Errorf("Expected picker cursor 1, got %d", m.snippetPicker.cursor)
+	}
+	m.width, m.height = 120, 40
+	if !strings.Contains(m.View(), "HTTPie") {
+		t.Error("Expected picker to render targets")
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+	if m.snippetPicker != nil {
+		t.Error("Expected picker to close after copy")
+	}
+	if cmd == nil {
+		t.Error("Expected clipboard command")
+	}
+	if !contains(m.statusMessage, "as httpie") {
+		t.Errorf("Unexpected status: %s", m.statusMessage)
+	}
+}
+
+func TestSnippetPicker_RejectsFolder(t *testing.T) {
+	m := createTestModel()
+	m = selectRequest(t, m, "Test Folder")
+
+	m, _ = handleSnippetKey(m)
+
+	if m.snippetPicker != nil {
+		t.Error("Expected no picker for a folder")
+	}
+	if !contains(m.statusMessage, "not a request") {
+		t.Errorf("Unexpected status: %s", m.statusMessage)
+	}
+}
+
+func TestSnippetCommand_WritesFile(t *testing.T) {
+	m := createTestModel()
+	m = selectRequest(t, m, "POST Request")
+	path := filepath.Join(t.TempDir(), "request.sh")
+
+	m, _ = handleSnippetCommand(m, []string{"curl " + path})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected snippet file, got error: %v (status: %s)", err, m.statusMessage)
+	}
+	if !strings.Contains(string(data), "curl -X POST 'https://example.com/api/create'") {
+		t.Errorf("Unexpected snippet:\n%s", data)
+	}
+
+	m, _ = handleSnippetCommand(m, []string{"cobol " + path})
+	if !contains(m.statusMessage, "unknown snippet target") {
+		t.Errorf("Expected unknown target error, got: %s", m.statusMessage)
+	}
+}
+
+func TestSnippetCommand_UsesUnsavedChanges(t *testing.T) {
+	m := createTestModel()
+	m = selectRequest(t, m, "POST Request")
+	item := m.currentItems[m.cursor]
+	itemID := m.getRequestIdentifier(item)
+	modified := m.deepCopyRequest(item.Request)
+	modified.Method = "PUT"
+	m.modifiedRequests[itemID] = modified
+	m.modifiedItems[itemID] = true
+
+	path := filepath.Join(t.TempDir(), "request.sh")
+	m, _ = handleSnippetCommand(m, []string{"curl " + path})
+
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "-X PUT") {
+		t.Errorf("Expected unsaved method in snippet:\n%s", data)
+	}
+}
+
+func TestViewportModes_RouteCommandAndBoundKeys(t *testing.T) {
+	m := createTestModel()
+	m = selectRequest(t, m, "POST Request")
+	m.mode = ModeResponse
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{':'}})
+	if !newModel.(Model).commandMode {
+		t.Error("Expected ':' to open command mode in response view")
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if newModel.(Model).snippetPicker == nil {
+		t.Error("Expected 'y' to open snippet picker in response view")
+	}
+}
+
+func TestClipboardCommand_WritesToProgramOutput(t *testing.T) {
+	t.Setenv("TMUX", "")
+	t.Setenv("TERM", "xterm-256color")
+
+	var out bytes.Buffer
+	command := &clipboardCommand{seq: osc52.New("hello")}
+	command.SetStdout(&out)
+	if err := command.Run(); err != nil {
+		t.Fatalf("Failed to write clipboard sequence: %v", err)
+	}
+	if out.String() != osc52.New("hello").String() {
+		t.Errorf("Expected OSC52 sequence, got %q", out.String())
+	}
+
+	m := createTestModel()
+	newModel, _ := m.Update(clipboardMsg{err: errors.New("terminal closed")})
+	m = newModel.(Model)
+	if !contains(m.statusMessage, "Failed to copy to clipboard: terminal closed") {
+		t.Errorf("Unexpected status: %s", m.statusMessage)
+	}
+}
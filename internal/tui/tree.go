@@ -0,0 +1,298 @@
+package tui
+
+import (
+	"fmt"
+	"postOffice/internal/postman"
+	"slices"
+	"strings"
+)
+
+type cutItem struct {
+	collection string
+	id         string
+	name       string
+}
+
+var httpMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
+func (m Model) currentFolder() (*[]postman.Item, error) {
+	if m.collection == nil {
+		return nil, fmt.Errorf("No collection loaded")
+	}
+	return postman.FindFolder(&m.collection.Items, m.breadcrumb)
+}
+
+func (m Model) selectedTreeItem() (postman.Item, bool) {
+	if m.mode != ModeRequests || m.collection == nil || m.cursor >= len(m.currentItems) {
+		return postman.Item{}, false
+	}
+	return m.currentItems[m.cursor], true
+}
+
+func (m Model) treeChanged(before historySnapshot, cursor int, status string) Model {
+	m.modifiedCollections[m.collection.Info.Name] = true
+	m = m.recordChange(status, before)
+	m = m.refreshCurrentView()
+	m.cursor = max(min(cursor, len(m.currentItems)-1), 0)
+	m.statusMessage = status + " (use :w to write to file)"
+	return m
+}
+
+func (m Model) createRequest(args string) Model {
+	items, err := m.currentFolder()
+	if err != nil {
+		m.statusMessage = err.Error()
+		return m
+	}
+
+	method := "GET"
+	fields := strings.Fields(args)
+	if len(fields) > 0 && slices.Contains(httpMethods, strings.ToUpper(fields[0])) {
+		method = strings.ToUpper(fields[0])
+		fields = fields[1:]
+	}
+	name := strings.Join(fields, " ")
+	if name == "" {
+		m.statusMessage = "Usage: :new [METHOD] <name>"
+		return m
+	}
+
+	item := postman.Item{
+		ID:   postman.NewID(),
+		Name: name,
+		Request: &postman.Request{
+			Method: method,
+			Header: []postman.Header{},
+		},
+	}
+	before := m.captureCollection(m.collection.Info.Name)
+	index := min(m.cursor+1, len(*items))
+	postman.InsertItem(items, index, item)
+
+	return m.treeChanged(before, index, fmt.Sprintf("Created request: %s", name))
+}
+
+func (m Model) createFolder(name string) Model {
+	items, err := m.currentFolder()
+	if err != nil {
+		m.statusMessage = err.Error()
+		return m
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" || strings.Contains(name, "/") {
+		m.statusMessage = "Usage: :mkdir <name> (without '/')"
+		return m
+	}
+	if postman.HasFolder(*items, name) {
+		m.statusMessage = fmt.Sprintf("Folder already exists: %s", name)
+		return m
+	}
+
+	folder := postman.NewFolder(name)
+	folder.ID = postman.NewID()
+	before := m.captureCollection(m.collection.Info.Name)
+	index := min(m.cursor+1, len(*items))
+	postman.InsertItem(items, index, folder)
+
+	return m.treeChanged(before, index, fmt.Sprintf("Created folder: %s", name))
+}
+
+func (m Model) renameItem(name string) Model {
+	selected, ok := m.selectedTreeItem()
+	if !ok {
+		m.statusMessage = "No item selected to rename"
+		return m
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		m.statusMessage = "Usage: :rename <new name>"
+		return m
+	}
+
+	items, err := m.currentFolder()
+	if err != nil {
+		m.statusMessage = err.Error()
+		return m
+	}
+	if selected.IsFolder() && (strings.Contains(name, "/") || postman.HasFolder(*items, name)) {
+		m.statusMessage = fmt.Sprintf("Cannot rename folder to %s", name)
+		return m
+	}
+
+	index := indexOfItem(*items, selected.ID)
+	if index < 0 {
+		m.statusMessage = "Error: Selected item not found in collection"
+		return m
+	}
+	before := m.captureCollection(m.collection.Info.Name)
+	(*items)[index].Name = name
+
+	return m.treeChanged(before, index, fmt.Sprintf("Renamed %s to %s", selected.Name, name))
+}
+
+func (m Model) moveItemToFolder(path string) Model {
+	selected, ok := m.selectedTreeItem()
+	if !ok {
+		m.statusMessage = "No item selected to move"
+		return m
+	}
+	if strings.TrimSpace(path) == "" {
+		m.statusMessage = "Usage: :mv <folder/path> (use / for the collection root)"
+		return m
+	}
+
+	destinationPath := postman.SplitFolderPath(path)
+	destination, err := postman.FindFolder(&m.collection.Items, destinationPath)
+	if err != nil {
+		m.statusMessage = err.Error()
+		return m
+	}
+	if slices.Contains(postman.FolderPathIDs(m.collection.Items, destinationPath), selected.ID) {
+		m.statusMessage = "Cannot move a folder into itself"
+		return m
+	}
+	if slices.Equal(destinationPath, m.breadcrumb) {
+		m.statusMessage = fmt.Sprintf("%s is already in that folder", selected.Name)
+		return m
+	}
+	if selected.IsFolder() && postman.HasFolder(*destination, selected.Name) {
+		m.statusMessage = fmt.Sprintf("Folder already exists at destination: %s", selected.Name)
+		return m
+	}
+
+	items, err := m.currentFolder()
+	if err != nil {
+		m.statusMessage = err.Error()
+		return m
+	}
+	before := m.captureCollection(m.collection.Info.Name)
+	item, removed := postman.RemoveItem(items, selected.ID)
+	if !removed {
+		m.statusMessage = "Error: Selected item not found in collection"
+		return m
+	}
+
+	destination, _ = postman.FindFolder(&m.collection.Items, destinationPath)
+	*destination = append(*destination, item)
+
+	label := strings.Join(destinationPath, "/")
+	if label == "" {
+		label = "/"
+	}
+	return m.treeChanged(before, m.cursor, fmt.Sprintf("Moved %s to %s", selected.Name, label))
+}
+
+func (m Model) cutSelectedItem() Model {
+	selected, ok := m.selectedTreeItem()
+	if !ok {
+		m.statusMessage = "No item selected to cut"
+		return m
+	}
+
+	if m.cutItem != nil && m.cutItem.id == selected.ID {
+		m.cutItem = nil
+		m.statusMessage = fmt.Sprintf("Cancelled cut of %s", selected.Name)
+		return m
+	}
+
+	m.cutItem = &cutItem{
+		collection: m.collection.Info.Name,
+		id:         selected.ID,
+		name:       selected.Name,
+	}
+	m.statusMessage = fmt.Sprintf("Cut %s - navigate to a folder and press p to paste", selected.Name)
+	return m
+}
+
+func (m Model) pasteCutItem() Model {
+	if m.cutItem == nil {
+		m.statusMessage = "Nothing to paste. Cut an item with x first"
+		return m
+	}
+	if m.mode != ModeRequests || m.collection == nil {
+		m.statusMessage = "Open a collection to paste into"
+		return m
+	}
+
+	source, exists := m.parser.GetCollection(m.cutItem.collection)
+	if !exists {
+		m.cutItem = nil
+		m.statusMessage = "Cut item's collection is no longer loaded"
+		return m
+	}
+	found, folderPath := postman.FindItemByID(source.Items, m.cutItem.id)
+	if found == nil {
+		m.cutItem = nil
+		m.statusMessage = "Cut item no longer exists"
+		return m
+	}
+
+	if source == m.collection && slices.Contains(postman.FolderPathIDs(m.collection.Items, m.breadcrumb), m.cutItem.id) {
+		m.statusMessage = "Cannot paste a folder into itself"
+		return m
+	}
+	destination, err := m.currentFolder()
+	if err != nil {
+		m.statusMessage = err.Error()
+		return m
+	}
+	if found.IsFolder() && !(source == m.collection && slices.Equal(folderPath, m.breadcrumb)) && postman.HasFolder(*destination, found.Name) {
+		m.statusMessage = fmt.Sprintf("Folder already exists here: %s", found.Name)
+		return m
+	}
+
+	sourceItems, err := postman.FindFolder(&source.Items, folderPath)
+	if err != nil {
+		m.statusMessage = err.Error()
+		return m
+	}
+	index := m.cursor + 1
+	if source == m.collection && slices.Equal(folderPath, m.breadcrumb) && indexOfItem(*sourceItems, m.cutItem.id) <= m.cursor {
+		index = m.cursor
+	}
+	before := m.captureState([]string{source.Info.Name, m.collection.Info.Name}, nil)
+	item, _ := postman.RemoveItem(sourceItems, m.cutItem.id)
+
+	destination, _ = m.currentFolder()
+	index = min(max(index, 0), len(*destination))
+	postman.InsertItem(destination, index, item)
+
+	m.modifiedCollections[source.Info.Name] = true
+	m.cutItem = nil
+	return m.treeChanged(before, index, fmt.Sprintf("Pasted %s", item.Name))
+}
+
+func (m Model) reorderSelectedItem(delta int) Model {
+	selected, ok := m.selectedTreeItem()
+	if !ok {
+		return m
+	}
+
+	items, err := m.currentFolder()
+	if err != nil {
+		m.statusMessage = err.Error()
+		return m
+	}
+	index := indexOfItem(*items, selected.ID)
+	if index < 0 {
+		m.statusMessage = "Error: Selected item not found in collection"
+		return m
+	}
+	before := m.captureCollection(m.collection.Info.Name)
+	if !postman.MoveItem(*items, index, index+delta) {
+		return m
+	}
+
+	return m.treeChanged(before, index+delta, fmt.Sprintf("Moved %s", selected.Name))
+}
+
+func indexOfItem(items []postman.Item, id string) int {
+	for i := range items {
+		if items[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
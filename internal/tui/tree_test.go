@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"testing"
+)
+
+func currentNames(m Model) []string {
+	var names []string
+	for _, item := range m.currentItems {
+		names = append(names, item.Name)
+	}
+	return names
+}
+
+func TestTreeEditing_NewAndMkdirPersistOnWrite(t *testing.T) {
+	m := createSavedTestModel(t)
+	m = selectRequest(t, m, "POST Request")
+
+	m, _ = handleNewCommand(m, []string{"post Create user"})
+	if m.currentItems[m.cursor].Name != "Create user" || m.currentItems[m.cursor].Request.Method != "POST" {
+		t.Fatalf("Expected new POST request under the cursor, got %+v", m.currentItems[m.cursor])
+	}
+
+	m, _ = handleMkdirCommand(m, []string{"Admin"})
+	if !m.currentItems[m.cursor].IsFolder() {
+		t.Fatalf("Expected new folder, status: %s", m.statusMessage)
+	}
+	if !m.modifiedCollections["Test Collection"] {
+		t.Error("Expected collection to be marked modified")
+	}
+
+	reloaded, _ := m.parser.LoadCollectionSnapshot("Test Collection")
+	if len(reloaded.Items) != 2 {
+		t.Errorf("Expected tree changes to stay in memory until :w, file has %d items", len(reloaded.Items))
+	}
+
+	m, _ = handleWriteCommand(m, nil)
+	reloaded, _ = m.parser.LoadCollectionSnapshot("Test Collection")
+	if len(reloaded.Items) != 4 || !reloaded.Items[3].IsFolder() || reloaded.Items[3].Name != "Admin" {
+		t.Fatalf("Expected request and empty folder to be saved, got %+v", reloaded.Items)
+	}
+
+	m, _ = handleMkdirCommand(m, []string{"Admin"})
+	if !contains(m.statusMessage, "already exists") {
+		t.Errorf("Expected duplicate folder to be rejected, got: %s", m.statusMessage)
+	}
+}
+
+func TestTreeEditing_MoveAndRename(t *testing.T) {
+	m := createSavedTestModel(t)
+	m = selectRequest(t, m, "POST Request")
+	movedID := m.currentItems[m.cursor].ID
+
+	m, _ = handleMoveCommand(m, []string{"Test Folder"})
+	if len(m.currentItems) != 1 {
+		t.Fatalf("Expected request to leave the root, got %v (%s)", currentNames(m), m.statusMessage)
+	}
+	folder := m.collection.Items[0]
+	if len(folder.Items) != 2 || folder.Items[1].ID != movedID {
+		t.Fatalf("Expected request to be appended to Test Folder, got %+v", folder.Items)
+	}
+
+	m = selectRequest(t, m, "Test Folder")
+	m, _ = handleMoveCommand(m, []string{"Test Folder"})
+	if !contains(m.statusMessage, "into itself") {
+		t.Errorf("Expected moving a folder into itself to fail, got: %s", m.statusMessage)
+	}
+
+	m, _ = handleRenameCommand(m, []string{"Users"})
+	if m.collection.Items[0].Name != "Users" {
+		t.Errorf("Expected folder to be renamed, got %s", m.collection.Items[0].Name)
+	}
+
+	m, _ = handleEnterKey(m)
+	m = selectRequest(t, m, "POST Request")
+	m, _ = handleMoveCommand(m, []string{"/"})
+	if len(m.collection.Items) != 2 || m.collection.Items[1].ID != movedID {
+		t.Errorf("Expected request to move back to the root, got %+v", m.collection.Items)
+	}
+}
+
+func TestTreeEditing_CutPasteAndReorder(t *testing.T) {
+	m := createSavedTestModel(t)
+	m = selectRequest(t, m, "POST Request")
+
+	m, _ = handleCutKey(m)
+	if m.cutItem == nil {
+		t.Fatal("Expected item to be marked as cut")
+	}
+
+	m = selectRequest(t, m, "Test Folder")
+	m, _ = handleEnterKey(m)
+	m, _ = handlePasteKey(m)
+	if got := currentNames(m); len(got) != 2 || got[1] != "POST Request" {
+		t.Fatalf("Expected POST Request pasted after the cursor, got %v (%s)", got, m.statusMessage)
+	}
+	if m.cutItem != nil || len(m.collection.Items) != 1 {
+		t.Error("Expected the cut item to be moved, not copied")
+	}
+
+	m, _ = handleMoveUpKey(m)
+	if got := currentNames(m); got[0] != "POST Request" || m.cursor != 0 {
+		t.Errorf("Expected POST Request moved up with the cursor, got %v cursor %d", got, m.cursor)
+	}
+	m, _ = handleMoveUpKey(m)
+	if m.cursor != 0 {
+		t.Error("Expected moving the first item up to do nothing")
+	}
+	m, _ = handleMoveDownKey(m)
+	if got := currentNames(m); got[1] != "POST Request" || m.cursor != 1 {
+		t.Errorf("Expected POST Request moved back down, got %v cursor %d", got, m.cursor)
+	}
+}
@@ -20,36 +20,64 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.responseViewport.Width = msg.Width - 8
 		m.infoViewport.Width = msg.Width - 8
 		m.jsonViewport.Width = msg.Width - 8
+		m.logsViewport.Width = msg.Width - 8
+		m.logsViewport.Height = msg.Height - 14
 		return m, nil
 
+	case EditorFinishedMsg:
+		m = m.applyExternalEdit(msg)
+		if m.mode == ModeEdit && m.editType == EditTypeScript {
+			return m, m.editFieldTextArea.Focus()
+		}
+		return m, nil
+
+	case clipboardMsg:
+		if msg.err != nil {
+			m.statusMessage = fmt.Sprintf("Failed to copy to clipboard: %v", msg.err)
+		}
+		return m, nil
+
+	case RunnerStepMsg:
+		return m.handleRunnerStep(msg)
+
+	case runnerTickMsg:
+		return m.handleRunnerTick(msg)
+
+	case loadTestTickMsg:
+		return m.handleLoadTestTick(msg)
+
 	case RequestCompleteMsg:
-		m.lastResponse = msg.Response
-		m.lastTestResult = msg.TestResult
-		m.lastExecutedItemID = msg.ItemID
+		m = m.finishInFlight(msg.ItemID)
+		m = m.applyVariableChanges(msg.ItemName, msg.Collection, msg.Environment, msg.VariableChanges)
+
+		if latest, exists := m.requestExecutions[msg.ItemID]; exists && latest.Sequence > msg.Sequence {
+			msg.Response.RemoveBodyFile()
+			m.statusMessage = fmt.Sprintf("Ignored an earlier response for %s; a newer one is already shown", msg.ItemName)
+			return m, nil
+		}
+
+		// A response for another request is only stored while the user is
+		// reading one, so parallel requests don't switch the view under them.
+		display := m.mode != ModeResponse || m.lastResponse == nil || m.lastExecutedItemID == msg.ItemID
+		if display {
+			m.lastResponse = msg.Response
+			m.lastTestResult = msg.TestResult
+			m.lastExecutedItemID = msg.ItemID
+			m.responseExample = ""
+		}
 
 		status := "Error"
 		if msg.Response.Error == nil {
 			status = msg.Response.Status
 		}
-		m.requestExecutions[msg.ItemID] = &RequestExecution{
+		m.setRequestExecution(msg.ItemID, &RequestExecution{
 			Status:     status,
 			Timestamp:  time.Now(),
 			Duration:   msg.Response.Duration,
 			Response:   msg.Response,
 			TestResult: msg.TestResult,
-		}
-
-		if msg.Collection != nil && msg.TestResult != nil {
-			if err := m.parser.SaveCollection(msg.Collection.Info.Name); err != nil {
-				m.statusMessage = fmt.Sprintf("Warning: failed to save collection variables: %v", err)
-			}
-		}
-
-		if msg.Environment != nil && msg.TestResult != nil {
-			if err := m.parser.SaveEnvironment(msg.Environment.Name); err != nil {
-				m.statusMessage = fmt.Sprintf("Warning: failed to save environment variables: %v", err)
-			}
-		}
+			Sequence:   msg.Sequence,
+		})
 
 		if msg.Response.Error != nil {
 			m.statusMessage = fmt.Sprintf("Request failed: %s - %v", msg.ItemName, msg.Response.Error)
@@ -60,13 +88,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			m.statusMessage = fmt.Sprintf("Response: %s - %s (%v)%s", msg.ItemName, msg.Response.Status, msg.Response.Duration, statusSuffix)
 		}
+		if !display {
+			m.statusMessage += " (select it and press ctrl+r to view)"
+			return m, nil
+		}
 
 		if m.mode == ModeResponse {
 			m.responseViewport.Width = m.width - 8
 			m.responseViewport.Height = m.height - 8
 			lines := m.buildResponseLines()
 			content := strings.Join(lines, "\n")
-			m.responseViewport.SetContent(content)
+			m = m.setViewportContent(ModeResponse, content)
 		}
 
 		return m, nil
@@ -78,6 +110,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.searchMode {
 			return m.handleSearchMode(msg)
 		}
+		if m.filterMode {
+			return m.handleFilterMode(msg)
+		}
+		if m.snippetPicker != nil {
+			return m.handleSnippetPickerKeys(msg)
+		}
+		if m.examplePicker != nil {
+			return m.handleExamplePickerKeys(msg)
+		}
 		if m.mode == ModeEdit {
 			if m.editFieldMode {
 				return m.handleFieldEdit(msg)
@@ -85,9 +126,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.handleEditModeKeys(msg)
 		}
 
-		if m.mode == ModeResponse || m.mode == ModeInfo || m.mode == ModeJSON {
+		if m.mode == ModeResponse || m.mode == ModeInfo || m.mode == ModeJSON || m.mode == ModeLog {
 			key := msg.String()
-			if key == "esc" || key == "h" || key == "backspace" || key == "q" {
+			if key == "esc" || key == "h" || key == "backspace" || key == "q" || key == ":" {
+				return m.handleNormalMode(msg)
+			}
+			if !isViewportScrollKey(key) && m.commandRegistry.HasKeyBinding(m.mode, key) {
 				return m.handleNormalMode(msg)
 			}
 
@@ -112,6 +156,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+func isViewportScrollKey(key string) bool {
+	switch key {
+	case "up", "down", "k", "j":
+		return true
+	}
+	return false
+}
+
 func (m Model) handleCommandMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
@@ -186,6 +238,10 @@ func (m Model) handleCommandMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (m Model) handleSearchMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
+	if isSearchableViewport(m.mode) {
+		return m.handleViewportSearchMode(msg)
+	}
+
 	switch msg.Type {
 	case tea.KeyEsc:
 		m.searchMode = false
@@ -344,6 +400,87 @@ func (m Model) loadCollection(path string) Model {
 	return m
 }
 
+func (m Model) createCollection(name string, path string) Model {
+	collection, err := m.parser.NewCollection(name, path)
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Failed to create collection: %v", err)
+		return m
+	}
+
+	m.collection = collection
+	m.mode = ModeRequests
+	m = m.loadRequestsList()
+
+	if err := m.parser.SaveState(); err != nil {
+		m.statusMessage = fmt.Sprintf("Created collection: %s (warning: failed to save state)", name)
+	} else {
+		m.statusMessage = fmt.Sprintf("Created collection: %s (use :new and :mkdir to add items)", name)
+	}
+	return m
+}
+
+func (m Model) createEnvironment(name string, path string) Model {
+	environment, err := m.parser.NewEnvironment(name, path)
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Failed to create environment: %v", err)
+		return m
+	}
+
+	m.environment = environment
+	m.mode = ModeEnvironments
+	m = m.loadEnvironmentsList()
+
+	if err := m.parser.SaveState(); err != nil {
+		m.statusMessage = fmt.Sprintf("Created environment: %s (warning: failed to save state)", name)
+	} else {
+		m.statusMessage = fmt.Sprintf("Created environment: %s", name)
+	}
+	return m
+}
+
+func (m Model) saveAs(path string) Model {
+	var name string
+	var err error
+
+	switch m.mode {
+	case ModeRequests:
+		if m.collection == nil {
+			m.statusMessage = "No collection loaded"
+			return m
+		}
+		name = m.collection.Info.Name
+		err = m.parser.SaveCollectionAs(name, path)
+		if err == nil {
+			delete(m.modifiedCollections, name)
+		}
+	case ModeEnvironments:
+		if m.cursor >= len(m.items) {
+			m.statusMessage = "No environment selected"
+			return m
+		}
+		name = m.items[m.cursor]
+		err = m.parser.SaveEnvironmentAs(name, path)
+		if err == nil {
+			delete(m.modifiedEnvironments, name)
+		}
+	default:
+		m.statusMessage = "Save as is only available for collections and environments"
+		return m
+	}
+
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Failed to save %s: %v", name, err)
+		return m
+	}
+
+	if err := m.parser.SaveState(); err != nil {
+		m.statusMessage = fmt.Sprintf("Saved %s to %s (warning: failed to save state)", name, path)
+	} else {
+		m.statusMessage = fmt.Sprintf("Saved %s to %s", name, path)
+	}
+	return m
+}
+
 func (m Model) handleSelection() Model {
 	if len(m.items) == 0 || m.cursor >= len(m.items) {
 		return m
@@ -390,7 +527,7 @@ func (m Model) handleSelection() Model {
 				m.infoViewport.Height = m.height - 8
 				lines := m.buildEnvironmentInfoLines()
 				content := strings.Join(lines, "\n")
-				m.infoViewport.SetContent(content)
+				m = m.setViewportContent(ModeInfo, content)
 
 				m.statusMessage = fmt.Sprintf("Showing environment: %s (q to close)", envName)
 			} else {
@@ -423,15 +560,23 @@ func (m Model) executeRequest(item postman.Item) (Model, tea.Cmd) {
 		m.statusMessage = fmt.Sprintf("Sending: %s %s", item.Request.Method, item.Name)
 	}
 
-	m.requestExecutions[itemID] = &RequestExecution{
-		Status:     "Sending...",
-		Timestamp:  time.Now(),
-		Duration:   0,
-		Response:   nil,
-		TestResult: nil,
+	return m.sendRequest(item, itemID, requestToExecute, isModified)
+}
+
+func (m Model) sendRequest(item postman.Item, itemID string, requestToExecute *postman.Request, isModified bool) (Model, tea.Cmd) {
+	m.requestSequence++
+	sequence := m.requestSequence
+	m.inFlightRequests[itemID]++
+	inFlight := 0
+	for _, count := range m.inFlightRequests {
+		inFlight += count
+	}
+	if inFlight > 1 {
+		m.statusMessage += fmt.Sprintf(" (%d requests in flight)", inFlight)
 	}
 
 	variables := m.parser.GetAllVariables(m.collection, m.breadcrumb, m.environment)
+	scopes := postman.SnapshotVariableScopes(m.collection, m.environment)
 
 	executor := m.executor
 	collection := m.collection
@@ -439,18 +584,65 @@ func (m Model) executeRequest(item postman.Item) (Model, tea.Cmd) {
 	itemCopy := item
 
 	return m, func() tea.Msg {
-		response, testResult := executor.Execute(requestToExecute, &itemCopy, collection, environment, variables)
+		response, testResult, changes := executor.ExecuteInScopes(requestToExecute, &itemCopy, scopes, variables, nil)
 
 		return RequestCompleteMsg{
-			ItemID:      itemID,
-			Response:    response,
-			TestResult:  testResult,
-			Collection:  collection,
-			Environment: environment,
-			ItemName:    item.Name,
-			IsModified:  isModified,
+			ItemID:          itemID,
+			Response:        response,
+			TestResult:      testResult,
+			Collection:      collection,
+			Environment:     environment,
+			ItemName:        item.Name,
+			IsModified:      isModified,
+			VariableChanges: changes,
+			Sequence:        sequence,
+		}
+	}
+}
+
+func (m Model) finishInFlight(itemID string) Model {
+	if m.inFlightRequests[itemID] <= 1 {
+		delete(m.inFlightRequests, itemID)
+	} else {
+		m.inFlightRequests[itemID]--
+	}
+	return m
+}
+
+func (m Model) applyVariableChanges(itemName string, collection *postman.Collection, environment *postman.Environment, changes postman.VariableChanges) Model {
+	if changes.Empty() {
+		return m
+	}
+
+	var collectionNames, environmentNames []string
+	if collection != nil {
+		if live, exists := m.parser.GetCollection(collection.Info.Name); exists {
+			collection = live
+		}
+		collectionNames = append(collectionNames, collection.Info.Name)
+	}
+	if environment != nil {
+		if live, exists := m.parser.GetEnvironment(environment.Name); exists {
+			environment = live
+		}
+		environmentNames = append(environmentNames, environment.Name)
+	}
+
+	before := m.captureState(collectionNames, environmentNames)
+	changes.ApplyTo(collection, environment)
+	m = m.recordVariableChanges(itemName, before)
+
+	if collection != nil && len(changes.Collection) > 0 {
+		if _, err := m.autoSaveCollection(collection.Info.Name); err != nil {
+			m.statusMessage = fmt.Sprintf("Warning: failed to save collection variables: %v", err)
+		}
+	}
+	if environment != nil && len(changes.Environment) > 0 {
+		if err := m.parser.SaveEnvironment(environment.Name); err != nil {
+			m.statusMessage = fmt.Sprintf("Warning: failed to save environment variables: %v", err)
 		}
 	}
+	return m
 }
 
 func (m Model) navigateInto(item postman.Item) Model {
@@ -690,6 +882,9 @@ func (m Model) enterEditMode(item postman.Item) Model {
 	m.editRequest = m.deepCopyRequest(item.Request)
 	m.editItemName = item.Name
 	m.editOriginalName = item.Name
+	m.editItemID = item.ID
+	m.editUndoStack = nil
+	m.editRedoStack = nil
 	m.editType = EditTypeRequest
 	m.editFieldCursor = 0
 	m.editFieldMode = false
@@ -716,20 +911,27 @@ func (m Model) saveEdit() Model {
 			return m
 		}
 
+		before := m.captureCollection(m.editCollectionName)
 		if !m.updateRequestInCollection(m.editItemPath, m.editOriginalName, m.editItemName, m.editRequest) {
 			m.statusMessage = "Error: Failed to update request in collection"
 			return m
 		}
 
-		itemID := m.getRequestIdentifierByPath(m.editCollectionName, m.editItemPath, m.editOriginalName)
+		itemID := m.getEditIdentifier(m.editOriginalName)
 		m.modifiedRequests[itemID] = m.editRequest
 		m.modifiedItems[itemID] = true
 		m.modifiedCollections[m.editCollectionName] = true
+		m = m.recordChange(fmt.Sprintf("Edit request %s", m.editItemName), before)
 
-		if err := m.parser.SaveCollection(m.editCollectionName); err != nil {
+		saved, err := m.autoSaveCollection(m.editCollectionName)
+		if err != nil {
 			m.statusMessage = fmt.Sprintf("Failed to save collection: %v", err)
 			return m
 		}
+		if !saved {
+			m.statusMessage = importedCollectionStatus
+			return m
+		}
 
 		m.statusMessage = "Saved changes to collection file"
 		delete(m.modifiedItems, itemID)
@@ -737,12 +939,19 @@ func (m Model) saveEdit() Model {
 			delete(m.modifiedCollections, m.editCollectionName)
 		}
 	case EditTypeScript:
+		before := m.captureCollection(m.editCollectionName)
 		m = m.saveScript()
-		if err := m.parser.SaveCollection(m.editCollectionName); err != nil {
+		m = m.recordChange(fmt.Sprintf("Edit script of %s", m.editScriptItemName), before)
+		saved, err := m.autoSaveCollection(m.editCollectionName)
+		if err != nil {
 			m.statusMessage = fmt.Sprintf("Failed to save collection: %v", err)
 			return m
 		}
-		itemID := m.getRequestIdentifierByPath(m.editCollectionName, m.editItemPath, m.editScriptItemName)
+		if !saved {
+			m.statusMessage = importedCollectionStatus
+			return m
+		}
+		itemID := m.getEditIdentifier(m.editScriptItemName)
 		delete(m.modifiedItems, itemID)
 		if len(m.modifiedItems) == 0 {
 			delete(m.modifiedCollections, m.editCollectionName)
@@ -752,8 +961,24 @@ func (m Model) saveEdit() Model {
 	return m
 }
 
+const importedCollectionStatus = "Changes kept in memory: the collection was imported, use :saveas <path> to save it"
+
+// autoSaveCollection writes an in-place edit of the named collection to its
+// file. Collections imported from a non-Postman file are only marked as
+// modified instead, so the source is never overwritten; saved reports which.
+func (m Model) autoSaveCollection(name string) (saved bool, err error) {
+	if m.parser.IsImported(name) {
+		m.modifiedCollections[name] = true
+		return false, nil
+	}
+	if err := m.parser.SaveCollection(name); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func (m Model) saveAllModifiedRequests() Model {
-	if len(m.modifiedCollections) == 0 {
+	if len(m.modifiedCollections) == 0 && len(m.modifiedEnvironments) == 0 {
 		m.statusMessage = "No unsaved changes"
 		return m
 	}
@@ -769,11 +994,20 @@ func (m Model) saveAllModifiedRequests() Model {
 		}
 	}
 
+	for environmentName := range m.modifiedEnvironments {
+		if err := m.parser.SaveEnvironment(environmentName); err != nil {
+			errors = append(errors, fmt.Sprintf("%s: %v", environmentName, err))
+		} else {
+			savedCount++
+		}
+	}
+
 	if len(errors) > 0 {
 		m.statusMessage = fmt.Sprintf("Saved %d collections, %d errors: %s", savedCount, len(errors), strings.Join(errors, "; "))
 	} else {
 		m.statusMessage = fmt.Sprintf("Saved %d collection(s) to file", savedCount)
 		m.modifiedCollections = make(map[string]bool)
+		m.modifiedEnvironments = make(map[string]bool)
 		m.modifiedItems = make(map[string]bool)
 	}
 
@@ -791,15 +1025,17 @@ func (m Model) handleEditModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	switch msg.String() {
 	case "esc":
+		before := m.captureCollection(m.editCollectionName)
 		if !m.updateRequestInCollection(m.editItemPath, m.editOriginalName, m.editItemName, m.editRequest) {
 			m.statusMessage = "Error: Failed to update request in collection"
 			return m, nil
 		}
 
-		itemID := m.getRequestIdentifierByPath(m.editCollectionName, m.editItemPath, m.editOriginalName)
+		itemID := m.getEditIdentifier(m.editOriginalName)
 		m.modifiedRequests[itemID] = m.editRequest
 		m.modifiedItems[itemID] = true
 		m.modifiedCollections[m.editCollectionName] = true
+		m = m.recordChange(fmt.Sprintf("Edit request %s", m.editItemName), before)
 		m.mode = m.previousMode
 		m.editType = EditTypeNone
 		m.editFieldMode = false
@@ -813,6 +1049,15 @@ func (m Model) handleEditModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.commandInput.Focus()
 		return m, m.commandInput.Focus()
 
+	case "u":
+		return m.undoEditField(), nil
+
+	case "ctrl+o":
+		return m.openBodyInEditor()
+
+	case "ctrl+r":
+		return m.redoEditField(), nil
+
 	case "j", "down":
 		fieldCount := m.getEditFieldCount()
 		if m.editFieldCursor < fieldCount-1 {
@@ -832,7 +1077,16 @@ func (m Model) handleEditModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		fieldName := []string{"Name", "Method", "URL", "Headers", "Body"}[m.editFieldCursor]
 
 		if m.editFieldCursor >= 3 {
-			m.editFieldTextArea.SetValue(fieldValue)
+			updated, err := m.setTextAreaValue(fieldValue)
+			if err != nil {
+				m.editFieldMode = false
+				m.statusMessage = fmt.Sprintf("Cannot edit %s here: %v", fieldName, err)
+				if fieldName == "Body" {
+					m.statusMessage += " (ctrl+o opens it in $EDITOR)"
+				}
+				return m, nil
+			}
+			m = updated
 			m.editFieldTextArea.Focus()
 			m.statusMessage = fmt.Sprintf("Editing %s... (Ctrl+S to save, Esc to cancel)", fieldName)
 			return m, m.editFieldTextArea.Focus()
@@ -914,6 +1168,9 @@ func (m Model) handleScriptEditKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.statusMessage = "Cancelled script editing"
 		return m, nil
 
+	case tea.KeyCtrlO:
+		return m.openScriptInEditor()
+
 	case tea.KeyRunes:
 		if msg.String() == ":" {
 			m.commandMode = true
@@ -945,9 +1202,16 @@ func (m Model) handleFieldEdit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.statusMessage = "Field edit cancelled"
 		return m, nil
 
+	case tea.KeyCtrlO:
+		if m.editFieldCursor == 4 {
+			return m.openBodyInEditor()
+		}
+		return m, nil
+
 	case tea.KeyCtrlS:
 		if isMultiLineField {
 			if m.editType == EditTypeRequest && m.editRequest != nil {
+				m = m.pushEditHistory()
 				switch m.editFieldCursor {
 				case 3:
 					m.editRequest.Header = m.parseHeaders(m.editFieldTextArea.Value())
@@ -968,6 +1232,7 @@ func (m Model) handleFieldEdit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case tea.KeyEnter:
 		if !isMultiLineField {
 			if m.editType == EditTypeRequest && m.editRequest != nil {
+				m = m.pushEditHistory()
 				switch m.editFieldCursor {
 				case 0:
 					m.editItemName = m.editFieldInput.Value()
@@ -1000,11 +1265,8 @@ func (m Model) deepCopyRequest(req *postman.Request) *postman.Request {
 		return nil
 	}
 
-	copied := &postman.Request{
-		Method: req.Method,
-	}
+	copied := *req
 
-	copied.URL.Raw = req.URL.Raw
 	if req.URL.Host != nil {
 		copied.URL.Host = make([]string, len(req.URL.Host))
 		copy(copied.URL.Host, req.URL.Host)
@@ -1020,24 +1282,36 @@ func (m Model) deepCopyRequest(req *postman.Request) *postman.Request {
 	}
 
 	if req.Body != nil {
-		copied.Body = &postman.Body{
-			Mode: req.Body.Mode,
-			Raw:  req.Body.Raw,
+		body := *req.Body
+		copied.Body = &body
+		if req.Body.FormData != nil {
+			copied.Body.FormData = make([]postman.FormParam, len(req.Body.FormData))
+			copy(copied.Body.FormData, req.Body.FormData)
 		}
 	}
 
-	return copied
+	return &copied
 }
 
 func (m Model) getRequestIdentifier(item postman.Item) string {
 	if m.collection == nil {
 		return ""
 	}
-	path := strings.Join(m.breadcrumb, "/")
-	if path != "" {
-		return m.collection.Info.Name + "/" + path + "/" + item.Name
+	return m.getItemIdentifier(m.collection.Info.Name, m.breadcrumb, item)
+}
+
+func (m Model) getItemIdentifier(collectionName string, breadcrumb []string, item postman.Item) string {
+	if item.ID != "" {
+		return item.ID
+	}
+	return m.getRequestIdentifierByPath(collectionName, breadcrumb, item.Name)
+}
+
+func (m Model) getEditIdentifier(itemName string) string {
+	if m.editItemID != "" {
+		return m.editItemID
 	}
-	return m.collection.Info.Name + "/" + item.Name
+	return m.getRequestIdentifierByPath(m.editCollectionName, m.editItemPath, itemName)
 }
 
 func (m Model) getRequestIdentifierByPath(collectionName string, breadcrumb []string, requestName string) string {
@@ -1059,39 +1333,84 @@ func (m Model) getEditFieldCount() int {
 	return 0
 }
 
-func (m Model) navigateToChangedRequest(itemID string) Model {
+type itemLocation struct {
+	collection *postman.Collection
+	folderPath []string
+	item       *postman.Item
+}
+
+func (m Model) locateItem(itemID string) (itemLocation, error) {
+	collections := []*postman.Collection{}
+	if m.collection != nil {
+		collections = append(collections, m.collection)
+	}
+	for _, name := range m.parser.ListCollections() {
+		if collection, exists := m.parser.GetCollection(name); exists && collection != m.collection {
+			collections = append(collections, collection)
+		}
+	}
+
+	for _, collection := range collections {
+		if item, folderPath := postman.FindItemByID(collection.Items, itemID); item != nil {
+			return itemLocation{collection: collection, folderPath: folderPath, item: item}, nil
+		}
+	}
+
 	parts := strings.Split(itemID, "/")
 	if len(parts) < 2 {
-		m.statusMessage = "Invalid request ID"
-		return m
+		return itemLocation{}, fmt.Errorf("Invalid request ID")
 	}
 
 	collectionName := parts[0]
 	requestName := parts[len(parts)-1]
 	folderPath := parts[1 : len(parts)-1]
 
-	if m.collection == nil || m.collection.Info.Name != collectionName {
-		if collection, exists := m.parser.GetCollection(collectionName); exists {
-			m.collection = collection
-		} else {
-			m.statusMessage = fmt.Sprintf("Collection not found: %s", collectionName)
-			return m
+	collection := m.collection
+	if collection == nil || collection.Info.Name != collectionName {
+		found, exists := m.parser.GetCollection(collectionName)
+		if !exists {
+			return itemLocation{}, fmt.Errorf("Collection not found: %s", collectionName)
 		}
+		collection = found
+	}
+
+	item := m.findOriginalItem(collection.Items, folderPath, requestName)
+	if item == nil {
+		return itemLocation{}, fmt.Errorf("Request not found: %s", requestName)
+	}
+	return itemLocation{collection: collection, folderPath: folderPath, item: item}, nil
+}
+
+func (m Model) changeLabel(itemID string) string {
+	location, err := m.locateItem(itemID)
+	if err != nil {
+		return itemID
+	}
+	parts := append([]string{location.collection.Info.Name}, location.folderPath...)
+	return strings.Join(append(parts, location.item.Name), "/")
+}
+
+func (m Model) navigateToChangedRequest(itemID string) Model {
+	location, err := m.locateItem(itemID)
+	if err != nil {
+		m.statusMessage = err.Error()
+		return m
 	}
 
+	m.collection = location.collection
 	m.mode = ModeRequests
-	m.breadcrumb = folderPath
+	m.breadcrumb = location.folderPath
 	m = m.loadRequestsList()
 
 	for i, item := range m.currentItems {
-		if item.Name == requestName {
+		if m.getRequestIdentifier(item) == m.getRequestIdentifier(*location.item) {
 			m.cursor = i
-			m.statusMessage = fmt.Sprintf("Navigated to: %s", requestName)
+			m.statusMessage = fmt.Sprintf("Navigated to: %s", item.Name)
 			return m
 		}
 	}
 
-	m.statusMessage = fmt.Sprintf("Request not found: %s", requestName)
+	m.statusMessage = fmt.Sprintf("Request not found: %s", location.item.Name)
 	return m
 }
 
@@ -1102,27 +1421,24 @@ func (m Model) showChangeDiff(itemID string) Model {
 		return m
 	}
 
-	parts := strings.Split(itemID, "/")
-	if len(parts) < 2 {
-		m.statusMessage = "Invalid request ID"
+	location, err := m.locateItem(itemID)
+	if err != nil {
+		m.statusMessage = err.Error()
 		return m
 	}
+	m.collection = location.collection
 
-	collectionName := parts[0]
-	requestName := parts[len(parts)-1]
-	folderPath := parts[1 : len(parts)-1]
-
-	if m.collection == nil || m.collection.Info.Name != collectionName {
-		if collection, exists := m.parser.GetCollection(collectionName); exists {
-			m.collection = collection
-		} else {
-			m.statusMessage = "Collection not found"
-			return m
-		}
+	saved, err := m.parser.LoadCollectionSnapshot(location.collection.Info.Name)
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Failed to load saved collection: %v", err)
+		return m
 	}
 
-	originalReq := m.findOriginalRequest(m.collection.Items, folderPath, requestName)
-	if originalReq == nil {
+	original, _ := postman.FindItemByID(saved.Items, location.item.ID)
+	if original == nil {
+		original = m.findOriginalItem(saved.Items, location.folderPath, location.item.Name)
+	}
+	if original == nil {
 		m.statusMessage = "Original request not found"
 		return m
 	}
@@ -1130,16 +1446,20 @@ func (m Model) showChangeDiff(itemID string) Model {
 	m.previousMode = ModeChanges
 	m.mode = ModeInfo
 	m.scrollOffset = 0
+	m.diffItemID = itemID
 	m.currentInfoItem = &postman.Item{
-		Name:    "Diff: " + requestName,
+		Name:    "Diff: " + location.item.Name,
 		Request: modifiedReq,
 	}
 
 	m.infoViewport.Width = m.width - 8
 	m.infoViewport.Height = m.height - 8
-	lines := m.buildItemInfoLines()
-	content := strings.Join(lines, "\n")
-	m.infoViewport.SetContent(content)
+	lines := m.buildRequestDiffLines(location.item.Name, original, &postman.Item{
+		Name:    location.item.Name,
+		Request: modifiedReq,
+		Events:  location.item.Events,
+	})
+	m = m.setViewportContent(ModeInfo, strings.Join(lines, "\n"))
 
 	m.statusMessage = "Showing diff (original → modified) (q to close)"
 
@@ -1147,6 +1467,13 @@ func (m Model) showChangeDiff(itemID string) Model {
 }
 
 func (m Model) findOriginalRequest(items []postman.Item, folderPath []string, requestName string) *postman.Request {
+	if item := m.findOriginalItem(items, folderPath, requestName); item != nil {
+		return item.Request
+	}
+	return nil
+}
+
+func (m Model) findOriginalItem(items []postman.Item, folderPath []string, requestName string) *postman.Item {
 	currentItems := items
 
 	for _, folderName := range folderPath {
@@ -1163,9 +1490,9 @@ func (m Model) findOriginalRequest(items []postman.Item, folderPath []string, re
 		}
 	}
 
-	for _, item := range currentItems {
-		if item.IsRequest() && item.Name == requestName {
-			return item.Request
+	for i := range currentItems {
+		if currentItems[i].IsRequest() && currentItems[i].Name == requestName {
+			return &currentItems[i]
 		}
 	}
 
@@ -1205,6 +1532,12 @@ func (m Model) parseHeaders(text string) []postman.Header {
 		return []postman.Header{}
 	}
 
+	var previous []postman.Header
+	if m.editRequest != nil {
+		previous = m.editRequest.Header
+	}
+	used := make([]bool, len(previous))
+
 	var headers []postman.Header
 	lines := strings.Split(text, "\n")
 	for _, line := range lines {
@@ -1218,10 +1551,16 @@ func (m Model) parseHeaders(text string) []postman.Header {
 			key := strings.TrimSpace(parts[0])
 			value := strings.TrimSpace(parts[1])
 			if key != "" {
-				headers = append(headers, postman.Header{
-					Key:   key,
-					Value: value,
-				})
+				header := postman.Header{Key: key}
+				for i, existing := range previous {
+					if !used[i] && existing.Key == key {
+						header = existing
+						used[i] = true
+						break
+					}
+				}
+				header.Value = value
+				headers = append(headers, header)
 			}
 		}
 	}
@@ -1254,7 +1593,7 @@ func (m Model) updateRequestInCollection(path []string, originalName string, new
 	}
 
 	for i := range *items {
-		if (*items)[i].IsRequest() && (*items)[i].Name == originalName {
+		if (*items)[i].IsRequest() && (*items)[i].Name == originalName && (m.editItemID == "" || (*items)[i].ID == m.editItemID) {
 			(*items)[i].Name = newName
 			(*items)[i].Request = updatedRequest
 			return true
@@ -1264,6 +1603,28 @@ func (m Model) updateRequestInCollection(path []string, originalName string, new
 	return false
 }
 
+// appendToCurrentFolder adds item at the end of the folder shown in the
+// requests view, records the change for undo and saves the collection.
+func (m Model) appendToCurrentFolder(item postman.Item, change string) (Model, bool) {
+	items, err := m.currentFolder()
+	if err != nil {
+		m.statusMessage = "Error: Could not find folder in breadcrumb"
+		return m, false
+	}
+
+	before := m.captureCollection(m.collection.Info.Name)
+	*items = append(*items, item)
+
+	m.modifiedCollections[m.collection.Info.Name] = true
+	m = m.recordChange(change, before)
+
+	if _, err := m.autoSaveCollection(m.collection.Info.Name); err != nil {
+		m.statusMessage = fmt.Sprintf("Failed to save collection: %v", err)
+		return m, false
+	}
+	return m, true
+}
+
 func (m Model) duplicateRequest(item postman.Item) Model {
 	if m.collection == nil || !item.IsRequest() || item.Request == nil {
 		m.statusMessage = "Error: Cannot duplicate request"
@@ -1271,40 +1632,45 @@ func (m Model) duplicateRequest(item postman.Item) Model {
 	}
 
 	duplicatedItem := postman.Item{
+		ID:          postman.NewID(),
 		Name:        item.Name + " (copy)",
 		Request:     m.deepCopyRequest(item.Request),
 		Description: item.Description,
 	}
 
-	items := &m.collection.Items
-
-	for _, folderName := range m.breadcrumb {
-		found := false
-		for j := range *items {
-			if (*items)[j].Name == folderName && (*items)[j].IsFolder() {
-				items = &(*items)[j].Items
-				found = true
-				break
-			}
-		}
-		if !found {
-			m.statusMessage = "Error: Could not find folder in breadcrumb"
-			return m
-		}
+	m, ok := m.appendToCurrentFolder(duplicatedItem, fmt.Sprintf("Duplicate request %s", item.Name))
+	if !ok {
+		return m
 	}
 
-	*items = append(*items, duplicatedItem)
+	m = m.refreshCurrentView()
+	m.cursor = len(m.currentItems) - 1
+	m.statusMessage = fmt.Sprintf("Duplicated request: %s", duplicatedItem.Name)
 
-	m.modifiedCollections[m.collection.Info.Name] = true
+	return m
+}
 
-	if err := m.parser.SaveCollection(m.collection.Info.Name); err != nil {
-		m.statusMessage = fmt.Sprintf("Failed to save collection: %v", err)
+func (m Model) importCurl(command string) Model {
+	if m.collection == nil {
+		m.statusMessage = "No collection loaded"
 		return m
 	}
 
+	item, err := postman.ParseCurlCommand(command)
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Failed to parse curl command: %v", err)
+		return m
+	}
+
+	m, ok := m.appendToCurrentFolder(*item, fmt.Sprintf("Import request %s", item.Name))
+	if !ok {
+		return m
+	}
+
+	m.mode = ModeRequests
 	m = m.refreshCurrentView()
 	m.cursor = len(m.currentItems) - 1
-	m.statusMessage = fmt.Sprintf("Duplicated request: %s", duplicatedItem.Name)
+	m.statusMessage = fmt.Sprintf("Imported request: %s", item.Name)
 
 	return m
 }
@@ -1332,16 +1698,22 @@ func (m Model) deleteRequest(item postman.Item) Model {
 		}
 	}
 
+	before := m.captureCollection(m.collection.Info.Name)
 	for i := range *items {
-		if (*items)[i].Name == item.Name && (*items)[i].IsRequest() {
+		if (*items)[i].Name == item.Name && (*items)[i].ID == item.ID && (*items)[i].IsRequest() {
 			*items = append((*items)[:i], (*items)[i+1:]...)
 			break
 		}
 	}
 
+	itemID := m.getRequestIdentifier(item)
+	delete(m.modifiedRequests, itemID)
+	delete(m.modifiedItems, itemID)
+	delete(m.requestExecutions, itemID)
 	m.modifiedCollections[m.collection.Info.Name] = true
+	m = m.recordChange(fmt.Sprintf("Delete request %s", item.Name), before)
 
-	if err := m.parser.SaveCollection(m.collection.Info.Name); err != nil {
+	if _, err := m.autoSaveCollection(m.collection.Info.Name); err != nil {
 		m.statusMessage = fmt.Sprintf("Failed to save collection: %v", err)
 		return m
 	}
@@ -1483,6 +1855,7 @@ func (m Model) enterScriptSelectionMode(item postman.Item) Model {
 		}
 		m.editScriptType = ScriptTypeTest
 		m.editScriptItemName = item.Name
+		m.editItemID = item.ID
 		m.editItemPath = append([]string{}, m.breadcrumb...)
 		m.editCollectionName = m.collection.Info.Name
 		m.editType = EditTypeScript
@@ -1497,6 +1870,7 @@ func (m Model) enterScriptSelectionMode(item postman.Item) Model {
 
 	m.scriptSelectionMode = true
 	m.editScriptItemName = item.Name
+	m.editItemID = item.ID
 	m.editItemPath = append([]string{}, m.breadcrumb...)
 	m.editCollectionName = m.collection.Info.Name
 	m.previousMode = m.mode
@@ -1615,7 +1989,7 @@ func (m Model) saveScript() Model {
 	}
 
 	m.modifiedCollections[m.editCollectionName] = true
-	itemID := m.getRequestIdentifierByPath(m.editCollectionName, m.editItemPath, m.editScriptItemName)
+	itemID := m.getEditIdentifier(m.editScriptItemName)
 	m.modifiedItems[itemID] = true
 
 	scriptTypeName := "pre-request"
@@ -1648,7 +2022,7 @@ func (m Model) findItemByPath(path []string, itemName string) *postman.Item {
 	}
 
 	for i := range current {
-		if current[i].Name == itemName {
+		if current[i].Name == itemName && (m.editItemID == "" || current[i].ID == m.editItemID) {
 			return &current[i]
 		}
 	}
@@ -1,10 +1,17 @@
 package tui
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"postOffice/internal/postman"
 
+	httpclient "postOffice/internal/http"
+
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -850,6 +857,88 @@ func TestIsItemModified(t *testing.T) {
 	}
 }
 
+func TestGetRequestIdentifier_UsesItemID(t *testing.T) {
+	m := createTestModel()
+	item := postman.Item{ID: "abc-123", Name: "Test Request"}
+
+	if id := m.getRequestIdentifier(item); id != "abc-123" {
+		t.Errorf("Expected item ID to be used as identifier, got '%s'", id)
+	}
+}
+
+func TestEditRequest_SameNameSiblingsKeepSeparateState(t *testing.T) {
+	m := createSavedTestModel(t)
+	m = m.duplicateRequest(m.currentItems[1])
+	m.collection.Items[2].Name = "POST Request"
+	m = m.refreshCurrentView()
+
+	first, second := m.currentItems[1], m.currentItems[2]
+	if first.Name != second.Name || first.ID == second.ID {
+		t.Fatalf("Expected same-name siblings with distinct IDs, got %q/%q and %q/%q", first.Name, first.ID, second.Name, second.ID)
+	}
+
+	m = m.enterEditMode(second)
+	m.editRequest.Method = "PUT"
+	updated, _ := m.handleEditModeKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+
+	if m.isItemModified(first.ID) {
+		t.Error("Expected untouched sibling to stay unmodified")
+	}
+	if !m.isItemModified(second.ID) {
+		t.Error("Expected edited sibling to be modified")
+	}
+	if m.collection.Items[1].Request.Method != "POST" || m.collection.Items[2].Request.Method != "PUT" {
+		t.Errorf("Expected only the edited sibling to change, got %s and %s",
+			m.collection.Items[1].Request.Method, m.collection.Items[2].Request.Method)
+	}
+}
+
+func TestEditRequest_RenameKeepsState(t *testing.T) {
+	m := createSavedTestModel(t)
+	item := m.currentItems[1]
+	itemID := m.getRequestIdentifier(item)
+	m.requestExecutions[itemID] = &RequestExecution{Status: "200 OK"}
+
+	m = m.enterEditMode(item)
+	m.editItemName = "Renamed Request"
+	updated, _ := m.handleEditModeKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+
+	renamed := m.currentItems[1]
+	if renamed.Name != "Renamed Request" {
+		t.Fatalf("Expected request to be renamed, got %s", renamed.Name)
+	}
+	if m.getRequestIdentifier(renamed) != itemID {
+		t.Errorf("Expected identifier to survive rename")
+	}
+	if _, exists := m.requestExecutions[itemID]; !exists {
+		t.Error("Expected execution state to survive rename")
+	}
+
+	m, _ = handleChangesCommand(m, nil)
+	if len(m.items) != 1 || m.items[0] != "Test Collection/Renamed Request" {
+		t.Errorf("Expected changes view to show the renamed path, got %v", m.items)
+	}
+
+	m = m.navigateToChangedRequest(m.changeIDs[0])
+	if m.currentItems[m.cursor].ID != itemID {
+		t.Errorf("Expected cursor on renamed request, got %s", m.currentItems[m.cursor].Name)
+	}
+}
+
+func TestDuplicateRequest_AssignsNewID(t *testing.T) {
+	m := createSavedTestModel(t)
+	original := m.currentItems[1]
+
+	m = m.duplicateRequest(original)
+
+	duplicate := m.collection.Items[len(m.collection.Items)-1]
+	if duplicate.ID == "" || duplicate.ID == original.ID {
+		t.Errorf("Expected duplicate to get a fresh ID, got %q (original %q)", duplicate.ID, original.ID)
+	}
+}
+
 func TestGetEditFieldCount(t *testing.T) {
 	m := createTestModel()
 
@@ -899,6 +988,74 @@ func TestGetCurrentFieldValue(t *testing.T) {
 	}
 }
 
+func TestEditRequest_PreservesUnmodeledFieldsOnSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "collection.json")
+	data := `{
+	"info": {
+		"_postman_id": "c1",
+		"name": "Preserved",
+		"schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+	},
+	"item": [
+		{
+			"id": "r1",
+			"name": "Request",
+			"protocolProfileBehavior": {
+				"disableBodyPruning": true
+			},
+			"request": {
+				"auth": {
+					"type": "noauth"
+				},
+				"method": "GET",
+				"header": [
+					{
+						"key": "X-Debug",
+						"value": "1",
+						"disabled": true
+					}
+				],
+				"url": {
+					"raw": "https://example.com/items?page=1",
+					"query": [
+						{
+							"key": "page",
+							"value": "1"
+						}
+					]
+				}
+			}
+		}
+	]
+}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("Failed to write collection: %v", err)
+	}
+
+	parser := postman.NewParser()
+	collection, err := parser.LoadCollection(path)
+	if err != nil {
+		t.Fatalf("Failed to load collection: %v", err)
+	}
+
+	m := NewModel(parser)
+	m.collection = collection
+	m.mode = ModeRequests
+	m = m.loadRequestsList()
+
+	m = m.enterEditMode(m.currentItems[0])
+	m.editRequest.Method = "POST"
+	m.editRequest.Header = m.parseHeaders("X-Debug: 2")
+	m = m.saveEdit()
+
+	saved, _ := os.ReadFile(path)
+	expected := strings.Replace(data, `"method": "GET"`, `"method": "POST"`, 1)
+	expected = strings.Replace(expected, `"value": "1",`, `"value": "2",`, 1)
+	if string(saved) != expected {
+		t.Errorf("Expected only the edited values to change, got:\n%s", saved)
+	}
+}
+
 func TestParseHeaders(t *testing.T) {
 	m := createTestModel()
 
@@ -1508,3 +1665,162 @@ func TestExecuteCommand_WithArguments(t *testing.T) {
 
 	_, _ = m.executeCommand()
 }
+
+func TestNewCollectionAndEnvironmentCommands(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	m := NewModel(postman.NewParser())
+
+	m, _ = handleNewCollectionCommand(m, []string{"My API " + filepath.Join(dir, "api.json")})
+	if m.mode != ModeRequests || m.collection == nil || m.collection.Info.Name != "My API" {
+		t.Fatalf("Expected new collection to open, status: %s", m.statusMessage)
+	}
+
+	m, _ = handleMkdirCommand(m, []string{"Users"})
+	m, _ = handleWriteCommand(m, nil)
+	if !contains(m.statusMessage, "Saved 1 collection") {
+		t.Errorf("Expected :w to save the new collection, got: %s", m.statusMessage)
+	}
+
+	m, _ = handleSaveAsCommand(m, []string{filepath.Join(dir, "copy.json")})
+	reloaded, err := postman.NewParser().LoadCollection(filepath.Join(dir, "copy.json"))
+	if err != nil || len(reloaded.Items) != 1 {
+		t.Errorf("Expected save as to write the collection, got %v (%s)", err, m.statusMessage)
+	}
+
+	m, _ = handleNewEnvironmentCommand(m, []string{"Local " + filepath.Join(dir, "local.json")})
+	if m.mode != ModeEnvironments || m.environment == nil || m.environment.Name != "Local" {
+		t.Fatalf("Expected new environment to be active, status: %s", m.statusMessage)
+	}
+
+	m, _ = handleNewEnvironmentCommand(m, []string{"Local"})
+	if !contains(m.statusMessage, "Usage") {
+		t.Errorf("Expected usage message, got: %s", m.statusMessage)
+	}
+}
+
+func TestExecuteRequest_ParallelRequestsMergeVariables(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			<-release
+		}
+		w.Write([]byte(r.Method))
+	}))
+	defer server.Close()
+
+	m := createSavedTestModel(t)
+	m.width, m.height = 120, 40
+	m.collection.Variables = []postman.Variable{{Key: "token", Value: "old"}}
+	setScript := func(item *postman.Item, script string) {
+		item.Request.URL.Raw = strings.Replace(item.Request.URL.Raw, "https://example.com", server.URL, 1)
+		item.Events = []postman.Event{{Listen: "test", Script: postman.Script{Exec: []string{script}}}}
+	}
+	setScript(&m.collection.Items[1], "pm.collectionVariables.set('created', pm.response.text());")
+	setScript(&m.collection.Items[0].Items[0], "pm.collectionVariables.set('fetched', pm.response.text()); pm.collectionVariables.set('token', 'new');")
+
+	m, slow := m.executeRequest(m.collection.Items[1])
+	m, fast := m.executeRequest(m.collection.Items[0].Items[0])
+	if len(m.inFlightRequests) != 2 || !contains(m.statusMessage, "(2 requests in flight)") {
+		t.Fatalf("Expected two requests in flight, got %v (%s)", m.inFlightRequests, m.statusMessage)
+	}
+	if list := m.renderItemsList(30); !contains(list, "⟳ Sending...") || contains(list, "⟳ Sending... (") {
+		t.Errorf("Expected an in-flight indicator, got:\n%s", m.renderItemsList(30))
+	}
+
+	slowDone := make(chan tea.Msg)
+	go func() { slowDone <- slow() }()
+
+	updated, _ := m.Update(fast())
+	m = updated.(Model)
+	if len(m.inFlightRequests) != 1 || m.lastResponse.Body != "GET" {
+		t.Fatalf("Expected the fast request to finish first, in flight: %v", m.inFlightRequests)
+	}
+
+	close(release)
+	updated, _ = m.Update(<-slowDone)
+	m = updated.(Model)
+	if len(m.inFlightRequests) != 0 || m.lastResponse.Body != "POST" {
+		t.Fatalf("Expected both requests to finish, in flight: %v", m.inFlightRequests)
+	}
+
+	values := map[string]string{}
+	for _, variable := range m.collection.Variables {
+		values[variable.Key] = variable.Value
+	}
+	if values["created"] != "POST" || values["fetched"] != "GET" || values["token"] != "new" || len(values) != 3 {
+		t.Errorf("Expected script writes from both requests to be merged, got %v", values)
+	}
+	if len(m.undoStack) != 2 {
+		t.Errorf("Expected one undo entry per request, got %d", len(m.undoStack))
+	}
+}
+
+func TestRequestComplete_IgnoresEarlierResponse(t *testing.T) {
+	m := createTestModel()
+	item := m.collection.Items[1]
+	itemID := m.getRequestIdentifier(item)
+
+	m, _ = m.executeRequest(item)
+	m, _ = m.executeRequest(item)
+	if m.inFlightRequests[itemID] != 2 || !contains(m.renderItemsList(30), "⟳ Sending... (2)") {
+		t.Fatalf("Expected the request to be in flight twice, got %d", m.inFlightRequests[itemID])
+	}
+
+	newer := &httpclient.Response{StatusCode: 201, Status: "201 Created"}
+	older := &httpclient.Response{StatusCode: 200, Status: "200 OK"}
+	updated, _ := m.Update(RequestCompleteMsg{ItemID: itemID, ItemName: item.Name, Response: newer, Sequence: 2})
+	m = updated.(Model)
+	updated, _ = m.Update(RequestCompleteMsg{ItemID: itemID, ItemName: item.Name, Response: older, Sequence: 1})
+	m = updated.(Model)
+
+	if m.lastResponse != newer || m.requestExecutions[itemID].Response != newer {
+		t.Errorf("Expected the newer response to stay visible")
+	}
+	if !contains(m.statusMessage, "Ignored an earlier response") {
+		t.Errorf("Unexpected status: %s", m.statusMessage)
+	}
+	if _, exists := m.inFlightRequests[itemID]; exists {
+		t.Errorf("Expected no requests in flight, got %d", m.inFlightRequests[itemID])
+	}
+}
+
+func TestRequestComplete_KeepsViewedResponseOfAnotherRequest(t *testing.T) {
+	m := createTestModel()
+	first, second := m.collection.Items[1], m.collection.Items[0].Items[0]
+	firstID := m.getRequestIdentifier(first)
+	secondID := m.getRequestIdentifierByPath(m.collection.Info.Name, []string{"Test Folder"}, second.Name)
+
+	viewed := &httpclient.Response{StatusCode: 200, Status: "200 OK", Body: "first"}
+	updated, _ := m.Update(RequestCompleteMsg{ItemID: firstID, ItemName: first.Name, Response: viewed, Sequence: 1})
+	m = updated.(Model)
+	m.mode = ModeResponse
+
+	other := &httpclient.Response{StatusCode: 201, Status: "201 Created", Body: "second"}
+	updated, _ = m.Update(RequestCompleteMsg{ItemID: secondID, ItemName: second.Name, Response: other, Sequence: 2})
+	m = updated.(Model)
+
+	if m.lastResponse != viewed || m.lastExecutedItemID != firstID {
+		t.Error("Expected the viewed response to stay on screen")
+	}
+	if m.requestExecutions[secondID].Response != other {
+		t.Error("Expected the other response to be stored")
+	}
+	if !contains(m.statusMessage, "press ctrl+r to view") {
+		t.Errorf("Unexpected status: %s", m.statusMessage)
+	}
+
+	resent := &httpclient.Response{StatusCode: 200, Status: "200 OK", Body: "first again"}
+	updated, _ = m.Update(RequestCompleteMsg{ItemID: firstID, ItemName: first.Name, Response: resent, Sequence: 3})
+	m = updated.(Model)
+	if m.lastResponse != resent {
+		t.Error("Expected a new response for the viewed request to replace it")
+	}
+
+	m.mode = ModeRequests
+	updated, _ = m.Update(RequestCompleteMsg{ItemID: secondID, ItemName: second.Name, Response: other, Sequence: 4})
+	m = updated.(Model)
+	if m.lastResponse != other {
+		t.Error("Expected the response to be shown when no response view is open")
+	}
+}
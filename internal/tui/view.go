@@ -20,6 +20,13 @@ func (m Model) View() string {
 }
 
 func (m Model) renderContent() []string {
+	if m.snippetPicker != nil {
+		return []string{m.renderSnippetPicker()}
+	}
+	if m.examplePicker != nil {
+		return []string{m.renderExamplePicker()}
+	}
+
 	switch m.mode {
 	case ModeResponse:
 		return []string{m.renderResponseView()}
@@ -42,6 +49,12 @@ func (m Model) renderContent() []string {
 	case ModeFileBrowser:
 		return []string{m.renderFileBrowser()}
 
+	case ModeRunner:
+		return []string{m.renderRunnerView()}
+
+	case ModeLoadTest:
+		return []string{m.renderLoadTestView()}
+
 	default:
 		return []string{m.renderMainWindow()}
 	}
@@ -0,0 +1,321 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+var (
+	searchMatchStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("0")).Background(lipgloss.Color("3"))
+	searchCurrentStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("0")).Background(lipgloss.Color("208")).Bold(true)
+)
+
+type viewportMatch struct {
+	line  int
+	start int
+	end   int
+}
+
+type viewportSearch struct {
+	mode    ViewMode
+	pattern string
+	matches []viewportMatch
+	current int
+}
+
+func isSearchableViewport(mode ViewMode) bool {
+	switch mode {
+	case ModeResponse, ModeInfo, ModeJSON, ModeLog:
+		return true
+	}
+	return false
+}
+
+func (m *Model) viewportFor(mode ViewMode) *viewport.Model {
+	switch mode {
+	case ModeResponse:
+		return &m.responseViewport
+	case ModeInfo:
+		return &m.infoViewport
+	case ModeJSON:
+		return &m.jsonViewport
+	case ModeLog:
+		return &m.logsViewport
+	}
+	return nil
+}
+
+func compileSearchPattern(pattern string) (*regexp.Regexp, error) {
+	prefix := ""
+	if !strings.ContainsFunc(pattern, unicode.IsUpper) {
+		prefix = "(?i)"
+	}
+
+	re, err := regexp.Compile(prefix + pattern)
+	if err != nil {
+		return regexp.MustCompile(prefix + regexp.QuoteMeta(pattern)), err
+	}
+	return re, nil
+}
+
+func findViewportMatches(lines []string, re *regexp.Regexp) []viewportMatch {
+	var matches []viewportMatch
+	for i, line := range lines {
+		for _, loc := range re.FindAllStringIndex(ansi.Strip(line), -1) {
+			if loc[0] == loc[1] {
+				continue
+			}
+			matches = append(matches, viewportMatch{line: i, start: loc[0], end: loc[1]})
+		}
+	}
+	return matches
+}
+
+func highlightViewportLines(lines []string, matches []viewportMatch, current int) []string {
+	highlighted := make([]string, len(lines))
+	copy(highlighted, lines)
+
+	for i := 0; i < len(matches); {
+		lineIndex := matches[i].line
+		var lineMatches []viewportMatch
+		var styles []lipgloss.Style
+		for ; i < len(matches) && matches[i].line == lineIndex; i++ {
+			lineMatches = append(lineMatches, matches[i])
+			if i == current {
+				styles = append(styles, searchCurrentStyle)
+			} else {
+				styles = append(styles, searchMatchStyle)
+			}
+		}
+		highlighted[lineIndex] = highlightLine(lines[lineIndex], lineMatches, styles)
+	}
+	return highlighted
+}
+
+// highlightLine renders the matches, given as byte offsets into the stripped
+// line, inside the styled line. Escape sequences outside a match are kept;
+// after each match the colours that were active at its end are re-applied so
+// syntax highlighting continues past it.
+func highlightLine(line string, matches []viewportMatch, styles []lipgloss.Style) string {
+	var out, active, matched strings.Builder
+	offset, next := 0, 0
+	inMatch := false
+	var state byte
+
+	for len(line) > 0 {
+		seq, _, n, newState := ansi.DecodeSequence(line, state, nil)
+		state = newState
+		line = line[n:]
+
+		if ansi.Strip(seq) == "" {
+			if ansi.HasCsiPrefix(seq) && strings.HasSuffix(seq, "m") {
+				if seq == "\x1b[0m" || seq == "\x1b[m" {
+					active.Reset()
+				} else {
+					active.WriteString(seq)
+				}
+			}
+			if !inMatch {
+				out.WriteString(seq)
+			}
+			continue
+		}
+
+		for text := seq; len(text) > 0; {
+			for !inMatch && next < len(matches) && matches[next].start < offset {
+				next++
+			}
+			if !inMatch && next < len(matches) && offset == matches[next].start {
+				inMatch = true
+			}
+
+			limit := len(text)
+			if inMatch {
+				limit = min(limit, matches[next].end-offset)
+			} else if next < len(matches) {
+				limit = min(limit, matches[next].start-offset)
+			}
+
+			if inMatch {
+				matched.WriteString(text[:limit])
+			} else {
+				out.WriteString(text[:limit])
+			}
+			offset += limit
+			text = text[limit:]
+
+			if inMatch && offset == matches[next].end {
+				out.WriteString(styles[next].Render(matched.String()))
+				out.WriteString(active.String())
+				matched.Reset()
+				inMatch = false
+				next++
+			}
+		}
+	}
+	return out.String()
+}
+
+func (m Model) setViewportContent(mode ViewMode, content string) Model {
+	vp := m.viewportFor(mode)
+	if vp == nil {
+		return m
+	}
+
+	if m.viewportContent == nil {
+		m.viewportContent = make(map[ViewMode]string)
+	}
+	m.viewportContent[mode] = content
+
+	if m.viewportSearch == nil || m.viewportSearch.mode != mode {
+		vp.SetContent(content)
+		return m
+	}
+
+	lines := strings.Split(content, "\n")
+	re, _ := compileSearchPattern(m.viewportSearch.pattern)
+	search := *m.viewportSearch
+	search.matches = findViewportMatches(lines, re)
+	if search.current >= len(search.matches) {
+		search.current = 0
+	}
+	m.viewportSearch = &search
+
+	vp.SetContent(strings.Join(highlightViewportLines(lines, search.matches, search.current), "\n"))
+	return m
+}
+
+func (m Model) startViewportSearch(pattern string) Model {
+	mode := m.mode
+	if pattern == "" {
+		return m.clearViewportSearch()
+	}
+
+	m.viewportSearch = &viewportSearch{mode: mode, pattern: pattern}
+	m = m.setViewportContent(mode, m.viewportContent[mode])
+
+	if _, err := compileSearchPattern(pattern); err != nil {
+		m.statusMessage = fmt.Sprintf("Invalid regex, searching literally: %v", err)
+	}
+	if len(m.viewportSearch.matches) == 0 {
+		m.statusMessage = fmt.Sprintf("Pattern not found: %s", pattern)
+		return m
+	}
+
+	vp := m.viewportFor(mode)
+	first := 0
+	for i, match := range m.viewportSearch.matches {
+		if match.line >= vp.YOffset {
+			first = i
+			break
+		}
+	}
+	return m.jumpToViewportMatch(first)
+}
+
+func (m Model) clearViewportSearch() Model {
+	if m.viewportSearch == nil {
+		return m
+	}
+
+	mode := m.viewportSearch.mode
+	m.viewportSearch = nil
+	if vp := m.viewportFor(mode); vp != nil {
+		offset := vp.YOffset
+		vp.SetContent(m.viewportContent[mode])
+		vp.SetYOffset(offset)
+	}
+	return m
+}
+
+func (m Model) jumpToViewportMatch(index int) Model {
+	search := m.viewportSearch
+	if search == nil || len(search.matches) == 0 {
+		return m
+	}
+
+	index = (index%len(search.matches) + len(search.matches)) % len(search.matches)
+	updated := *search
+	updated.current = index
+	m.viewportSearch = &updated
+
+	vp := m.viewportFor(search.mode)
+	lines := strings.Split(m.viewportContent[search.mode], "\n")
+	vp.SetContent(strings.Join(highlightViewportLines(lines, updated.matches, index), "\n"))
+
+	line := updated.matches[index].line
+	if line < vp.YOffset || line >= vp.YOffset+vp.Height {
+		vp.SetYOffset(max(line-vp.Height/3, 0))
+	}
+
+	m.statusMessage = fmt.Sprintf("/%s [%d/%d]", updated.pattern, index+1, len(updated.matches))
+	return m
+}
+
+func (m Model) viewportSearchStatus() string {
+	if m.viewportSearch == nil || m.viewportSearch.mode != m.mode {
+		return ""
+	}
+	if len(m.viewportSearch.matches) == 0 {
+		return fmt.Sprintf("/%s [no matches]", m.viewportSearch.pattern)
+	}
+	return fmt.Sprintf("/%s [%d/%d]  n/N: next/previous  esc: clear", m.viewportSearch.pattern, m.viewportSearch.current+1, len(m.viewportSearch.matches))
+}
+
+func (m Model) handleViewportSearchMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.searchMode = false
+		m.searchInput.SetValue("")
+		m.searchInput.Blur()
+		m = m.clearViewportSearch()
+		m.statusMessage = "Search cancelled"
+		return m, nil
+
+	case tea.KeyEnter:
+		m.searchMode = false
+		m.searchInput.Blur()
+		if m.searchInput.Value() == "" {
+			m = m.clearViewportSearch()
+			m.statusMessage = "Search cleared"
+		}
+		return m, nil
+
+	default:
+		m.searchInput, cmd = m.searchInput.Update(msg)
+		return m.startViewportSearch(m.searchInput.Value()), cmd
+	}
+}
+
+func handleNextMatchKey(m Model) (Model, tea.Cmd) {
+	if m.viewportSearch == nil || m.viewportSearch.mode != m.mode {
+		m.statusMessage = "No active search (press / to search)"
+		return m, nil
+	}
+	if len(m.viewportSearch.matches) == 0 {
+		m.statusMessage = fmt.Sprintf("Pattern not found: %s", m.viewportSearch.pattern)
+		return m, nil
+	}
+	return m.jumpToViewportMatch(m.viewportSearch.current + 1), nil
+}
+
+func handlePrevMatchKey(m Model) (Model, tea.Cmd) {
+	if m.viewportSearch == nil || m.viewportSearch.mode != m.mode {
+		m.statusMessage = "No active search (press / to search)"
+		return m, nil
+	}
+	if len(m.viewportSearch.matches) == 0 {
+		m.statusMessage = fmt.Sprintf("Pattern not found: %s", m.viewportSearch.pattern)
+		return m, nil
+	}
+	return m.jumpToViewportMatch(m.viewportSearch.current - 1), nil
+}
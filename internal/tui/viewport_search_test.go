@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"fmt"
+	"postOffice/internal/logger"
+	"regexp"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func createViewportSearchModel(mode ViewMode, lines []string) Model {
+	m := createTestModel()
+	m.width, m.height = 100, 30
+	m.mode = mode
+	vp := m.viewportFor(mode)
+	vp.Width, vp.Height = 80, 10
+	return m.setViewportContent(mode, strings.Join(lines, "\n"))
+}
+
+func typeSearch(m Model, pattern string) Model {
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	m = newModel.(Model)
+	for _, r := range pattern {
+		newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = newModel.(Model)
+	}
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	return newModel.(Model)
+}
+
+func TestCompileSearchPattern(t *testing.T) {
+	re, err := compileSearchPattern("user-\\d+")
+	if err != nil || !re.MatchString("USER-42") {
+		t.Error("Expected a lowercase pattern to match case-insensitively as a regex")
+	}
+
+	re, _ = compileSearchPattern("User")
+	if re.MatchString("user") {
+		t.Error("Expected a pattern with capitals to be case-sensitive")
+	}
+
+	re, err = compileSearchPattern("items[0")
+	if err == nil || !re.MatchString("items[0]") {
+		t.Error("Expected an invalid regex to fall back to a literal search")
+	}
+}
+
+func TestViewportSearch_NavigatesMatchesInAllViewports(t *testing.T) {
+	var lines []string
+	for i := 0; i < 40; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	lines[5] = "id: 5 \x1b[32mtoken\x1b[0m"
+	lines[25] = "token token"
+
+	for _, mode := range []ViewMode{ModeResponse, ModeInfo, ModeJSON, ModeLog} {
+		m := createViewportSearchModel(mode, lines)
+		m = typeSearch(m, "tok.n")
+
+		if m.viewportSearch == nil || len(m.viewportSearch.matches) != 3 {
+			t.Fatalf("mode %d: expected 3 matches, got %+v", mode, m.viewportSearch)
+		}
+		if m.statusMessage != "/tok.n [1/3]" {
+			t.Errorf("mode %d: unexpected status %q", mode, m.statusMessage)
+		}
+
+		newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+		m = newModel.(Model)
+		newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+		m = newModel.(Model)
+		vp := m.viewportFor(mode)
+		if m.viewportSearch.current != 2 || vp.YOffset > 25 || vp.YOffset+vp.Height <= 25 {
+			t.Errorf("mode %d: expected to jump to line 25, at match %d offset %d", mode, m.viewportSearch.current, vp.YOffset)
+		}
+
+		newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+		m = newModel.(Model)
+		if m.viewportSearch.current != 0 {
+			t.Errorf("mode %d: expected n to wrap around, got match %d", mode, m.viewportSearch.current)
+		}
+		newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'N'}})
+		m = newModel.(Model)
+		if m.viewportSearch.current != 2 {
+			t.Errorf("mode %d: expected N to wrap backwards, got match %d", mode, m.viewportSearch.current)
+		}
+		if !contains(m.renderCommandBar(), "/tok.n [3/3]") {
+			t.Errorf("mode %d: expected match counter in command bar", mode)
+		}
+	}
+}
+
+func TestViewportSearch_HighlightsAndClears(t *testing.T) {
+	lines := []string{`{"name": "alpha"}`, "\x1b[32m\"name\"\x1b[0m: \"beta\""}
+	highlighted := highlightViewportLines(lines, findViewportMatches(lines, regexp.MustCompile("name")), 1)
+	expected := []string{
+		`{"` + searchMatchStyle.Render("name") + `": "alpha"}`,
+		"\x1b[32m\"" + searchCurrentStyle.Render("name") + "\x1b[32m\"\x1b[0m: \"beta\"",
+	}
+	for i := range expected {
+		if highlighted[i] != expected[i] {
+			t.Errorf("Line %d: expected %q, got %q", i, expected[i], highlighted[i])
+		}
+	}
+
+	m := createViewportSearchModel(ModeJSON, lines)
+	original := m.jsonViewport.View()
+	m = typeSearch(m, "name")
+	if m.viewportSearch == nil || len(m.viewportSearch.matches) != 2 {
+		t.Fatalf("Expected two matches, got %+v", m.viewportSearch)
+	}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(Model)
+	if m.viewportSearch != nil || m.mode != ModeJSON {
+		t.Fatal("Expected esc to clear the search before closing the view")
+	}
+	if m.jsonViewport.View() != original {
+		t.Error("Expected the original content to be restored")
+	}
+}
+
+func TestViewportSearch_ReappliedWhenContentChanges(t *testing.T) {
+	m := createViewportSearchModel(ModeResponse, []string{"first"})
+	m = typeSearch(m, "second")
+	if len(m.viewportSearch.matches) != 0 || !contains(m.statusMessage, "Pattern not found") {
+		t.Fatalf("Expected no matches, status: %s", m.statusMessage)
+	}
+
+	m = m.setViewportContent(ModeResponse, "first\nsecond")
+	if len(m.viewportSearch.matches) != 1 {
+		t.Errorf("Expected search to be re-run on new content, got %d matches", len(m.viewportSearch.matches))
+	}
+}
+
+func TestLogsView_IsScrollableViewport(t *testing.T) {
+	logger.Init("")
+	for i := 0; i < 50; i++ {
+		logger.LogFileOpen(fmt.Sprintf("file-%d.json", i))
+	}
+
+	m := createTestModel()
+	m.width, m.height = 100, 30
+	m, _ = handleLogsCommand(m, nil)
+	if m.logsViewport.TotalLineCount() < 50 || m.logsViewport.Height != 16 {
+		t.Fatalf("Expected logs to be loaded into a sized viewport, got %d lines, height %d", m.logsViewport.TotalLineCount(), m.logsViewport.Height)
+	}
+
+	offset := m.logsViewport.YOffset
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	m = newModel.(Model)
+	if m.logsViewport.YOffset != offset-1 {
+		t.Errorf("Expected k to scroll the logs viewport, offset %d -> %d", offset, m.logsViewport.YOffset)
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"postOffice/internal/http"
+	"postOffice/internal/postman"
+	"postOffice/internal/runner"
+	"strings"
+	"time"
+)
+
+func runLoadTest(args []string) error {
+	flags := flag.NewFlagSet("load", flag.ExitOnError)
+	environmentName := flags.String("e", "", "environment file or name of a loaded environment")
+	itemPath := flags.String("item", "", "folder or request to load test (use / for nested folders); defaults to the whole collection")
+	var options runner.LoadOptions
+	flags.IntVar(&options.VUs, "u", 1, "number of virtual users sending requests concurrently")
+	flags.DurationVar(&options.Duration, "duration", 0, "how long to run, e.g. 30s or 1h (defaults to 30s unless -n is set)")
+	flags.IntVar(&options.Iterations, "n", 0, "total iterations across all virtual users")
+	flags.DurationVar(&options.RampUp, "ramp-up", 0, "time over which virtual users are started")
+	flags.Float64Var(&options.RPS, "rps", 0, "target requests per second across all virtual users (0 for no limit)")
+	maxErrorRate := flags.Float64("max-error-rate", 0, "fail when more than this fraction of requests fails, e.g. 0.01 for 1%")
+	maxBodyMB := flags.Int64("max-body-mb", 10, "response bodies larger than this many MB are streamed to a temp file")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: postOffice load [flags] <collection file or name>")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("expected exactly one collection")
+	}
+
+	parser := postman.NewParser()
+	if err := parser.LoadState(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load previous state: %v\n", err)
+	}
+
+	collection, err := loadRunCollection(parser, flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	environment, err := loadRunEnvironment(parser, *environmentName)
+	if err != nil {
+		return err
+	}
+
+	name := collection.Info.Name
+	var path []string
+	if *itemPath != "" {
+		path = strings.Split(strings.Trim(*itemPath, "/"), "/")
+		name += " / " + strings.Join(path, " / ")
+	}
+	targets, err := runner.FindTargets(collection, path)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		return fmt.Errorf("no requests to run")
+	}
+
+	executor := http.NewExecutor()
+	executor.SetMaxBodyBytes(*maxBodyMB * 1024 * 1024)
+	executor = executor.WithTransport(http.NewTunedTransport(options.VUs))
+
+	load := runner.NewLoad(executor, parser, collection, environment, name, targets, options)
+	fmt.Printf("Load testing %s (%s)\n", name, load.Options())
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	load.Start()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-load.Finished():
+			snapshot := load.Snapshot()
+			runner.WriteLoadSummary(os.Stdout, snapshot)
+			if snapshot.ErrorRate() > *maxErrorRate {
+				return fmt.Errorf("%d of %d requests failed (%.1f%%, allowed %.1f%%)",
+					snapshot.Failed(), snapshot.Requests, snapshot.ErrorRate()*100, *maxErrorRate*100)
+			}
+			return nil
+		case <-ticker.C:
+			fmt.Println(runner.FormatLoadProgress(load.Snapshot()))
+		case <-interrupt:
+			fmt.Println("Stopping...")
+			load.Stop()
+		}
+	}
+}
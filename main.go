@@ -19,7 +19,15 @@ func main() {
 }
 
 func run() error {
+	if len(os.Args) > 1 && os.Args[1] == "run" {
+		return runCollection(os.Args[2:])
+	}
+	if len(os.Args) > 1 && os.Args[1] == "load" {
+		return runLoadTest(os.Args[2:])
+	}
+
 	logPath := flag.String("log", "", "path to log file for debugging file operations")
+	maxBodyMB := flag.Int64("max-body-mb", 10, "response bodies larger than this many MB (and binary bodies) are streamed to a temp file instead of being kept in memory")
 	flag.Parse()
 
 	if err := logger.Init(*logPath); err != nil {
@@ -33,9 +41,14 @@ func run() error {
 	}
 
 	model := tui.NewModel(parser)
+	model.SetMaxBodyBytes(*maxBodyMB * 1024 * 1024)
 
 	p := tea.NewProgram(model, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	finalModel, err := p.Run()
+	if final, ok := finalModel.(tui.Model); ok {
+		final.RemoveResponseFiles()
+	}
+	if err != nil {
 		return fmt.Errorf("failed to run TUI: %w", err)
 	}
 
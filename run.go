@@ -0,0 +1,152 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"postOffice/internal/http"
+	"postOffice/internal/postman"
+	"postOffice/internal/runner"
+	"slices"
+	"strings"
+)
+
+type reportTargets []string
+
+func (r *reportTargets) String() string {
+	return strings.Join(*r, ",")
+}
+
+func (r *reportTargets) Set(value string) error {
+	format, path, found := strings.Cut(value, ":")
+	if !found || format == "" || path == "" {
+		return fmt.Errorf("expected format:path, e.g. junit:results.xml")
+	}
+	if !slices.Contains(runner.ReportFormats, format) {
+		return fmt.Errorf("unknown report format %q (expected %s)", format, strings.Join(runner.ReportFormats, ", "))
+	}
+	*r = append(*r, value)
+	return nil
+}
+
+func loadRunCollection(parser *postman.Parser, nameOrPath string) (*postman.Collection, error) {
+	if _, err := os.Stat(nameOrPath); err == nil {
+		return parser.LoadCollection(nameOrPath)
+	}
+	if collection, exists := parser.GetCollection(nameOrPath); exists {
+		return collection, nil
+	}
+	return nil, fmt.Errorf("collection not found: %s", nameOrPath)
+}
+
+func loadRunEnvironment(parser *postman.Parser, nameOrPath string) (*postman.Environment, error) {
+	if nameOrPath == "" {
+		return nil, nil
+	}
+	if _, err := os.Stat(nameOrPath); err == nil {
+		return parser.LoadEnvironment(nameOrPath)
+	}
+	if environment, exists := parser.GetEnvironment(nameOrPath); exists {
+		return environment, nil
+	}
+	return nil, fmt.Errorf("environment not found: %s", nameOrPath)
+}
+
+func runCollection(args []string) error {
+	flags := flag.NewFlagSet("run", flag.ExitOnError)
+	environmentName := flags.String("e", "", "environment file or name of a loaded environment")
+	dataPath := flags.String("d", "", "CSV or JSON data file; each row becomes an iteration")
+	iterations := flags.Int("n", 0, "number of iterations (defaults to the number of data rows, or 1)")
+	delay := flags.Duration("delay", 0, "time to wait between requests, e.g. 250ms")
+	bail := flags.Bool("bail", false, "stop the run at the first failed request")
+	folder := flags.String("folder", "", "only run the requests in this folder (use / for nested folders)")
+	var reports reportTargets
+	flags.Var(&reports, "report", "write a report as format:path (junit, json or html); may be repeated")
+	maxBodyMB := flags.Int64("max-body-mb", 10, "response bodies larger than this many MB are streamed to a temp file")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: postOffice run [flags] <collection file or name>")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("expected exactly one collection")
+	}
+
+	parser := postman.NewParser()
+	if err := parser.LoadState(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load previous state: %v\n", err)
+	}
+
+	collection, err := loadRunCollection(parser, flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	environment, err := loadRunEnvironment(parser, *environmentName)
+	if err != nil {
+		return err
+	}
+
+	var data []map[string]string
+	if *dataPath != "" {
+		if data, err = runner.LoadData(*dataPath); err != nil {
+			return err
+		}
+	}
+
+	var path []string
+	if *folder != "" {
+		path = strings.Split(strings.Trim(*folder, "/"), "/")
+	}
+	items, err := runner.FindFolder(collection, path)
+	if err != nil {
+		return err
+	}
+
+	executor := http.NewExecutor()
+	executor.SetMaxBodyBytes(*maxBodyMB * 1024 * 1024)
+
+	run := runner.New(executor, parser, collection, environment, runner.CollectTargets(items, path), runner.Options{
+		Iterations:    *iterations,
+		Data:          data,
+		Delay:         *delay,
+		StopOnFailure: *bail,
+	})
+	if run.Total() == 0 {
+		return fmt.Errorf("no requests to run")
+	}
+
+	fmt.Printf("Running %s\n", collection.Info.Name)
+	lastIteration := -1
+	result := run.RunAll(func(result runner.RequestResult) {
+		result.Response.RemoveBodyFile()
+
+		iteration := run.Result.Iterations[result.Iteration]
+		if result.Iteration != lastIteration {
+			lastIteration = result.Iteration
+			fmt.Printf("\nIteration %d", result.Iteration+1)
+			if len(iteration.Data) > 0 {
+				fmt.Printf(" (%s)", runner.FormatData(iteration.Data))
+			}
+			fmt.Println()
+		}
+		for _, line := range runner.FormatResult(result, iteration.Data) {
+			fmt.Println(line)
+		}
+	})
+	runner.WriteSummary(os.Stdout, result)
+
+	for _, report := range reports {
+		format, path, _ := strings.Cut(report, ":")
+		if err := runner.WriteReportFile(path, format, result); err != nil {
+			return err
+		}
+		fmt.Printf("Wrote %s report to %s\n", format, path)
+	}
+
+	if summary := result.Summary(); summary.FailedRequests > 0 {
+		return fmt.Errorf("%d of %d requests failed", summary.FailedRequests, summary.Requests)
+	}
+	return nil
+}